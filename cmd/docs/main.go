@@ -16,6 +16,9 @@ import (
 func main() {
 	log.SetOutput(ioutil.Discard)
 
+	// generate the docs without the defaults of the user config file
+	_ = os.Setenv("KP_CONFIG", "")
+
 	cmd := rootcommand.GetRootCommand()
 
 	cmd.DisableAutoGenTag = true
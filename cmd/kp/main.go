@@ -4,19 +4,40 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/rootcommand"
 )
 
 func main() {
 	log.SetOutput(ioutil.Discard)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// cancel running requests and waits on the first interrupt, a second interrupt exits immediately
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+		signal.Stop(signals)
+	}()
+
 	cmd := rootcommand.GetRootCommand()
-	err := cmd.Execute()
+	err := cmd.ExecuteContext(ctx)
 	if err != nil {
+		var exitErr commands.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
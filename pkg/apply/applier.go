@@ -0,0 +1,334 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/clusterstack"
+	"github.com/vmware-tanzu/kpack-cli/pkg/clusterstore"
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+const (
+	ActionCreated    = "created"
+	ActionConfigured = "configured"
+	ActionUnchanged  = "unchanged"
+)
+
+type Printer interface {
+	Printlnf(format string, args ...interface{}) error
+	PrintStatus(format string, args ...interface{}) error
+}
+
+type SourceUploader interface {
+	Upload(keychain authn.Keychain, ref, path string, excludes []string) (string, error)
+}
+
+type Result struct {
+	Resource Resource
+	Action   string
+}
+
+type Summary struct {
+	Results []Result
+}
+
+func (s Summary) Count(action string) int {
+	count := 0
+	for _, r := range s.Results {
+		if r.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+type Applier struct {
+	client         versioned.Interface
+	printer        Printer
+	sourceUploader SourceUploader
+	waiter         commands.ResourceWaiter
+	stackFactory   *clusterstack.Factory
+	storeFactory   *clusterstore.Factory
+	dryRun         bool
+}
+
+func NewApplier(printer Printer, client versioned.Interface, fetcher registry.Fetcher, relocator registry.Relocator, sourceUploader SourceUploader, waiter commands.ResourceWaiter, dryRun bool) *Applier {
+	return &Applier{
+		client:         client,
+		printer:        printer,
+		sourceUploader: sourceUploader,
+		waiter:         waiter,
+		stackFactory:   clusterstack.NewFactory(printer, relocator, fetcher),
+		storeFactory:   clusterstore.NewFactory(printer, relocator, fetcher),
+		dryRun:         dryRun,
+	}
+}
+
+// Apply creates or patches resources in the order given. Images, stacks and source code are uploaded
+// the same way the create commands do before the resources are applied.
+func (a *Applier) Apply(ctx context.Context, keychain authn.Keychain, kpConfig config.KpConfig, resources []Resource) (Summary, []runtime.Object, error) {
+	var (
+		summary Summary
+		objs    []runtime.Object
+	)
+
+	for _, r := range resources {
+		if err := a.printer.PrintStatus("Applying %s...", r); err != nil {
+			return summary, nil, err
+		}
+
+		if err := a.prepare(keychain, kpConfig, r); err != nil {
+			return summary, nil, errors.Wrapf(err, "failed to apply %s", r)
+		}
+
+		obj, action, err := a.apply(ctx, r.Object)
+		if err != nil {
+			return summary, nil, errors.Wrapf(err, "failed to apply %s", r)
+		}
+
+		summary.Results = append(summary.Results, Result{Resource: r, Action: action})
+		objs = append(objs, obj)
+	}
+
+	return summary, objs, nil
+}
+
+func (a *Applier) prepare(keychain authn.Keychain, kpConfig config.KpConfig, r Resource) error {
+	switch obj := r.Object.(type) {
+	case *v1alpha2.ClusterStore:
+		return a.prepareStore(keychain, kpConfig, obj, r.Dir)
+	case *v1alpha2.ClusterStack:
+		return a.prepareStack(keychain, kpConfig, obj, r.Dir)
+	case *v1alpha2.Image:
+		return a.prepareImage(keychain, obj, r.Dir)
+	}
+	return nil
+}
+
+func (a *Applier) prepareStore(keychain authn.Keychain, kpConfig config.KpConfig, store *v1alpha2.ClusterStore, dir string) error {
+	defaultRepo, err := kpConfig.DefaultRepository()
+	if err != nil {
+		return err
+	}
+
+	if err := a.printer.PrintStatus("Uploading to '%s'...", defaultRepo); err != nil {
+		return err
+	}
+
+	for i, source := range store.Spec.Sources {
+		uploaded, err := a.storeFactory.Uploader.UploadBuildpackage(keychain, localPath(dir, source.Image), defaultRepo)
+		if err != nil {
+			return err
+		}
+		store.Spec.Sources[i].Image = uploaded
+	}
+	return nil
+}
+
+func (a *Applier) prepareStack(keychain authn.Keychain, kpConfig config.KpConfig, stack *v1alpha2.ClusterStack, dir string) error {
+	buildImage := localPath(dir, stack.Spec.BuildImage.Image)
+	runImage := localPath(dir, stack.Spec.RunImage.Image)
+
+	stackID, err := a.stackFactory.Uploader.ValidateStackIDs(keychain, buildImage, runImage)
+	if err != nil {
+		return err
+	}
+
+	defaultRepo, err := kpConfig.DefaultRepository()
+	if err != nil {
+		return err
+	}
+
+	if err := a.printer.PrintStatus("Uploading to '%s'...", defaultRepo); err != nil {
+		return err
+	}
+
+	stack.Spec.Id = stackID
+	stack.Spec.BuildImage.Image, stack.Spec.RunImage.Image, err = a.stackFactory.Uploader.UploadStackImages(keychain, buildImage, runImage, defaultRepo)
+	return err
+}
+
+func (a *Applier) prepareImage(keychain authn.Keychain, img *v1alpha2.Image, dir string) error {
+	path, ok := img.Annotations[LocalPathAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(img.Annotations, LocalPathAnnotation)
+
+	ref, err := name.ParseReference(img.Spec.Tag)
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	sourceRepo := ref.Context().Name() + "-source"
+	if err := a.printer.PrintStatus("Uploading to '%s'...", sourceRepo); err != nil {
+		return err
+	}
+
+	sourceRef, err := a.sourceUploader.Upload(keychain, sourceRepo, path, nil)
+	if err != nil {
+		return err
+	}
+
+	img.Spec.Source = corev1alpha1.SourceConfig{
+		Registry: &corev1alpha1.Registry{
+			Image: sourceRef,
+		},
+		SubPath: img.Spec.Source.SubPath,
+	}
+	return nil
+}
+
+func (a *Applier) apply(ctx context.Context, desired Object) (Object, string, error) {
+	if err := k8s.SetLastAppliedCfg(desired); err != nil {
+		return nil, "", err
+	}
+
+	current, err := a.get(ctx, desired)
+	if k8serrors.IsNotFound(err) {
+		if a.dryRun {
+			return desired, ActionCreated, nil
+		}
+
+		created, err := a.create(ctx, desired)
+		if err != nil {
+			return nil, "", err
+		}
+		return created, ActionCreated, a.wait(ctx, created)
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	current.GetObjectKind().SetGroupVersionKind(desired.GetObjectKind().GroupVersionKind())
+
+	patch, err := k8s.CreateThreeWayPatch(current, desired)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if patch == nil {
+		return current, ActionUnchanged, nil
+	}
+
+	if a.dryRun {
+		patched, err := mergePatch(current, patch)
+		return patched, ActionConfigured, err
+	}
+
+	patched, err := a.patch(ctx, desired, patch)
+	if err != nil {
+		return nil, "", err
+	}
+	return patched, ActionConfigured, a.wait(ctx, patched)
+}
+
+func (a *Applier) wait(ctx context.Context, obj Object) error {
+	if _, ok := obj.(*v1alpha2.Image); ok {
+		return nil
+	}
+	return a.waiter.Wait(ctx, obj)
+}
+
+func (a *Applier) get(ctx context.Context, obj Object) (Object, error) {
+	kpack := a.client.KpackV1alpha2()
+	switch obj.(type) {
+	case *v1alpha2.ClusterStore:
+		return kpack.ClusterStores().Get(ctx, obj.GetName(), metav1.GetOptions{})
+	case *v1alpha2.ClusterStack:
+		return kpack.ClusterStacks().Get(ctx, obj.GetName(), metav1.GetOptions{})
+	case *v1alpha2.ClusterBuilder:
+		return kpack.ClusterBuilders().Get(ctx, obj.GetName(), metav1.GetOptions{})
+	case *v1alpha2.Builder:
+		return kpack.Builders(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	case *v1alpha2.Image:
+		return kpack.Images(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	}
+	return nil, errors.Errorf("unsupported type %T", obj)
+}
+
+func (a *Applier) create(ctx context.Context, obj Object) (Object, error) {
+	kpack := a.client.KpackV1alpha2()
+	switch o := obj.(type) {
+	case *v1alpha2.ClusterStore:
+		return kpack.ClusterStores().Create(ctx, o, metav1.CreateOptions{})
+	case *v1alpha2.ClusterStack:
+		return kpack.ClusterStacks().Create(ctx, o, metav1.CreateOptions{})
+	case *v1alpha2.ClusterBuilder:
+		return kpack.ClusterBuilders().Create(ctx, o, metav1.CreateOptions{})
+	case *v1alpha2.Builder:
+		return kpack.Builders(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	case *v1alpha2.Image:
+		return kpack.Images(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	}
+	return nil, errors.Errorf("unsupported type %T", obj)
+}
+
+func (a *Applier) patch(ctx context.Context, obj Object, patch []byte) (Object, error) {
+	kpack := a.client.KpackV1alpha2()
+	switch obj.(type) {
+	case *v1alpha2.ClusterStore:
+		return kpack.ClusterStores().Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	case *v1alpha2.ClusterStack:
+		return kpack.ClusterStacks().Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	case *v1alpha2.ClusterBuilder:
+		return kpack.ClusterBuilders().Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	case *v1alpha2.Builder:
+		return kpack.Builders(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	case *v1alpha2.Image:
+		return kpack.Images(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+	return nil, errors.Errorf("unsupported type %T", obj)
+}
+
+func mergePatch(obj Object, patch []byte) (Object, error) {
+	objBytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedBytes, err := jsonpatch.MergePatch(objBytes, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, _ := newObject(obj.GetObjectKind().GroupVersionKind().Kind)
+	return patched, json.Unmarshal(patchedBytes, patched)
+}
+
+// localPath resolves image references that are paths relative to the directory of the resource.
+func localPath(dir, ref string) string {
+	if ref == "" || filepath.IsAbs(ref) {
+		return ref
+	}
+
+	path := filepath.Join(dir, ref)
+	if _, err := os.Stat(path); err != nil {
+		return ref
+	}
+	return path
+}
@@ -0,0 +1,218 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// LocalPathAnnotation on an Image is the local source code directory to upload, relative to its file.
+	LocalPathAnnotation = "kpack.io/local-path"
+
+	apiVersion = "kpack.io/v1alpha2"
+	imageKind  = "Image"
+)
+
+// kindOrder is the order resources are applied in so that dependencies exist before their dependents.
+var kindOrder = []string{
+	v1alpha2.ClusterStoreKind,
+	v1alpha2.ClusterStackKind,
+	v1alpha2.ClusterBuilderKind,
+	v1alpha2.BuilderKind,
+	imageKind,
+}
+
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+type Resource struct {
+	Object Object
+	// Dir is the directory local paths of the resource are relative to.
+	Dir string
+}
+
+func (r Resource) Kind() string {
+	return r.Object.GetObjectKind().GroupVersionKind().Kind
+}
+
+func (r Resource) String() string {
+	if r.Object.GetNamespace() != "" {
+		return fmt.Sprintf("%s %q", r.Kind(), r.Object.GetNamespace()+"/"+r.Object.GetName())
+	}
+	return fmt.Sprintf("%s %q", r.Kind(), r.Object.GetName())
+}
+
+// ReadPath reads the resources in a file or in the yaml and json files of a directory.
+func ReadPath(path string) ([]Resource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files = nil
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+
+	var resources []Resource
+	for _, file := range files {
+		r, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r...)
+	}
+	return resources, nil
+}
+
+func readFile(file string) ([]Resource, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f, file, filepath.Dir(file))
+}
+
+// Read reads every resource of a yaml or json stream. Source is only used in errors.
+func Read(reader io.Reader, source, dir string) ([]Resource, error) {
+	var resources []Resource
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var doc map[string]interface{}
+		if err := decoder.Decode(&doc); err == io.EOF {
+			return resources, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to read '%s'", source)
+		}
+
+		if len(doc) == 0 {
+			continue
+		}
+
+		obj, err := decode(doc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read '%s'", source)
+		}
+
+		resources = append(resources, Resource{Object: obj, Dir: dir})
+	}
+}
+
+func decode(doc map[string]interface{}) (Object, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	obj, ok := newObject(typeMeta.Kind)
+	if !ok {
+		return nil, errors.Errorf("unsupported kind '%s', must be one of: %s", typeMeta.Kind, strings.Join(kindOrder, ", "))
+	}
+
+	if typeMeta.APIVersion != apiVersion {
+		return nil, errors.Errorf("unsupported apiVersion '%s' for %s, must be '%s'", typeMeta.APIVersion, typeMeta.Kind, apiVersion)
+	}
+
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, err
+	}
+
+	if obj.GetName() == "" {
+		return nil, errors.Errorf("%s is missing metadata.name", typeMeta.Kind)
+	}
+
+	return obj, nil
+}
+
+func newObject(kind string) (Object, bool) {
+	switch kind {
+	case v1alpha2.ClusterStoreKind:
+		return &v1alpha2.ClusterStore{}, true
+	case v1alpha2.ClusterStackKind:
+		return &v1alpha2.ClusterStack{}, true
+	case v1alpha2.ClusterBuilderKind:
+		return &v1alpha2.ClusterBuilder{}, true
+	case v1alpha2.BuilderKind:
+		return &v1alpha2.Builder{}, true
+	case imageKind:
+		return &v1alpha2.Image{}, true
+	default:
+		return nil, false
+	}
+}
+
+// Sort orders resources by kind so they can be applied in dependency order and
+// defaults the namespace of namespaced resources.
+func Sort(resources []Resource, namespace string) ([]Resource, error) {
+	sorted := make([]Resource, len(resources))
+	copy(sorted, resources)
+
+	seen := map[string]struct{}{}
+	for _, r := range sorted {
+		switch r.Kind() {
+		case v1alpha2.BuilderKind, imageKind:
+			if r.Object.GetNamespace() == "" {
+				r.Object.SetNamespace(namespace)
+			}
+		default:
+			r.Object.SetNamespace("")
+		}
+
+		if _, ok := seen[r.String()]; ok {
+			return nil, errors.Errorf("%s is defined more than once", r)
+		}
+		seen[r.String()] = struct{}{}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return kindIndex(sorted[i].Kind()) < kindIndex(sorted[j].Kind())
+	})
+	return sorted, nil
+}
+
+func kindIndex(kind string) int {
+	for i, k := range kindOrder {
+		if k == kind {
+			return i
+		}
+	}
+	return len(kindOrder)
+}
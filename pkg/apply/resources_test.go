@@ -0,0 +1,99 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/apply"
+)
+
+func TestResources(t *testing.T) {
+	spec.Run(t, "TestResources", testResources)
+}
+
+func testResources(t *testing.T, when spec.G, it spec.S) {
+	const resources = `apiVersion: kpack.io/v1alpha2
+kind: Image
+metadata:
+  name: some-image
+---
+apiVersion: kpack.io/v1alpha2
+kind: Builder
+metadata:
+  name: some-builder
+  namespace: other-namespace
+---
+---
+{"apiVersion": "kpack.io/v1alpha2", "kind": "ClusterStack", "metadata": {"name": "some-stack"}}
+---
+apiVersion: kpack.io/v1alpha2
+kind: ClusterStore
+metadata:
+  name: some-store
+  namespace: ignored
+`
+
+	when("Read", func() {
+		it("reads every resource of a multi document stream", func() {
+			r, err := apply.Read(strings.NewReader(resources), "some-file", "some-dir")
+			require.NoError(t, err)
+
+			require.Len(t, r, 4)
+			require.Equal(t, "some-dir", r[0].Dir)
+			require.Equal(t, "Image", r[0].Kind())
+			require.Equal(t, "ClusterStack", r[2].Kind())
+			require.Equal(t, "some-stack", r[2].Object.GetName())
+		})
+
+		it("errors on unsupported api versions", func() {
+			_, err := apply.Read(strings.NewReader(`apiVersion: kpack.io/v1alpha1
+kind: Image
+metadata:
+  name: some-image
+`), "some-file", "some-dir")
+			require.EqualError(t, err, "failed to read 'some-file': unsupported apiVersion 'kpack.io/v1alpha1' for Image, must be 'kpack.io/v1alpha2'")
+		})
+
+		it("errors on resources without a name", func() {
+			_, err := apply.Read(strings.NewReader(`apiVersion: kpack.io/v1alpha2
+kind: ClusterStore
+`), "some-file", "some-dir")
+			require.EqualError(t, err, "failed to read 'some-file': ClusterStore is missing metadata.name")
+		})
+	})
+
+	when("Sort", func() {
+		it("orders resources by dependency and defaults namespaces", func() {
+			r, err := apply.Read(strings.NewReader(resources), "some-file", "some-dir")
+			require.NoError(t, err)
+
+			sorted, err := apply.Sort(r, "some-namespace")
+			require.NoError(t, err)
+
+			var names []string
+			for _, resource := range sorted {
+				names = append(names, resource.String())
+			}
+			require.Equal(t, []string{
+				`ClusterStore "some-store"`,
+				`ClusterStack "some-stack"`,
+				`Builder "other-namespace/some-builder"`,
+				`Image "some-namespace/some-image"`,
+			}, names)
+		})
+
+		it("errors on duplicate resources", func() {
+			r, err := apply.Read(strings.NewReader(resources+"---\n"+resources), "some-file", "some-dir")
+			require.NoError(t, err)
+
+			_, err = apply.Sort(r, "some-namespace")
+			require.EqualError(t, err, `Image "some-namespace/some-image" is defined more than once`)
+		})
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+const KpIgnoreFile = ".kpignore"
+
+var ignoreFiles = []string{".gitignore", KpIgnoreFile}
+
+// defaultPatterns are always excluded.
+var defaultPatterns = []string{".git/"}
+
+// Ignorer excludes the paths matched by the .gitignore and .kpignore files of a directory and its
+// subdirectories, by additional patterns and by the .git directory, all in gitignore syntax.
+// The patterns of a nested ignore file only apply to the paths in its directory.
+type Ignorer struct {
+	matchers []matcher
+}
+
+type matcher struct {
+	// dir is the slash separated path of the directory of the patterns relative to the root, empty for the root
+	dir       string
+	gitIgnore *ignore.GitIgnore
+}
+
+func NewIgnorer(dir string, patterns ...string) (*Ignorer, error) {
+	lines, err := readIgnoreFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	lines = append(append(append([]string{}, defaultPatterns...), lines...), patterns...)
+	return &Ignorer{matchers: []matcher{{gitIgnore: ignore.CompileIgnoreLines(lines...)}}}, nil
+}
+
+// Ignored reports whether a path relative to the directory is excluded. A nil Ignorer excludes nothing.
+func (i *Ignorer) Ignored(relPath string, isDir bool) bool {
+	if i == nil {
+		return false
+	}
+
+	path := filepath.ToSlash(relPath)
+	for _, m := range i.matchers {
+		p := path
+		if m.dir != "" {
+			if !strings.HasPrefix(path, m.dir+"/") {
+				continue
+			}
+			p = strings.TrimPrefix(path, m.dir+"/")
+		}
+
+		if isDir {
+			p += "/"
+		}
+		if m.gitIgnore.MatchesPath(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// withDir returns an ignorer that also excludes the paths matched by the ignore files of a subdirectory.
+func (i *Ignorer) withDir(dir, relDir string) (*Ignorer, error) {
+	if i == nil {
+		return nil, nil
+	}
+
+	lines, err := readIgnoreFiles(dir)
+	if err != nil || len(lines) == 0 {
+		return i, err
+	}
+
+	matchers := append(append([]matcher{}, i.matchers...), matcher{
+		dir:       filepath.ToSlash(relDir),
+		gitIgnore: ignore.CompileIgnoreLines(lines...),
+	})
+	return &Ignorer{matchers: matchers}, nil
+}
+
+func readIgnoreFiles(dir string) ([]string, error) {
+	var lines []string
+	for _, f := range ignoreFiles {
+		buf, err := ioutil.ReadFile(filepath.Join(dir, f))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		lines = append(lines, strings.Split(string(buf), "\n")...)
+	}
+	return lines, nil
+}
+
+type File struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// ListFiles lists the regular files of a directory that are not excluded by the ignorer.
+func ListFiles(dir string, ignorer *Ignorer) ([]File, error) {
+	var files []File
+	err := walkDir(dir, ignorer, func(_, relPath string, fi os.FileInfo) error {
+		if fi.Mode().IsRegular() {
+			files = append(files, File{Path: filepath.ToSlash(relPath), Size: fi.Size(), ModTime: fi.ModTime()})
+		}
+		return nil
+	})
+	return files, err
+}
+
+func walkDir(dir string, ignorer *Ignorer, fn func(file, relPath string, fi os.FileInfo) error) error {
+	// the ignorers of the visited directories, a directory is always visited before its files
+	dirIgnorers := map[string]*Ignorer{".": ignorer}
+
+	return filepath.Walk(dir, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		} else if relPath == "." {
+			return nil
+		}
+
+		dirIgnorer := dirIgnorers[filepath.Dir(relPath)]
+		if dirIgnorer.Ignored(relPath, fi.IsDir()) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if fi.IsDir() {
+			if dirIgnorers[relPath], err = dirIgnorer.withDir(file, relPath); err != nil {
+				return err
+			}
+		}
+
+		return fn(file, relPath, fi)
+	})
+}
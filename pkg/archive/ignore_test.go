@@ -0,0 +1,167 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/archive"
+)
+
+func TestIgnore(t *testing.T) {
+	spec.Run(t, "Test Ignore", testIgnore)
+}
+
+func testIgnore(t *testing.T, when spec.G, it spec.S) {
+	var dir string
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ignore-test")
+		require.NoError(t, err)
+
+		for path, contents := range map[string]string{
+			".gitignore":           "node_modules/\n*.log\n!important.log\n",
+			".kpignore":            ".env\n",
+			".env":                 "SECRET=value",
+			"app.js":               "app",
+			"debug.log":            "debug",
+			"important.log":        "important",
+			"node_modules/dep.js":  "dep",
+			"build/output.js":      "output",
+			"src/node_modules.txt": "not a directory",
+		} {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(contents), 0644))
+		}
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	when("#ListFiles", func() {
+		it("lists the files not excluded by the ignore files and patterns", func() {
+			ignorer, err := archive.NewIgnorer(dir, "build")
+			require.NoError(t, err)
+
+			files, err := archive.ListFiles(dir, ignorer)
+			require.NoError(t, err)
+
+			sizes := map[string]int64{}
+			for _, f := range files {
+				sizes[f.Path] = f.Size
+			}
+
+			require.Len(t, files, 5)
+			require.Equal(t, map[string]int64{
+				".gitignore":           35,
+				".kpignore":            5,
+				"app.js":               3,
+				"important.log":        9,
+				"src/node_modules.txt": 15,
+			}, sizes)
+		})
+
+		it("excludes the .git directory", func() {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git", "objects"), 0755))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".git", "objects", "object"), []byte("object"), 0644))
+
+			ignorer, err := archive.NewIgnorer(dir)
+			require.NoError(t, err)
+
+			files, err := archive.ListFiles(dir, ignorer)
+			require.NoError(t, err)
+			for _, f := range files {
+				require.NotContains(t, f.Path, ".git/")
+			}
+		})
+
+		it("applies nested ignore files to their directory", func() {
+			for path, contents := range map[string]string{
+				"src/.gitignore":      "*.tmp\n",
+				"src/app.tmp":         "tmp",
+				"src/lib/.kpignore":   "generated/\n",
+				"src/lib/lib.js":      "lib",
+				"src/lib/lib.tmp":     "tmp",
+				"src/lib/generated/a": "a",
+				"app.tmp":             "tmp",
+			} {
+				require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+				require.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(contents), 0644))
+			}
+
+			ignorer, err := archive.NewIgnorer(dir, "build")
+			require.NoError(t, err)
+
+			files, err := archive.ListFiles(dir, ignorer)
+			require.NoError(t, err)
+
+			var paths []string
+			for _, f := range files {
+				paths = append(paths, f.Path)
+			}
+			require.Equal(t, []string{
+				".gitignore",
+				".kpignore",
+				"app.js",
+				"app.tmp",
+				"important.log",
+				"src/.gitignore",
+				"src/lib/.kpignore",
+				"src/lib/lib.js",
+				"src/node_modules.txt",
+			}, paths)
+		})
+
+		it("lists every file without an ignorer", func() {
+			files, err := archive.ListFiles(dir, nil)
+			require.NoError(t, err)
+			require.Len(t, files, 9)
+		})
+	})
+
+	when("#CreateTar", func() {
+		it("does not archive excluded files", func() {
+			ignorer, err := archive.NewIgnorer(dir, "*.js")
+			require.NoError(t, err)
+
+			tarPath, err := archive.CreateTar(dir, ignorer)
+			require.NoError(t, err)
+			defer os.Remove(tarPath)
+
+			f, err := os.Open(tarPath)
+			require.NoError(t, err)
+			defer f.Close()
+
+			var names []string
+			tr := tar.NewReader(f)
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				names = append(names, header.Name)
+			}
+
+			require.Equal(t, []string{
+				"/.gitignore",
+				"/.kpignore",
+				"/build",
+				"/important.log",
+				"/src",
+				"/src/node_modules.txt",
+			}, names)
+		})
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -17,7 +18,9 @@ var (
 	normalizedTime = time.Date(1980, time.January, 1, 0, 0, 1, 0, time.UTC)
 )
 
-func CreateTar(path string) (string, error) {
+// CreateTar archives a directory reproducibly. Entries are written in lexical order with normalized times
+// and owners, so the same files always produce the same tar.
+func CreateTar(path string, ignorer *Ignorer) (string, error) {
 	fh, err := ioutil.TempFile("", "")
 	if err != nil {
 		return "", fmt.Errorf("create file for tar: %s", err)
@@ -27,13 +30,24 @@ func CreateTar(path string) (string, error) {
 	tw := tar.NewWriter(fh)
 	defer tw.Close()
 
-	if err := writeDirToTar(tw, path, "/", 0, 0, -1); err != nil {
+	if err := writeDirToTar(tw, path, "/", 0, 0, -1, ignorer); err != nil {
 		return "", err
 	}
 
 	return fh.Name(), nil
 }
 
+func WriteTar(srcDir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	if err := writeDirToTar(tw, srcDir, "", 0, 0, -1, nil); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// ReadTar extracts the directories and regular files of a tar into dir. Links are skipped and entries
+// outside of dir are rejected, so an untrusted tar cannot write anywhere else.
 func ReadTar(reader io.Reader, dir string) error {
 	tarReader := tar.NewReader(reader)
 	for {
@@ -44,7 +58,11 @@ func ReadTar(reader io.Reader, dir string) error {
 			return err
 		}
 
-		filePath := filepath.Join(dir, header.Name)
+		filePath, err := tarEntryPath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+
 		switch header.Typeflag {
 		case tar.TypeDir:
 			err := os.MkdirAll(filePath, os.FileMode(header.Mode))
@@ -52,12 +70,7 @@ func ReadTar(reader io.Reader, dir string) error {
 				return err
 			}
 		case tar.TypeReg:
-			outFile, err := os.Create(filePath)
-			if err != nil {
-				return err
-			}
-			defer outFile.Close()
-			if _, err := io.Copy(outFile, tarReader); err != nil {
+			if err := readTarFile(tarReader, filePath); err != nil {
 				return err
 			}
 		}
@@ -65,19 +78,39 @@ func ReadTar(reader io.Reader, dir string) error {
 	return nil
 }
 
-func writeDirToTar(tw *tar.Writer, srcDir, basePath string, uid, gid int, mode int64) error {
-	return filepath.Walk(srcDir, func(file string, fi os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+func tarEntryPath(dir, name string) (string, error) {
+	filePath := filepath.Join(dir, name)
+	rel, err := filepath.Rel(dir, filePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("tar entry %q is outside of the extraction directory", name)
+	}
+	return filePath, nil
+}
 
+func readTarFile(reader io.Reader, filePath string) error {
+	outFile, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, reader)
+	return err
+}
+
+func writeDirToTar(tw *tar.Writer, srcDir, basePath string, uid, gid int, mode int64, ignorer *Ignorer) error {
+	return walkDir(srcDir, ignorer, func(file, relPath string, fi os.FileInfo) error {
 		if fi.Mode()&os.ModeSocket != 0 {
 			return nil
 		}
 
-		var header *tar.Header
+		var (
+			header *tar.Header
+			err    error
+		)
 		if fi.Mode()&os.ModeSymlink != 0 {
-			target, err := os.Readlink(file)
+			var target string
+			target, err = os.Readlink(file)
 			if err != nil {
 				return err
 			}
@@ -93,13 +126,6 @@ func writeDirToTar(tw *tar.Writer, srcDir, basePath string, uid, gid int, mode i
 			}
 		}
 
-		relPath, err := filepath.Rel(srcDir, file)
-		if err != nil {
-			return err
-		} else if relPath == "." {
-			return nil
-		}
-
 		header.Name = filepath.ToSlash(filepath.Join(basePath, relPath))
 		finalizeHeader(header, uid, gid, mode)
 
@@ -133,4 +159,6 @@ func finalizeHeader(header *tar.Header, uid, gid int, mode int64) {
 	header.Gname = ""
 
 	header.ModTime = normalizedTime
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
 }
@@ -0,0 +1,100 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/archive"
+)
+
+func TestTar(t *testing.T) {
+	spec.Run(t, "Test Tar operations", testTar)
+}
+
+func testTar(t *testing.T, when spec.G, it spec.S) {
+	when("#ReadTar", func() {
+		var (
+			parentDir string
+			dir       string
+		)
+
+		it.Before(func() {
+			var err error
+			parentDir, err = ioutil.TempDir("", "read-tar")
+			require.NoError(t, err)
+
+			dir = filepath.Join(parentDir, "extracted")
+			require.NoError(t, os.Mkdir(dir, 0755))
+		})
+
+		it.After(func() {
+			require.NoError(t, os.RemoveAll(parentDir))
+		})
+
+		type entry struct {
+			header  tar.Header
+			content string
+		}
+
+		makeTar := func(entries ...entry) *bytes.Buffer {
+			buf := &bytes.Buffer{}
+			tw := tar.NewWriter(buf)
+			for _, e := range entries {
+				header := e.header
+				header.Size = int64(len(e.content))
+				require.NoError(t, tw.WriteHeader(&header))
+				_, err := tw.Write([]byte(e.content))
+				require.NoError(t, err)
+			}
+			require.NoError(t, tw.Close())
+			return buf
+		}
+
+		it("extracts directories and files", func() {
+			tarFile := makeTar(
+				entry{header: tar.Header{Name: "some-dir", Typeflag: tar.TypeDir, Mode: 0755}},
+				entry{header: tar.Header{Name: "some-dir/some-file", Typeflag: tar.TypeReg, Mode: 0644}, content: "some-content"},
+			)
+
+			require.NoError(t, archive.ReadTar(tarFile, dir))
+
+			content, err := ioutil.ReadFile(filepath.Join(dir, "some-dir", "some-file"))
+			require.NoError(t, err)
+			require.Equal(t, "some-content", string(content))
+		})
+
+		it("rejects entries outside of the directory", func() {
+			tarFile := makeTar(
+				entry{header: tar.Header{Name: "../escaped-file", Typeflag: tar.TypeReg, Mode: 0644}, content: "some-content"},
+			)
+
+			require.EqualError(t, archive.ReadTar(tarFile, dir), `tar entry "../escaped-file" is outside of the extraction directory`)
+
+			_, err := os.Stat(filepath.Join(parentDir, "escaped-file"))
+			require.True(t, os.IsNotExist(err))
+		})
+
+		it("skips symlinks and hardlinks", func() {
+			tarFile := makeTar(
+				entry{header: tar.Header{Name: "some-symlink", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}},
+				entry{header: tar.Header{Name: "some-hardlink", Typeflag: tar.TypeLink, Linkname: "/etc/passwd"}},
+			)
+
+			require.NoError(t, archive.ReadTar(tarFile, dir))
+
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			require.Empty(t, files)
+		})
+	})
+}
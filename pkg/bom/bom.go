@@ -0,0 +1,148 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bom
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	RawFormat       = "raw"
+	CycloneDXFormat = "cyclonedx"
+	SPDXFormat      = "spdx"
+)
+
+var Formats = []string{RawFormat, CycloneDXFormat, SPDXFormat}
+
+// Entry is a bill of materials entry as written by buildpacks to the io.buildpacks.build.metadata label.
+type Entry struct {
+	Name      string                 `json:"name"`
+	Version   string                 `json:"version,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Buildpack Buildpack              `json:"buildpack"`
+}
+
+type Buildpack struct {
+	Id      string `json:"id"`
+	Version string `json:"version,omitempty"`
+}
+
+// Subject is the built image the bill of materials describes.
+type Subject struct {
+	Name    string
+	Digest  string
+	Created time.Time
+}
+
+// Convert converts the raw buildpack bill of materials into the format.
+func Convert(format string, subject Subject, raw json.RawMessage) ([]byte, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+
+	if format == RawFormat {
+		return raw, nil
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, errors.Wrap(err, "bill of materials is not a list of buildpack entries")
+	}
+
+	if format == CycloneDXFormat {
+		return json.MarshalIndent(newCycloneDX(subject, entries), "", "  ")
+	}
+	return json.MarshalIndent(newSPDX(subject, entries), "", "  ")
+}
+
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return errors.Errorf("unsupported bom format '%s', must be one of: %s", format, strings.Join(Formats, ", "))
+}
+
+func (e Entry) version() string {
+	if e.Version != "" {
+		return e.Version
+	}
+	return e.stringMetadata("version")
+}
+
+func (e Entry) stringMetadata(key string) string {
+	if s, ok := e.Metadata[key].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// licenses reads license types from the metadata, which buildpacks write as strings or as tables with a type.
+func (e Entry) licenses() []string {
+	values, ok := e.Metadata["licenses"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var licenses []string
+	for _, v := range values {
+		switch l := v.(type) {
+		case string:
+			licenses = append(licenses, l)
+		case map[string]interface{}:
+			if t, ok := l["type"].(string); ok && t != "" {
+				licenses = append(licenses, t)
+			}
+		}
+	}
+	return licenses
+}
+
+var knownMetadata = map[string]bool{
+	"version":  true,
+	"licenses": true,
+	"purl":     true,
+	"cpe":      true,
+	"sha256":   true,
+	"uri":      true,
+}
+
+// otherMetadata returns the metadata without a dedicated field in the target formats, sorted by key.
+func (e Entry) otherMetadata() ([]string, map[string]string) {
+	var keys []string
+	values := map[string]string{}
+	for k, v := range e.Metadata {
+		if knownMetadata[k] {
+			continue
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			s = string(b)
+		}
+
+		keys = append(keys, k)
+		values[k] = s
+	}
+	sort.Strings(keys)
+	return keys, values
+}
+
+func (b Buildpack) String() string {
+	if b.Version == "" {
+		return b.Id
+	}
+	return fmt.Sprintf("%s@%s", b.Id, b.Version)
+}
@@ -0,0 +1,221 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bom_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/bom"
+)
+
+func TestConvert(t *testing.T) {
+	spec.Run(t, "TestConvert", testConvert)
+}
+
+func testConvert(t *testing.T, when spec.G, it spec.S) {
+	const raw = `[
+  {
+    "name": "openjdk-jre",
+    "metadata": {
+      "version": "11.0.12",
+      "licenses": [{"type": "GPL-2.0-only", "uri": "https://openjdk.java.net/legal/gplv2+ce.html"}],
+      "purl": "pkg:generic/openjdk-jre@11.0.12",
+      "cpe": "cpe:2.3:a:oracle:jre:11.0.12:*:*:*:*:*:*:*",
+      "sha256": "abc123",
+      "uri": "https://example.com/jre.tar.gz",
+      "layer": "jre",
+      "stacks": ["io.buildpacks.stacks.bionic"]
+    },
+    "buildpack": {"id": "paketo-buildpacks/bellsoft-liberica", "version": "8.5.0"}
+  },
+  {
+    "name": "spring-boot",
+    "version": "2.5.4",
+    "metadata": {"licenses": ["Apache-2.0", "MIT"]},
+    "buildpack": {"id": "paketo-buildpacks/spring-boot"}
+  }
+]`
+
+	subject := bom.Subject{
+		Name:    "some-registry.io/some-image",
+		Digest:  "sha256:image-digest",
+		Created: time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	it("returns the raw bill of materials unchanged", func() {
+		out, err := bom.Convert(bom.RawFormat, subject, json.RawMessage(`{"some":"metadata"}`))
+		require.NoError(t, err)
+		require.Equal(t, `{"some":"metadata"}`, string(out))
+	})
+
+	it("converts to CycloneDX", func() {
+		out, err := bom.Convert(bom.CycloneDXFormat, subject, json.RawMessage(raw))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.3",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2021-06-01T10:00:00Z",
+    "tools": [{"name": "kp"}],
+    "component": {"type": "container", "name": "some-registry.io/some-image", "version": "sha256:image-digest"}
+  },
+  "components": [
+    {
+      "type": "library",
+      "name": "openjdk-jre",
+      "version": "11.0.12",
+      "licenses": [{"license": {"id": "GPL-2.0-only"}}],
+      "purl": "pkg:generic/openjdk-jre@11.0.12",
+      "cpe": "cpe:2.3:a:oracle:jre:11.0.12:*:*:*:*:*:*:*",
+      "hashes": [{"alg": "SHA-256", "content": "abc123"}],
+      "externalReferences": [{"type": "distribution", "url": "https://example.com/jre.tar.gz"}],
+      "properties": [
+        {"name": "kp:buildpack", "value": "paketo-buildpacks/bellsoft-liberica@8.5.0"},
+        {"name": "kp:metadata:layer", "value": "jre"},
+        {"name": "kp:metadata:stacks", "value": "[\"io.buildpacks.stacks.bionic\"]"}
+      ]
+    },
+    {
+      "type": "library",
+      "name": "spring-boot",
+      "version": "2.5.4",
+      "licenses": [{"license": {"id": "Apache-2.0"}}, {"license": {"id": "MIT"}}],
+      "properties": [{"name": "kp:buildpack", "value": "paketo-buildpacks/spring-boot"}]
+    }
+  ]
+}`, string(out))
+	})
+
+	it("writes CycloneDX licenses that are not SPDX ids as names or expressions", func() {
+		const licenses = `[
+  {
+    "name": "some-dependency",
+    "metadata": {"licenses": ["Apache-2.0", "The Apache Software License", "MIT OR Apache-2.0"]},
+    "buildpack": {"id": "some-buildpack"}
+  }
+]`
+
+		out, err := bom.Convert(bom.CycloneDXFormat, subject, json.RawMessage(licenses))
+		require.NoError(t, err)
+
+		var parsed struct {
+			Components []struct {
+				Licenses json.RawMessage `json:"licenses"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(out, &parsed))
+		require.Len(t, parsed.Components, 1)
+		require.JSONEq(t, `[
+  {"license": {"id": "Apache-2.0"}},
+  {"license": {"name": "The Apache Software License"}},
+  {"expression": "MIT OR Apache-2.0"}
+]`, string(parsed.Components[0].Licenses))
+	})
+
+	it("writes SPDX licenses that are not SPDX ids as license refs and groups expressions", func() {
+		const licenses = `[
+  {
+    "name": "some-dependency",
+    "metadata": {"licenses": ["Apache-2.0", "Apache License 2.0", "MIT OR Apache-2.0"]},
+    "buildpack": {"id": "some-buildpack"}
+  },
+  {
+    "name": "other-dependency",
+    "metadata": {"licenses": ["Apache License 2.0"]},
+    "buildpack": {"id": "some-buildpack"}
+  }
+]`
+
+		out, err := bom.Convert(bom.SPDXFormat, subject, json.RawMessage(licenses))
+		require.NoError(t, err)
+
+		var parsed struct {
+			Packages []struct {
+				LicenseDeclared string `json:"licenseDeclared"`
+			} `json:"packages"`
+			HasExtractedLicensingInfos json.RawMessage `json:"hasExtractedLicensingInfos"`
+		}
+		require.NoError(t, json.Unmarshal(out, &parsed))
+		require.Len(t, parsed.Packages, 3)
+		require.Equal(t, "Apache-2.0 AND LicenseRef-1-Apache-License-2.0 AND (MIT OR Apache-2.0)", parsed.Packages[1].LicenseDeclared)
+		require.Equal(t, "LicenseRef-1-Apache-License-2.0", parsed.Packages[2].LicenseDeclared)
+		require.JSONEq(t, `[
+  {"licenseId": "LicenseRef-1-Apache-License-2.0", "name": "Apache License 2.0", "extractedText": "Apache License 2.0"}
+]`, string(parsed.HasExtractedLicensingInfos))
+	})
+
+	it("converts to SPDX", func() {
+		out, err := bom.Convert(bom.SPDXFormat, subject, json.RawMessage(raw))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "spdxVersion": "SPDX-2.2",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "some-registry.io/some-image",
+  "documentNamespace": "https://kpack.io/spdx/some-registry.io/some-image@sha256:image-digest",
+  "creationInfo": {"created": "2021-06-01T10:00:00Z", "creators": ["Tool: kp"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Image",
+      "name": "some-registry.io/some-image",
+      "versionInfo": "sha256:image-digest",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false,
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "NOASSERTION",
+      "copyrightText": "NOASSERTION"
+    },
+    {
+      "SPDXID": "SPDXRef-Package-1",
+      "name": "openjdk-jre",
+      "versionInfo": "11.0.12",
+      "downloadLocation": "https://example.com/jre.tar.gz",
+      "filesAnalyzed": false,
+      "checksums": [{"algorithm": "SHA256", "checksumValue": "abc123"}],
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "GPL-2.0-only",
+      "copyrightText": "NOASSERTION",
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:generic/openjdk-jre@11.0.12"},
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:oracle:jre:11.0.12:*:*:*:*:*:*:*"}
+      ],
+      "comment": "buildpack: paketo-buildpacks/bellsoft-liberica@8.5.0\nlayer: jre\nstacks: [\"io.buildpacks.stacks.bionic\"]"
+    },
+    {
+      "SPDXID": "SPDXRef-Package-2",
+      "name": "spring-boot",
+      "versionInfo": "2.5.4",
+      "downloadLocation": "NOASSERTION",
+      "filesAnalyzed": false,
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "Apache-2.0 AND MIT",
+      "copyrightText": "NOASSERTION",
+      "comment": "buildpack: paketo-buildpacks/spring-boot"
+    }
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Image"},
+    {"spdxElementId": "SPDXRef-Image", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Package-1"},
+    {"spdxElementId": "SPDXRef-Image", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Package-2"}
+  ]
+}`, string(out))
+	})
+
+	it("errors when the bill of materials is not a list of entries", func() {
+		_, err := bom.Convert(bom.SPDXFormat, subject, json.RawMessage(`{"some":"metadata"}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "bill of materials is not a list of buildpack entries")
+	})
+
+	it("errors on unsupported formats", func() {
+		_, err := bom.Convert("xml", subject, json.RawMessage(raw))
+		require.EqualError(t, err, "unsupported bom format 'xml', must be one of: raw, cyclonedx, spdx")
+	})
+}
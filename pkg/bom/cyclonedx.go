@@ -0,0 +1,130 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bom
+
+import (
+	"time"
+)
+
+const cycloneDXSpecVersion = "1.3"
+
+type cycloneDX struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp,omitempty"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	Type               string                       `json:"type"`
+	Name               string                       `json:"name"`
+	Version            string                       `json:"version,omitempty"`
+	Licenses           []cycloneDXLicenseChoice     `json:"licenses,omitempty"`
+	Purl               string                       `json:"purl,omitempty"`
+	Cpe                string                       `json:"cpe,omitempty"`
+	Hashes             []cycloneDXHash              `json:"hashes,omitempty"`
+	ExternalReferences []cycloneDXExternalReference `json:"externalReferences,omitempty"`
+	Properties         []cycloneDXProperty          `json:"properties,omitempty"`
+}
+
+// cycloneDXLicenseChoice is either a license or an SPDX license expression.
+type cycloneDXLicenseChoice struct {
+	License    *cycloneDXLicense `json:"license,omitempty"`
+	Expression string            `json:"expression,omitempty"`
+}
+
+// cycloneDXLicense has an SPDX license id or, for other licenses, a name.
+type cycloneDXLicense struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXExternalReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func newCycloneDX(subject Subject, entries []Entry) cycloneDX {
+	doc := cycloneDX{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Tools: []cycloneDXTool{{Name: "kp"}},
+			Component: cycloneDXComponent{
+				Type:    "container",
+				Name:    subject.Name,
+				Version: subject.Digest,
+			},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	if !subject.Created.IsZero() {
+		doc.Metadata.Timestamp = subject.Created.UTC().Format(time.RFC3339)
+	}
+
+	for _, e := range entries {
+		c := cycloneDXComponent{
+			Type:    "library",
+			Name:    e.Name,
+			Version: e.version(),
+			Purl:    e.stringMetadata("purl"),
+			Cpe:     e.stringMetadata("cpe"),
+		}
+
+		for _, l := range e.licenses() {
+			c.Licenses = append(c.Licenses, newCycloneDXLicenseChoice(l))
+		}
+
+		if sha := e.stringMetadata("sha256"); sha != "" {
+			c.Hashes = []cycloneDXHash{{Alg: "SHA-256", Content: sha}}
+		}
+
+		if uri := e.stringMetadata("uri"); uri != "" {
+			c.ExternalReferences = []cycloneDXExternalReference{{Type: "distribution", URL: uri}}
+		}
+
+		c.Properties = append(c.Properties, cycloneDXProperty{Name: "kp:buildpack", Value: e.Buildpack.String()})
+		keys, values := e.otherMetadata()
+		for _, k := range keys {
+			c.Properties = append(c.Properties, cycloneDXProperty{Name: "kp:metadata:" + k, Value: values[k]})
+		}
+
+		doc.Components = append(doc.Components, c)
+	}
+
+	return doc
+}
+
+func newCycloneDXLicenseChoice(license string) cycloneDXLicenseChoice {
+	switch {
+	case isSPDXLicenseID(license):
+		return cycloneDXLicenseChoice{License: &cycloneDXLicense{Id: license}}
+	case isSPDXExpression(license):
+		return cycloneDXLicenseChoice{Expression: license}
+	default:
+		return cycloneDXLicenseChoice{License: &cycloneDXLicense{Name: license}}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	spdxVersion    = "SPDX-2.2"
+	spdxNoAssert   = "NOASSERTION"
+	spdxDocumentId = "SPDXRef-DOCUMENT"
+	spdxImageId    = "SPDXRef-Image"
+)
+
+// spdxIdChars matches the characters that are not allowed in SPDX ids.
+var spdxIdChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+type spdx struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+
+	HasExtractedLicensingInfos []spdxExtractedLicense `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+// spdxExtractedLicense declares a license that is not on the SPDX license list, it is referenced by its LicenseRef id.
+type spdxExtractedLicense struct {
+	LicenseId     string `json:"licenseId"`
+	Name          string `json:"name"`
+	ExtractedText string `json:"extractedText"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+	Comment          string            `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func newSPDX(subject Subject, entries []Entry) spdx {
+	created := subject.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	doc := spdx{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentId,
+		Name:              subject.Name,
+		DocumentNamespace: fmt.Sprintf("https://kpack.io/spdx/%s@%s", subject.Name, subject.Digest),
+		CreationInfo: spdxCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: kp"},
+		},
+		Packages: []spdxPackage{{
+			SPDXID:           spdxImageId,
+			Name:             subject.Name,
+			VersionInfo:      subject.Digest,
+			DownloadLocation: spdxNoAssert,
+			LicenseConcluded: spdxNoAssert,
+			LicenseDeclared:  spdxNoAssert,
+			CopyrightText:    spdxNoAssert,
+		}},
+		Relationships: []spdxRelationship{{
+			SPDXElementID:      spdxDocumentId,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: spdxImageId,
+		}},
+	}
+
+	for i, e := range entries {
+		p := spdxPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			Name:             e.Name,
+			VersionInfo:      e.version(),
+			DownloadLocation: spdxNoAssert,
+			LicenseConcluded: spdxNoAssert,
+			LicenseDeclared:  spdxNoAssert,
+			CopyrightText:    spdxNoAssert,
+		}
+
+		if uri := e.stringMetadata("uri"); uri != "" {
+			p.DownloadLocation = uri
+		}
+
+		if licenses := e.licenses(); len(licenses) > 0 {
+			p.LicenseDeclared = doc.licenseExpression(licenses)
+		}
+
+		if sha := e.stringMetadata("sha256"); sha != "" {
+			p.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: sha}}
+		}
+
+		if purl := e.stringMetadata("purl"); purl != "" {
+			p.ExternalRefs = append(p.ExternalRefs, spdxExternalRef{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl})
+		}
+		if cpe := e.stringMetadata("cpe"); cpe != "" {
+			p.ExternalRefs = append(p.ExternalRefs, spdxExternalRef{ReferenceCategory: "SECURITY", ReferenceType: "cpe23Type", ReferenceLocator: cpe})
+		}
+
+		comment := []string{"buildpack: " + e.Buildpack.String()}
+		keys, values := e.otherMetadata()
+		for _, k := range keys {
+			comment = append(comment, fmt.Sprintf("%s: %s", k, values[k]))
+		}
+		p.Comment = strings.Join(comment, "\n")
+
+		doc.Packages = append(doc.Packages, p)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      spdxImageId,
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: p.SPDXID,
+		})
+	}
+
+	return doc
+}
+
+// licenseExpression joins the licenses into a single SPDX license expression. Licenses that are not SPDX
+// ids or expressions are referenced by a LicenseRef id and added to the extracted licenses of the document.
+func (doc *spdx) licenseExpression(licenses []string) string {
+	var terms []string
+	for _, l := range licenses {
+		switch {
+		case isSPDXLicenseID(l):
+			terms = append(terms, l)
+		case isSPDXExpression(l) && len(licenses) > 1:
+			terms = append(terms, "("+l+")")
+		case isSPDXExpression(l):
+			terms = append(terms, l)
+		default:
+			terms = append(terms, doc.licenseRef(l))
+		}
+	}
+	return strings.Join(terms, " AND ")
+}
+
+func (doc *spdx) licenseRef(name string) string {
+	for _, l := range doc.HasExtractedLicensingInfos {
+		if l.Name == name {
+			return l.LicenseId
+		}
+	}
+
+	id := fmt.Sprintf("LicenseRef-%d-%s", len(doc.HasExtractedLicensingInfos)+1, spdxIdChars.ReplaceAllString(name, "-"))
+	doc.HasExtractedLicensingInfos = append(doc.HasExtractedLicensingInfos, spdxExtractedLicense{
+		LicenseId:     id,
+		Name:          name,
+		ExtractedText: name,
+	})
+	return id
+}
@@ -0,0 +1,47 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package bom
+
+import "strings"
+
+// spdxLicenseIDs are the SPDX license identifiers of the licenses commonly found in buildpack dependencies.
+// Other licenses are written by name, which is always valid.
+var spdxLicenseIDs = toSet(
+	"0BSD", "AFL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-1.1", "Apache-2.0", "Artistic-2.0",
+	"BlueOak-1.0.0", "BSD-1-Clause", "BSD-2-Clause", "BSD-2-Clause-Patent", "BSD-3-Clause", "BSD-4-Clause",
+	"BSL-1.0", "bzip2-1.0.6", "CC-BY-3.0", "CC-BY-4.0", "CC-BY-SA-3.0", "CC-BY-SA-4.0", "CC0-1.0",
+	"CDDL-1.0", "CDDL-1.1", "CPL-1.0", "curl", "ECL-2.0", "EPL-1.0", "EPL-2.0", "EUPL-1.1", "EUPL-1.2",
+	"GFDL-1.3-only", "GFDL-1.3-or-later", "GPL-1.0-only", "GPL-1.0-or-later", "GPL-2.0-only",
+	"GPL-2.0-or-later", "GPL-3.0-only", "GPL-3.0-or-later", "ICU", "IJG", "ISC", "LGPL-2.0-only",
+	"LGPL-2.0-or-later", "LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0-only", "LGPL-3.0-or-later",
+	"Libpng", "MIT", "MIT-0", "MPL-1.1", "MPL-2.0", "MS-PL", "NCSA", "OFL-1.1", "OpenSSL", "PHP-3.01",
+	"PostgreSQL", "PSF-2.0", "Python-2.0", "Ruby", "Unicode-DFS-2016", "Unlicense", "UPL-1.0", "W3C",
+	"WTFPL", "X11", "Zlib", "ZPL-2.1",
+)
+
+// spdxExpressionOperators join license identifiers in an SPDX license expression.
+var spdxExpressionOperators = []string{" AND ", " OR ", " WITH ", "("}
+
+// isSPDXLicenseID reports whether the license is a known SPDX license identifier.
+func isSPDXLicenseID(license string) bool {
+	return spdxLicenseIDs[license]
+}
+
+// isSPDXExpression reports whether the license combines licenses with SPDX operators, e.g. "MIT OR Apache-2.0".
+func isSPDXExpression(license string) bool {
+	for _, op := range spdxExpressionOperators {
+		if strings.Contains(license, op) {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(values ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
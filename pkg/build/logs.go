@@ -0,0 +1,273 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Phases are the build pod containers that logs can be filtered by.
+var Phases = []string{"prepare", "detect", "analyze", "restore", "build", "export", "rebase", "completion"}
+
+type LogOptions struct {
+	Phase      string
+	Follow     bool
+	Timestamps bool
+	Prefix     bool
+	Color      bool
+	// Running streams every build that is running instead of exiting once a build pod completes.
+	Running bool
+}
+
+func (o LogOptions) Validate() error {
+	if o.Phase == "" {
+		return nil
+	}
+
+	for _, phase := range Phases {
+		if o.Phase == phase {
+			return nil
+		}
+	}
+	return errors.Errorf("invalid phase '%s', must be one of: %s", o.Phase, strings.Join(Phases, ", "))
+}
+
+type LogsClient struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewLogsClient(k8sClient kubernetes.Interface) *LogsClient {
+	return &LogsClient{k8sClient: k8sClient}
+}
+
+func ImageBuildSelector(image, buildNumber string) string {
+	return fmt.Sprintf("%s=%s,%s=%s", v1alpha2.ImageLabel, image, v1alpha2.BuildNumberLabel, buildNumber)
+}
+
+func AllBuildsSelector() string {
+	return v1alpha2.BuildLabel
+}
+
+// Tail prints the logs of the build pods matching selector. Containers of a pod are printed in order
+// while pods are streamed concurrently.
+func (c *LogsClient) Tail(ctx context.Context, writer io.Writer, namespace, selector string, opts LogOptions) error {
+	t := &podTailer{
+		client:    c.k8sClient,
+		ctx:       ctx,
+		out:       &lineWriter{writer: writer},
+		opts:      opts,
+		queues:    map[string]chan string{},
+		processed: map[string]struct{}{},
+	}
+	defer t.wait()
+
+	pods, err := c.k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		if t.handle(&pods.Items[i]) && !opts.Running {
+			return t.wait()
+		}
+	}
+
+	if !opts.Follow {
+		return t.wait()
+	}
+
+	watcher, err := c.k8sClient.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector:   selector,
+		ResourceVersion: pods.ResourceVersion,
+	})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return t.wait()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return t.wait()
+			}
+
+			pod, ok := event.Object.(*corev1.Pod)
+			if !ok || (event.Type != watch.Added && event.Type != watch.Modified) {
+				continue
+			}
+
+			if t.handle(pod) && !opts.Running {
+				return t.wait()
+			}
+		}
+	}
+}
+
+type podTailer struct {
+	client kubernetes.Interface
+	ctx    context.Context
+	out    *lineWriter
+	opts   LogOptions
+
+	wg        sync.WaitGroup
+	queues    map[string]chan string
+	processed map[string]struct{}
+
+	errOnce sync.Once
+	err     error
+}
+
+// handle queues the started containers of the pod and returns whether the pod has finished.
+func (t *podTailer) handle(pod *corev1.Pod) bool {
+	done := podFinished(pod)
+
+	queue, ok := t.queues[pod.Name]
+	if !ok {
+		if done && t.opts.Running {
+			return done
+		}
+
+		queue = make(chan string, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)+len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+		t.queues[pod.Name] = queue
+
+		t.wg.Add(1)
+		go t.stream(pod, queue)
+	}
+
+	if queue == nil {
+		return done
+	}
+
+	for _, container := range startedContainers(pod) {
+		key := pod.Name + "/" + container
+		if _, ok := t.processed[key]; ok {
+			continue
+		}
+		t.processed[key] = struct{}{}
+
+		if t.opts.Phase == "" || t.opts.Phase == container {
+			queue <- container
+		}
+	}
+
+	if done {
+		close(queue)
+		t.queues[pod.Name] = nil
+	}
+	return done
+}
+
+func (t *podTailer) wait() error {
+	for name, queue := range t.queues {
+		if queue != nil {
+			close(queue)
+			t.queues[name] = nil
+		}
+	}
+	t.wg.Wait()
+	return t.err
+}
+
+func (t *podTailer) stream(pod *corev1.Pod, containers <-chan string) {
+	defer t.wg.Done()
+
+	for container := range containers {
+		if err := t.streamContainer(pod, container); err != nil {
+			t.errOnce.Do(func() { t.err = err })
+		}
+	}
+}
+
+func (t *podTailer) streamContainer(pod *corev1.Pod, container string) error {
+	logs, err := t.client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     t.opts.Follow,
+		Timestamps: t.opts.Timestamps,
+	}).Stream(t.ctx)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	prefix := ""
+	if t.opts.Prefix {
+		prefix = fmt.Sprintf("[%s] ", container)
+		if t.opts.Running {
+			prefix = fmt.Sprintf("[%s/%s] ", pod.Labels[v1alpha2.BuildLabel], container)
+		}
+	} else {
+		header := fmt.Sprintf("===> %s", strings.ToUpper(container))
+		if t.opts.Color {
+			header = cyan(header)
+		}
+		if err := t.out.writeLine(header + "\n"); err != nil {
+			return err
+		}
+	}
+
+	r := bufio.NewReader(logs)
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			if err := t.out.writeLine(prefix + line); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF || t.ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+type lineWriter struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func (w *lineWriter) writeLine(line string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := io.WriteString(w.writer, line)
+	return err
+}
+
+func startedContainers(pod *corev1.Pod) []string {
+	var containers []string
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Waiting == nil {
+			containers = append(containers, status.Name)
+		}
+	}
+	return containers
+}
+
+func podFinished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded
+}
+
+func cyan(s string) string {
+	return fmt.Sprintf("%s%s%s", "\033[0;36m", s, "\033[0m")
+}
@@ -0,0 +1,109 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchTools "k8s.io/client-go/tools/watch"
+)
+
+// LogTailer tails the logs of a build until its pod completes.
+type LogTailer interface {
+	TailBuildName(ctx context.Context, writer io.Writer, namespace string, buildName string) error
+}
+
+// Waiter waits for a build to finish while tailing its logs.
+type Waiter struct {
+	kpackClient versioned.Interface
+	logTailer   LogTailer
+}
+
+func NewWaiter(kpackClient versioned.Interface, logTailer LogTailer) *Waiter {
+	return &Waiter{kpackClient: kpackClient, logTailer: logTailer}
+}
+
+// Wait returns the image built by the build, or an error if the build fails.
+// The logs of a successful build are tailed until its pod completes.
+func (w *Waiter) Wait(ctx context.Context, writer io.Writer, bld *v1alpha2.Build) (string, error) {
+	tailCtx, cancelTail := context.WithCancel(ctx)
+	defer cancelTail()
+
+	tailed := make(chan struct{})
+	go func() {
+		defer close(tailed)
+		if err := w.logTailer.TailBuildName(tailCtx, writer, bld.Namespace, bld.Name); err != nil && tailCtx.Err() == nil {
+			_, _ = fmt.Fprintf(writer, "error tailing logs: %s\n", err)
+		}
+	}()
+
+	finished, err := w.watchUntilFinished(ctx, bld)
+	if err == nil {
+		if cond := finished.Status.GetCondition(corev1alpha1.ConditionSucceeded); cond.IsFalse() {
+			err = errors.Errorf("build %q failed", finished.Name)
+			if cond.Message != "" {
+				err = errors.Errorf("build %q failed: %s", finished.Name, cond.Message)
+			}
+		}
+	}
+
+	// a failed build may not have a pod to tail
+	if err != nil {
+		cancelTail()
+	}
+	<-tailed
+
+	if err != nil {
+		return "", err
+	}
+	return finished.Status.LatestImage, nil
+}
+
+func (w *Waiter) watchUntilFinished(ctx context.Context, bld *v1alpha2.Build) (*v1alpha2.Build, error) {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", bld.Name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return w.kpackClient.KpackV1alpha2().Builds(bld.Namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return w.kpackClient.KpackV1alpha2().Builds(bld.Namespace).Watch(ctx, options)
+		},
+	}
+
+	event, err := watchTools.UntilWithSync(ctx, lw, &v1alpha2.Build{}, nil, buildHasFinished)
+	if err != nil {
+		return nil, err
+	}
+
+	finished, ok := event.Object.(*v1alpha2.Build)
+	if !ok {
+		return nil, errors.New("unexpected object received, expected Build")
+	}
+	return finished, nil
+}
+
+func buildHasFinished(event watch.Event) (bool, error) {
+	if event.Type == watch.Error {
+		return false, errors.Errorf("error on watch %+v", event.Object)
+	}
+
+	bld, ok := event.Object.(*v1alpha2.Build)
+	if !ok {
+		return false, errors.New("unexpected object received, expected Build")
+	}
+	return !bld.Status.GetCondition(corev1alpha1.ConditionSucceeded).IsUnknown(), nil
+}
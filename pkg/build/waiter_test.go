@@ -0,0 +1,77 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/build"
+)
+
+func TestWaiter(t *testing.T) {
+	spec.Run(t, "TestWaiter", testWaiter)
+}
+
+type fakeLogTailer struct {
+	tailed []string
+}
+
+func (f *fakeLogTailer) TailBuildName(_ context.Context, _ io.Writer, namespace string, buildName string) error {
+	f.tailed = append(f.tailed, namespace+"/"+buildName)
+	return nil
+}
+
+func testWaiter(t *testing.T, when spec.G, it spec.S) {
+	newBuild := func(status corev1.ConditionStatus, message string) *v1alpha2.Build {
+		return &v1alpha2.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-build", Namespace: "some-namespace"},
+			Status: v1alpha2.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{{Type: corev1alpha1.ConditionSucceeded, Status: status, Message: message}},
+				},
+				LatestImage: "some-registry.io/some-repo@sha256:some-digest",
+			},
+		}
+	}
+
+	it("tails the logs and returns the image of a successful build", func() {
+		bld := newBuild(corev1.ConditionTrue, "")
+		logTailer := &fakeLogTailer{}
+
+		latestImage, err := build.NewWaiter(kpackfakes.NewSimpleClientset(bld), logTailer).Wait(context.Background(), ioutil.Discard, bld)
+		require.NoError(t, err)
+		require.Equal(t, "some-registry.io/some-repo@sha256:some-digest", latestImage)
+		require.Equal(t, []string{"some-namespace/some-build"}, logTailer.tailed)
+	})
+
+	it("returns an error when the build fails", func() {
+		bld := newBuild(corev1.ConditionFalse, "some failure")
+
+		_, err := build.NewWaiter(kpackfakes.NewSimpleClientset(bld), &fakeLogTailer{}).Wait(context.Background(), ioutil.Discard, bld)
+		require.EqualError(t, err, `build "some-build" failed: some failure`)
+	})
+
+	it("waits until the context is done while the build is running", func() {
+		bld := newBuild(corev1.ConditionUnknown, "")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := build.NewWaiter(kpackfakes.NewSimpleClientset(bld), &fakeLogTailer{}).Wait(ctx, ioutil.Discard, bld)
+		require.Error(t, err)
+		require.Equal(t, context.DeadlineExceeded, ctx.Err())
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+// OrderEdits are incremental edits of a builder order.
+// Groups are numbered from 1 and refer to the order before the edits, new groups are inserted last.
+type OrderEdits struct {
+	AddBuildpacks      []string // <group>:<buildpack>[@<version>]
+	RemoveBuildpacks   []string // <group>:<buildpack>
+	InsertGroups       []string // <position>:<buildpack>[@<version>][,<buildpack>[@<version>]...]
+	OptionalBuildpacks []string // <group>:<buildpack>
+	RequiredBuildpacks []string // <group>:<buildpack>
+	PinBuildpacks      []string // <buildpack>@<version>
+	UnpinBuildpacks    []string // <buildpack>
+}
+
+func (e OrderEdits) IsEmpty() bool {
+	return len(e.AddBuildpacks) == 0 && len(e.RemoveBuildpacks) == 0 && len(e.InsertGroups) == 0 &&
+		len(e.OptionalBuildpacks) == 0 && len(e.RequiredBuildpacks) == 0 &&
+		len(e.PinBuildpacks) == 0 && len(e.UnpinBuildpacks) == 0
+}
+
+// Apply returns a copy of the order with the edits applied. Groups left without buildpacks are removed.
+func (e OrderEdits) Apply(order []corev1alpha1.OrderEntry) ([]corev1alpha1.OrderEntry, error) {
+	edited := make([]corev1alpha1.OrderEntry, len(order))
+	for i := range order {
+		order[i].DeepCopyInto(&edited[i])
+	}
+
+	for _, value := range e.RemoveBuildpacks {
+		group, id, err := parseGroupBuildpack(value, len(edited))
+		if err != nil {
+			return nil, err
+		}
+
+		i, err := findBuildpack(edited[group], group, id)
+		if err != nil {
+			return nil, err
+		}
+
+		edited[group].Group = append(edited[group].Group[:i], edited[group].Group[i+1:]...)
+	}
+
+	for _, value := range e.AddBuildpacks {
+		group, ref, err := parseGroupBuildpack(value, len(edited))
+		if err != nil {
+			return nil, err
+		}
+
+		bp := parseBuildpackRef(ref)
+		if _, err := findBuildpack(edited[group], group, bp.Id); err == nil {
+			return nil, errors.Errorf("buildpack %q is already in group %d", bp.Id, group+1)
+		}
+
+		edited[group].Group = append(edited[group].Group, bp)
+	}
+
+	for _, optional := range []struct {
+		values   []string
+		optional bool
+	}{{e.OptionalBuildpacks, true}, {e.RequiredBuildpacks, false}} {
+		for _, value := range optional.values {
+			group, id, err := parseGroupBuildpack(value, len(edited))
+			if err != nil {
+				return nil, err
+			}
+
+			i, err := findBuildpack(edited[group], group, id)
+			if err != nil {
+				return nil, err
+			}
+
+			edited[group].Group[i].Optional = optional.optional
+		}
+	}
+
+	for _, value := range e.PinBuildpacks {
+		ref := parseBuildpackRef(value)
+		if ref.Version == "" {
+			return nil, errors.Errorf("invalid pin %q, must be of the form <buildpack>@<version>", value)
+		}
+
+		if err := setVersion(edited, ref.Id, ref.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range e.UnpinBuildpacks {
+		if err := setVersion(edited, id, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	var groups []corev1alpha1.OrderEntry
+	for _, entry := range edited {
+		if len(entry.Group) > 0 {
+			groups = append(groups, entry)
+		}
+	}
+
+	for _, value := range e.InsertGroups {
+		parts := strings.SplitN(value, ":", 2)
+		position, err := strconv.Atoi(parts[0])
+		if len(parts) != 2 || err != nil || parts[1] == "" {
+			return nil, errors.Errorf("invalid group %q, must be of the form <position>:<buildpack>[,<buildpack>...]", value)
+		}
+
+		if position < 1 || position > len(groups)+1 {
+			return nil, errors.Errorf("invalid group position %d, must be between 1 and %d", position, len(groups)+1)
+		}
+
+		entry := CreateOrder(strings.Split(parts[1], ","))[0]
+		groups = append(groups[:position-1], append([]corev1alpha1.OrderEntry{entry}, groups[position-1:]...)...)
+	}
+
+	if len(groups) == 0 {
+		return nil, errors.New("order must contain at least one group")
+	}
+
+	return groups, nil
+}
+
+// FormatOrder renders an order the way the status commands display it, for showing order changes.
+func FormatOrder(order []corev1alpha1.OrderEntry) string {
+	sb := strings.Builder{}
+	for i, entry := range order {
+		sb.WriteString(fmt.Sprintf("Group #%d\n", i+1))
+		for _, ref := range entry.Group {
+			data, optional := CreateDetectionOrderRow(ref)
+			if optional != "" {
+				data = data + " " + optional
+			}
+			sb.WriteString(data + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// parseGroupBuildpack parses <group>:<buildpack> into a zero based group index.
+func parseGroupBuildpack(value string, groups int) (int, string, error) {
+	parts := strings.SplitN(value, ":", 2)
+	group, err := strconv.Atoi(parts[0])
+	if len(parts) != 2 || err != nil || parts[1] == "" {
+		return 0, "", errors.Errorf("invalid buildpack %q, must be of the form <group>:<buildpack>", value)
+	}
+
+	if group < 1 || group > groups {
+		return 0, "", errors.Errorf("invalid group %d, the order has %d groups", group, groups)
+	}
+
+	return group - 1, parts[1], nil
+}
+
+func parseBuildpackRef(value string) corev1alpha1.BuildpackRef {
+	return CreateOrder([]string{value})[0].Group[0]
+}
+
+func findBuildpack(entry corev1alpha1.OrderEntry, group int, value string) (int, error) {
+	id := parseBuildpackRef(value).Id
+	for i, ref := range entry.Group {
+		if ref.Id == id {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("buildpack %q not found in group %d", id, group+1)
+}
+
+func setVersion(order []corev1alpha1.OrderEntry, id, version string) error {
+	found := false
+	for i := range order {
+		for j := range order[i].Group {
+			if order[i].Group[j].Id == id {
+				order[i].Group[j].Version = version
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return errors.Errorf("buildpack %q not found in order", id)
+	}
+	return nil
+}
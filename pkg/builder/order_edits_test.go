@@ -0,0 +1,84 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder_test
+
+import (
+	"testing"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/builder"
+)
+
+func TestOrderEdits(t *testing.T) {
+	spec.Run(t, "TestOrderEdits", testOrderEdits)
+}
+
+func testOrderEdits(t *testing.T, when spec.G, it spec.S) {
+	ref := func(id, version string, optional bool) corev1alpha1.BuildpackRef {
+		return corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: id, Version: version}, Optional: optional}
+	}
+
+	order := []corev1alpha1.OrderEntry{
+		{Group: []corev1alpha1.BuildpackRef{ref("some-java", "", false), ref("some-procfile", "1.0.0", true)}},
+		{Group: []corev1alpha1.BuildpackRef{ref("some-go", "", false)}},
+	}
+
+	it("edits the order without modifying the original", func() {
+		edited, err := builder.OrderEdits{
+			AddBuildpacks:      []string{"2:some-procfile@2.0.0"},
+			RequiredBuildpacks: []string{"1:some-procfile"},
+			UnpinBuildpacks:    []string{"some-procfile"},
+			InsertGroups:       []string{"3:some-node,some-npm@1.0.0"},
+		}.Apply(order)
+		require.NoError(t, err)
+
+		require.Equal(t, []corev1alpha1.OrderEntry{
+			{Group: []corev1alpha1.BuildpackRef{ref("some-java", "", false), ref("some-procfile", "", false)}},
+			{Group: []corev1alpha1.BuildpackRef{ref("some-go", "", false), ref("some-procfile", "", false)}},
+			{Group: []corev1alpha1.BuildpackRef{ref("some-node", "", false), ref("some-npm", "1.0.0", false)}},
+		}, edited)
+		require.Equal(t, ref("some-procfile", "1.0.0", true), order[0].Group[1])
+	})
+
+	it("removes groups without buildpacks before inserting groups", func() {
+		edited, err := builder.OrderEdits{
+			RemoveBuildpacks: []string{"2:some-go"},
+			InsertGroups:     []string{"1:some-node"},
+		}.Apply(order)
+		require.NoError(t, err)
+
+		require.Equal(t, []corev1alpha1.OrderEntry{
+			{Group: []corev1alpha1.BuildpackRef{ref("some-node", "", false)}},
+			order[0],
+		}, edited)
+	})
+
+	it("returns an error for an invalid edit", func() {
+		for edits, message := range map[*builder.OrderEdits]string{
+			{AddBuildpacks: []string{"some-go"}}:                                        `invalid buildpack "some-go", must be of the form <group>:<buildpack>`,
+			{AddBuildpacks: []string{"1:some-java@2.0.0"}}:                              `buildpack "some-java" is already in group 1`,
+			{RemoveBuildpacks: []string{"2:some-java"}}:                                 `buildpack "some-java" not found in group 2`,
+			{OptionalBuildpacks: []string{"0:some-java"}}:                               `invalid group 0, the order has 2 groups`,
+			{PinBuildpacks: []string{"some-java"}}:                                      `invalid pin "some-java", must be of the form <buildpack>@<version>`,
+			{UnpinBuildpacks: []string{"some-node"}}:                                    `buildpack "some-node" not found in order`,
+			{InsertGroups: []string{"4:some-node"}}:                                     `invalid group position 4, must be between 1 and 3`,
+			{RemoveBuildpacks: []string{"2:some-go", "1:some-java", "1:some-procfile"}}: `order must contain at least one group`,
+		} {
+			_, err := edits.Apply(order)
+			require.EqualError(t, err, message)
+		}
+	})
+
+	it("formats the order like the status commands", func() {
+		require.Equal(t, `Group #1
+  some-java
+  some-procfile@1.0.0 (Optional)
+Group #2
+  some-go
+`, builder.FormatOrder(order))
+	})
+}
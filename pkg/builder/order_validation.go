@@ -0,0 +1,150 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const anyStack = "*"
+
+// OrderValidation is the result of resolving an order against the buildpacks of a ClusterStore.
+// Errors are buildpacks the store cannot provide, Warnings are buildpacks that do not support the stack.
+type OrderValidation struct {
+	Errors   []string
+	Warnings []string
+}
+
+// ValidateOrder resolves every buildpack of the order against the store. The stack is optional.
+func ValidateOrder(order []corev1alpha1.OrderEntry, store *v1alpha2.ClusterStore, stack *v1alpha2.ClusterStack) OrderValidation {
+	var (
+		validation OrderValidation
+		seen       = map[string]bool{}
+	)
+
+	for _, entry := range order {
+		for _, ref := range entry.Group {
+			key := ref.Id + "@" + ref.Version
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			buildpack, problem := resolveBuildpack(ref, store.Status.Buildpacks)
+			if problem != "" {
+				validation.Errors = append(validation.Errors, problem)
+				continue
+			}
+
+			if stack != nil && !supportsStack(buildpack, stack.Status.Id) {
+				validation.Warnings = append(validation.Warnings,
+					fmt.Sprintf("buildpack %q does not support stack %q of ClusterStack %q", buildpack.String(), stack.Status.Id, stack.Name))
+			}
+		}
+	}
+
+	return validation
+}
+
+func resolveBuildpack(ref corev1alpha1.BuildpackRef, buildpacks []corev1alpha1.StoreBuildpack) (corev1alpha1.StoreBuildpack, string) {
+	var (
+		resolved corev1alpha1.StoreBuildpack
+		versions []string
+	)
+
+	for _, bp := range buildpacks {
+		if bp.Id != ref.Id {
+			continue
+		}
+
+		if bp.Version == ref.Version {
+			return bp, ""
+		}
+
+		if ref.Version == "" && (resolved.Id == "" || compareVersions(bp.Version, resolved.Version) > 0) {
+			resolved = bp
+		}
+		versions = append(versions, bp.Version)
+	}
+
+	if len(versions) == 0 {
+		return resolved, fmt.Sprintf("buildpack %q not found", ref.Id)
+	}
+
+	if ref.Version != "" {
+		return resolved, fmt.Sprintf("buildpack %q version %q not found, closest available versions: %s",
+			ref.Id, ref.Version, strings.Join(closestVersions(ref.Version, versions), ", "))
+	}
+
+	return resolved, ""
+}
+
+func supportsStack(buildpack corev1alpha1.StoreBuildpack, stackId string) bool {
+	// meta-buildpacks do not list stacks and stacks that have not resolved do not have an id
+	if len(buildpack.Stacks) == 0 || stackId == "" {
+		return true
+	}
+
+	for _, s := range buildpack.Stacks {
+		if s.ID == stackId || s.ID == anyStack {
+			return true
+		}
+	}
+	return false
+}
+
+// closestVersions returns the nearest available versions below and above the version.
+func closestVersions(version string, available []string) []string {
+	sort.Slice(available, func(i, j int) bool {
+		return compareVersions(available[i], available[j]) < 0
+	})
+
+	i := sort.Search(len(available), func(i int) bool {
+		return compareVersions(available[i], version) > 0
+	})
+
+	var closest []string
+	if i > 0 {
+		closest = append(closest, available[i-1])
+	}
+	if i < len(available) {
+		closest = append(closest, available[i])
+	}
+	return closest
+}
+
+// compareVersions compares dot separated versions numerically, falling back to a string comparison for non numeric parts.
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+
+		switch {
+		case aErr != nil || bErr != nil:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		case aNum < bNum:
+			return -1
+		case aNum > bNum:
+			return 1
+		}
+	}
+
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/builder"
+)
+
+func TestValidateOrder(t *testing.T) {
+	spec.Run(t, "TestValidateOrder", testValidateOrder)
+}
+
+func testValidateOrder(t *testing.T, when spec.G, it spec.S) {
+	storeBuildpack := func(id, version string, stacks ...string) corev1alpha1.StoreBuildpack {
+		bp := corev1alpha1.StoreBuildpack{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: id, Version: version}}
+		for _, s := range stacks {
+			bp.Stacks = append(bp.Stacks, corev1alpha1.BuildpackStack{ID: s})
+		}
+		return bp
+	}
+
+	store := &v1alpha2.ClusterStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-store"},
+		Status: v1alpha2.ClusterStoreStatus{
+			Buildpacks: []corev1alpha1.StoreBuildpack{
+				storeBuildpack("some-java", "1.9.0", "some-stack-id"),
+				storeBuildpack("some-java", "1.10.0", "some-stack-id"),
+				storeBuildpack("some-java", "2.0.0", "some-other-stack-id"),
+				storeBuildpack("some-procfile", "1.0.0", "*"),
+				storeBuildpack("some-meta", "1.0.0"),
+			},
+		},
+	}
+
+	stack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-stack"},
+		Status: v1alpha2.ClusterStackStatus{
+			ResolvedClusterStack: v1alpha2.ResolvedClusterStack{Id: "some-stack-id"},
+		},
+	}
+
+	it("resolves buildpacks with and without versions", func() {
+		order := builder.CreateOrder([]string{"some-java@1.10.0", "some-procfile", "some-meta"})
+
+		require.Equal(t, builder.OrderValidation{}, builder.ValidateOrder(order, store, stack))
+	})
+
+	it("reports missing buildpacks and the closest versions", func() {
+		order := append(
+			builder.CreateOrder([]string{"some-java@1.9.5", "some-missing"}),
+			builder.CreateOrder([]string{"some-java@3.0.0", "some-procfile@0.1.0"})...,
+		)
+
+		require.Equal(t, []string{
+			`buildpack "some-java" version "1.9.5" not found, closest available versions: 1.9.0, 1.10.0`,
+			`buildpack "some-missing" not found`,
+			`buildpack "some-java" version "3.0.0" not found, closest available versions: 2.0.0`,
+			`buildpack "some-procfile" version "0.1.0" not found, closest available versions: 1.0.0`,
+		}, builder.ValidateOrder(order, store, stack).Errors)
+	})
+
+	it("warns about buildpacks that do not support the stack", func() {
+		order := append(
+			builder.CreateOrder([]string{"some-java"}),
+			builder.CreateOrder([]string{"some-java", "some-procfile"})...,
+		)
+
+		require.Equal(t, builder.OrderValidation{
+			Warnings: []string{`buildpack "some-java@2.0.0" does not support stack "some-stack-id" of ClusterStack "some-stack"`},
+		}, builder.ValidateOrder(order, store, stack))
+	})
+
+	it("does not check stacks without a stack", func() {
+		order := builder.CreateOrder([]string{"some-java@2.0.0"})
+
+		require.Equal(t, builder.OrderValidation{}, builder.ValidateOrder(order, store, nil))
+	})
+}
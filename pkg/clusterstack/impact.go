@@ -0,0 +1,168 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterstack
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StackChange describes an update to a stack. kpack rebases images when only the run image changes
+// and rebuilds them when the stack id changes. A build image change only rebuilds builders.
+type StackChange struct {
+	BuildImageChanged bool
+	RunImageChanged   bool
+	IdChanged         bool
+}
+
+type BuilderRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+type NamespaceImpact struct {
+	Namespace string
+	Images    int
+	Rebases   int
+	Rebuilds  int
+}
+
+type Impact struct {
+	Change     StackChange
+	Builders   []BuilderRef
+	Namespaces []NamespaceImpact
+}
+
+func (i Impact) Total() NamespaceImpact {
+	total := NamespaceImpact{}
+	for _, n := range i.Namespaces {
+		total.Images += n.Images
+		total.Rebases += n.Rebases
+		total.Rebuilds += n.Rebuilds
+	}
+	return total
+}
+
+// NewStackChange compares the resolved images and id of a stack with the images and id it is updated to.
+func NewStackChange(stack *v1alpha2.ClusterStack, buildImageRef, runImageRef, stackId string) (StackChange, error) {
+	buildImageChanged, err := imageChanged(stack.Status.BuildImage.LatestImage, buildImageRef)
+	if err != nil {
+		return StackChange{}, err
+	}
+
+	runImageChanged, err := imageChanged(stack.Status.RunImage.LatestImage, runImageRef)
+	if err != nil {
+		return StackChange{}, err
+	}
+
+	return StackChange{
+		BuildImageChanged: buildImageChanged,
+		RunImageChanged:   runImageChanged,
+		IdChanged:         stack.Status.Id != stackId,
+	}, nil
+}
+
+func imageChanged(oldRef, newRef string) (bool, error) {
+	newDigest, err := getDigest(newRef)
+	if err != nil {
+		return false, err
+	}
+
+	if oldRef == "" {
+		return true, nil
+	}
+
+	oldDigest, err := getDigest(oldRef)
+	if err != nil {
+		return false, err
+	}
+	return oldDigest != newDigest, nil
+}
+
+// AnalyzeImpact finds the cluster builders and builders in every namespace that use the stack
+// and counts the rebases and rebuilds the change triggers for the images that use them.
+func AnalyzeImpact(ctx context.Context, client versioned.Interface, stackName string, change StackChange) (Impact, error) {
+	impact := Impact{Change: change}
+	uses := map[BuilderRef]struct{}{}
+
+	clusterBuilders, err := client.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return impact, err
+	}
+
+	for _, cb := range clusterBuilders.Items {
+		if cb.Spec.Stack.Kind == v1alpha2.ClusterStackKind && cb.Spec.Stack.Name == stackName {
+			ref := BuilderRef{Kind: v1alpha2.ClusterBuilderKind, Name: cb.Name}
+			impact.Builders = append(impact.Builders, ref)
+			uses[ref] = struct{}{}
+		}
+	}
+
+	builders, err := client.KpackV1alpha2().Builders(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return impact, err
+	}
+
+	for _, b := range builders.Items {
+		if b.Spec.Stack.Kind == v1alpha2.ClusterStackKind && b.Spec.Stack.Name == stackName {
+			ref := BuilderRef{Kind: v1alpha2.BuilderKind, Namespace: b.Namespace, Name: b.Name}
+			impact.Builders = append(impact.Builders, ref)
+			uses[ref] = struct{}{}
+		}
+	}
+
+	images, err := client.KpackV1alpha2().Images(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return impact, err
+	}
+
+	namespaces := map[string]*NamespaceImpact{}
+	for _, img := range images.Items {
+		ref := BuilderRef{Kind: img.Spec.Builder.Kind, Name: img.Spec.Builder.Name}
+		if ref.Kind == v1alpha2.BuilderKind {
+			ref.Namespace = img.Namespace
+		}
+		if _, ok := uses[ref]; !ok {
+			continue
+		}
+
+		n, ok := namespaces[img.Namespace]
+		if !ok {
+			n = &NamespaceImpact{Namespace: img.Namespace}
+			namespaces[img.Namespace] = n
+		}
+		n.Images++
+
+		switch {
+		case change.IdChanged || (change.RunImageChanged && img.Status.LatestImage == ""):
+			n.Rebuilds++
+		case change.RunImageChanged:
+			n.Rebases++
+		}
+	}
+
+	for _, n := range namespaces {
+		impact.Namespaces = append(impact.Namespaces, *n)
+	}
+	sort.Slice(impact.Namespaces, func(i, j int) bool {
+		return impact.Namespaces[i].Namespace < impact.Namespaces[j].Namespace
+	})
+	sort.SliceStable(impact.Builders, func(i, j int) bool {
+		a, b := impact.Builders[i], impact.Builders[j]
+		if a.Kind != b.Kind {
+			return a.Kind == v1alpha2.ClusterBuilderKind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return impact, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+
+	applypkg "github.com/vmware-tanzu/kpack-cli/pkg/apply"
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func NewApplyCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+	var (
+		filenames []string
+		namespace string
+		tlsCfg    registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <filename>",
+		Short: "Create or patch kpack resources from files",
+		Long: `Create or patch the ClusterStores, ClusterStacks, ClusterBuilders, Builders, and Images defined in yaml or json files.
+
+Resources are applied in dependency order: clusterstores and clusterstacks, then clusterbuilders and builders, then images.
+Existing resources are patched with a three-way merge against their last applied configuration, the same way "kubectl apply" does.
+
+Buildpackages of clusterstores and build and run images of clusterstacks are uploaded to the default repository.
+They may be image references or local files relative to the file of the resource.
+Images with a "kpack.io/local-path" annotation have the local source code directory it points to uploaded,
+the same way the "--local-path" flag of "kp image save" does.
+
+The namespace of builders and images defaults to the kubernetes current-context namespace.
+The default repository is read from the "default.repository" key in the "kp-config" ConfigMap within "kpack" namespace.`,
+		Example: `kp apply -f resources/
+kp apply -f stack.yaml -f builder.yaml -f image.yaml -n my-namespace
+cat resources.yaml | kp apply -f -
+kp apply -f resources/ --dry-run`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			var resources []applypkg.Resource
+			for _, filename := range filenames {
+				r, err := readResources(cmd, filename)
+				if err != nil {
+					return err
+				}
+				resources = append(resources, r...)
+			}
+
+			resources, err = applypkg.Sort(resources, cs.Namespace)
+			if err != nil {
+				return err
+			}
+
+			if len(resources) == 0 {
+				return errors.New("no resources found")
+			}
+
+			kpConfig := config.NewKpConfigProvider(cs).GetKpConfig(ctx)
+
+			applier := applypkg.NewApplier(
+				ch,
+				cs.KpackClient,
+				rup.Fetcher(tlsCfg),
+				rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()),
+				rup.SourceUploader(ch.Writer(), tlsCfg, ch.IsUploading()),
+				newWaiter(cs.DynamicClient),
+				ch.IsDryRun(),
+			)
+
+			summary, objs, err := applier.Apply(ctx, authn.DefaultKeychain, kpConfig, resources)
+			if err != nil {
+				return err
+			}
+
+			if err := ch.PrintObjs(objs); err != nil {
+				return err
+			}
+
+			for _, result := range summary.Results {
+				if err := ch.Printlnf("\t%s %s", result.Resource, result.Action); err != nil {
+					return err
+				}
+			}
+
+			return ch.PrintResult("Applied %d resource(s): %d created, %d configured, %d unchanged",
+				len(summary.Results),
+				summary.Count(applypkg.ActionCreated),
+				summary.Count(applypkg.ActionConfigured),
+				summary.Count(applypkg.ActionUnchanged))
+		},
+	}
+	cmd.Flags().StringArrayVarP(&filenames, "filename", "f", []string{}, "file or directory of resources to apply, repeat for each file or directory")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace of builders and images without one")
+	commands.SetImgUploadDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func readResources(cmd *cobra.Command, filename string) ([]applypkg.Resource, error) {
+	if filename != "-" {
+		return applypkg.ReadPath(filename)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return applypkg.Read(cmd.InOrStdin(), "stdin", dir)
+}
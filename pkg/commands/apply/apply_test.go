@@ -0,0 +1,362 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	applycmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/apply"
+	commandsfakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestApplyCommand(t *testing.T) {
+	spec.Run(t, "TestApplyCommand", testApplyCommand)
+}
+
+const (
+	storeYAML = `apiVersion: kpack.io/v1alpha2
+kind: ClusterStore
+metadata:
+  name: some-store
+spec:
+  sources:
+  - image: some-registry.io/repo/buildpack-image
+`
+
+	stackYAML = `apiVersion: kpack.io/v1alpha2
+kind: ClusterStack
+metadata:
+  name: some-stack
+spec:
+  buildImage:
+    image: some-registry.io/repo/build-image
+  runImage:
+    image: some-registry.io/repo/run-image
+`
+
+	builderAndImageYAML = `apiVersion: kpack.io/v1alpha2
+kind: Image
+metadata:
+  name: some-image
+  annotations:
+    kpack.io/local-path: app
+spec:
+  tag: some-registry.io/repo/some-image
+  builder:
+    kind: ClusterBuilder
+    name: some-builder
+---
+apiVersion: kpack.io/v1alpha2
+kind: ClusterBuilder
+metadata:
+  name: some-builder
+spec:
+  tag: some-registry.io/repo/some-builder
+  stack:
+    kind: ClusterStack
+    name: some-stack
+  store:
+    kind: ClusterStore
+    name: some-store
+  order:
+  - group:
+    - id: buildpack-id
+`
+)
+
+func testApplyCommand(t *testing.T, when spec.G, it spec.S) {
+	fakeFetcher := &registryfakes.Fetcher{}
+	fakeFetcher.AddStackImages(registryfakes.StackInfo{
+		StackID: "stack-id",
+		BuildImg: registryfakes.ImageInfo{
+			Ref:    "some-registry.io/repo/build-image",
+			Digest: "build-image-digest",
+		},
+		RunImg: registryfakes.ImageInfo{
+			Ref:    "some-registry.io/repo/run-image",
+			Digest: "run-image-digest",
+		},
+	})
+	fakeFetcher.AddBuildpackImages(registryfakes.BuildpackImgInfo{
+		Id: "buildpack-id",
+		ImageInfo: registryfakes.ImageInfo{
+			Ref:    "some-registry.io/repo/buildpack-image",
+			Digest: "buildpack-image-digest",
+		},
+	})
+
+	kpConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository": "default-registry.io/default-repo",
+		},
+	}
+
+	var (
+		dir        string
+		fakeWaiter *commandsfakes.FakeWaiter
+	)
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+		return applycmds.NewApplyCommand(clientSetProvider, registryfakes.UtilProvider{FakeFetcher: fakeFetcher}, func(dynamic.Interface) commands.ResourceWaiter {
+			return fakeWaiter
+		})
+	}
+
+	writeFile := func(name, contents string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+
+	lastApplied := func(obj k8s.Annotatable) {
+		require.NoError(t, k8s.SetLastAppliedCfg(obj))
+	}
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "apply-test")
+		require.NoError(t, err)
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "app"), 0755))
+
+		fakeWaiter = &commandsfakes.FakeWaiter{}
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	expectedStore := func() *v1alpha2.ClusterStore {
+		store := &v1alpha2.ClusterStore{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1alpha2.ClusterStoreKind,
+				APIVersion: "kpack.io/v1alpha2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "some-store",
+			},
+			Spec: v1alpha2.ClusterStoreSpec{
+				Sources: []corev1alpha1.StoreImage{
+					{Image: "default-registry.io/default-repo/buildpack-id@sha256:buildpack-image-digest"},
+				},
+			},
+		}
+		lastApplied(store)
+		return store
+	}
+
+	expectedStack := func() *v1alpha2.ClusterStack {
+		stack := &v1alpha2.ClusterStack{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1alpha2.ClusterStackKind,
+				APIVersion: "kpack.io/v1alpha2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "some-stack",
+			},
+			Spec: v1alpha2.ClusterStackSpec{
+				Id:         "stack-id",
+				BuildImage: v1alpha2.ClusterStackSpecImage{Image: "default-registry.io/default-repo/build@sha256:build-image-digest"},
+				RunImage:   v1alpha2.ClusterStackSpecImage{Image: "default-registry.io/default-repo/run@sha256:run-image-digest"},
+			},
+		}
+		lastApplied(stack)
+		return stack
+	}
+
+	expectedBuilder := func() *v1alpha2.ClusterBuilder {
+		builder := &v1alpha2.ClusterBuilder{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1alpha2.ClusterBuilderKind,
+				APIVersion: "kpack.io/v1alpha2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "some-builder",
+			},
+			Spec: v1alpha2.ClusterBuilderSpec{
+				BuilderSpec: v1alpha2.BuilderSpec{
+					Tag:   "some-registry.io/repo/some-builder",
+					Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "some-stack"},
+					Store: corev1.ObjectReference{Kind: v1alpha2.ClusterStoreKind, Name: "some-store"},
+					Order: []corev1alpha1.OrderEntry{
+						{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "buildpack-id"}}}},
+					},
+				},
+			},
+		}
+		lastApplied(builder)
+		return builder
+	}
+
+	expectedImage := func() *v1alpha2.Image {
+		img := &v1alpha2.Image{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Image",
+				APIVersion: "kpack.io/v1alpha2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "some-image",
+				Namespace:   "some-namespace",
+				Annotations: map[string]string{},
+			},
+			Spec: v1alpha2.ImageSpec{
+				Tag:     "some-registry.io/repo/some-image",
+				Builder: corev1.ObjectReference{Kind: v1alpha2.ClusterBuilderKind, Name: "some-builder"},
+				Source: corev1alpha1.SourceConfig{
+					Registry: &corev1alpha1.Registry{Image: "some-registry.io/repo/some-image-source:source-id"},
+				},
+			},
+		}
+		lastApplied(img)
+		return img
+	}
+
+	it("creates resources from a directory in dependency order", func() {
+		writeFile("builders.yaml", builderAndImageYAML)
+		writeFile("stack.yaml", stackYAML)
+		writeFile("store.yml", storeYAML)
+		writeFile("README.md", "not a resource")
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig},
+			Args:    []string{"-f", dir, "-n", "some-namespace"},
+			ExpectedOutput: `Applying ClusterStore "some-store"...
+Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo/buildpack-id@sha256:buildpack-image-digest'
+Applying ClusterStack "some-stack"...
+Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo/build@sha256:build-image-digest'
+	Uploading 'default-registry.io/default-repo/run@sha256:run-image-digest'
+Applying ClusterBuilder "some-builder"...
+Applying Image "some-namespace/some-image"...
+Uploading to 'some-registry.io/repo/some-image-source'...
+	Uploading 'some-registry.io/repo/some-image-source:source-id'
+	ClusterStore "some-store" created
+	ClusterStack "some-stack" created
+	ClusterBuilder "some-builder" created
+	Image "some-namespace/some-image" created
+Applied 4 resource(s): 4 created, 0 configured, 0 unchanged
+`,
+			ExpectCreates: []runtime.Object{
+				expectedStore(),
+				expectedStack(),
+				expectedBuilder(),
+				expectedImage(),
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Len(t, fakeWaiter.WaitCalls, 3)
+	})
+
+	it("patches existing resources with a three-way merge and skips unchanged resources", func() {
+		writeFile("builders.yaml", builderAndImageYAML)
+
+		existingBuilder := expectedBuilder()
+		existingBuilder.ResourceVersion = "1"
+
+		existingImage := expectedImage()
+		existingImage.Spec.Builder.Name = "old-builder"
+		existingImage.Spec.Build = &corev1alpha1.ImageBuild{
+			Env: []corev1.EnvVar{{Name: "some-key", Value: "some-value"}},
+		}
+		lastApplied(existingImage)
+		limit := int64(5)
+		existingImage.Spec.FailedBuildHistoryLimit = &limit
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig, existingBuilder, existingImage},
+			Args:    []string{"-f", filepath.Join(dir, "builders.yaml"), "-n", "some-namespace"},
+			ExpectedOutput: `Applying ClusterBuilder "some-builder"...
+Applying Image "some-namespace/some-image"...
+Uploading to 'some-registry.io/repo/some-image-source'...
+	Uploading 'some-registry.io/repo/some-image-source:source-id'
+	ClusterBuilder "some-builder" unchanged
+	Image "some-namespace/some-image" configured
+Applied 2 resource(s): 0 created, 1 configured, 1 unchanged
+`,
+			ExpectPatches: []string{
+				`{"metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"Image\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"some-image\",\"namespace\":\"some-namespace\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"some-registry.io/repo/some-image\",\"builder\":{\"kind\":\"ClusterBuilder\",\"name\":\"some-builder\"},\"source\":{\"registry\":{\"image\":\"some-registry.io/repo/some-image-source:source-id\"}}},\"status\":{}}"}},"spec":{"build":null,"builder":{"name":"some-builder"}}}`,
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Len(t, fakeWaiter.WaitCalls, 0)
+	})
+
+	it("does not create or upload anything with --dry-run", func() {
+		writeFile("resources.yaml", storeYAML+"---\n"+builderAndImageYAML)
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig},
+			Args:    []string{"-f", filepath.Join(dir, "resources.yaml"), "-n", "some-namespace", "--dry-run"},
+			ExpectedOutput: `Applying ClusterStore "some-store"... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo/buildpack-id@sha256:buildpack-image-digest'
+Applying ClusterBuilder "some-builder"... (dry run)
+Applying Image "some-namespace/some-image"... (dry run)
+Uploading to 'some-registry.io/repo/some-image-source'... (dry run)
+	Skipping 'some-registry.io/repo/some-image-source:source-id'
+	ClusterStore "some-store" created
+	ClusterBuilder "some-builder" created
+	Image "some-namespace/some-image" created
+Applied 3 resource(s): 3 created, 0 configured, 0 unchanged (dry run)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Len(t, fakeWaiter.WaitCalls, 0)
+	})
+
+	it("reads resources from stdin", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig},
+			StdIn:   stackYAML,
+			Args:    []string{"-f", "-"},
+			ExpectedOutput: `Applying ClusterStack "some-stack"...
+Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo/build@sha256:build-image-digest'
+	Uploading 'default-registry.io/default-repo/run@sha256:run-image-digest'
+	ClusterStack "some-stack" created
+Applied 1 resource(s): 1 created, 0 configured, 0 unchanged
+`,
+			ExpectCreates: []runtime.Object{
+				expectedStack(),
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors on unsupported resources", func() {
+		writeFile("secret.yaml", `apiVersion: v1
+kind: Secret
+metadata:
+  name: some-secret
+`)
+
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{kpConfig},
+			Args:                []string{"-f", filepath.Join(dir, "secret.yaml")},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: failed to read '" + filepath.Join(dir, "secret.yaml") + "': unsupported kind 'Secret', must be one of: ClusterStore, ClusterStack, ClusterBuilder, Builder, Image\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
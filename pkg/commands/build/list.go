@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/build"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
@@ -32,6 +33,11 @@ The namespace defaults to the kubernetes current-context namespace.`,
 		Args:         commands.OptionalArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -52,32 +58,40 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return errors.New("no builds found")
 			} else {
 				sort.Slice(buildList.Items, build.Sort(buildList.Items))
-				return displayBuildsTable(cmd, buildList)
+				return displayBuildsTable(printer, buildList)
 			}
 		},
 	}
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayBuildsTable(cmd *cobra.Command, buildList *v1alpha2.BuildList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Build", "Status", "Image", "Reason")
-	if err != nil {
-		return err
+func displayBuildsTable(printer *commands.OutputPrinter, buildList *v1alpha2.BuildList) error {
+	var objs []runtime.Object
+	for i := range buildList.Items {
+		objs = append(objs, &buildList.Items[i])
 	}
 
-	for _, bld := range buildList.Items {
-		err := writer.AddRow(
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "Build"},
+		{Header: "Status"},
+		{Header: "Image"},
+		{Header: "Reason"},
+		{Header: "Image Resource", Wide: true},
+		{Header: "Pod", Wide: true},
+		{Header: "Builder", Wide: true},
+	}, func(obj runtime.Object) []string {
+		bld := obj.(*v1alpha2.Build)
+		return []string{
 			bld.Labels[v1alpha2.BuildNumberLabel],
-			getStatus(bld),
+			getStatus(*bld),
 			bld.Status.LatestImage,
-			getTruncatedReason(bld),
-		)
-		if err != nil {
-			return err
+			getTruncatedReason(*bld),
+			bld.Labels[v1alpha2.ImageLabel],
+			bld.Status.PodName,
+			bld.Spec.Builder.Image,
 		}
-	}
-
-	return writer.Write()
+	})
 }
@@ -4,11 +4,13 @@
 package build
 
 import (
-	"context"
+	"fmt"
+	"io"
+	"os"
 	"sort"
+	"strings"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
-	"github.com/pivotal/kpack/pkg/logs"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +24,9 @@ func NewLogsCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	var (
 		namespace   string
 		buildNumber string
+		file        string
+		all         bool
+		opts        build.LogOptions
 	)
 
 	cmd := &cobra.Command{
@@ -30,37 +35,82 @@ func NewLogsCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Long: `Tails logs from the containers of a specific build of an image in the provided namespace.
 
 The build defaults to the latest build number.
-The namespace defaults to the kubernetes current-context namespace.`,
-		Example:      "kp build logs my-image\nkp build logs my-image -b 2 -n my-namespace",
-		Args:         commands.ExactArgsWithUsage(1),
+The namespace defaults to the kubernetes current-context namespace.
+
+Use "--phase" to only print the logs of one build phase and "--follow=false" to print the logs without waiting for the build to complete.
+Use "--all" to follow the logs of every running build in the namespace, each line is prefixed with the build name and phase.`,
+		Example: `kp build logs my-image
+kp build logs my-image -b 2 -n my-namespace
+kp build logs my-image --phase build --follow=false --timestamps
+kp build logs my-image --file build.log
+kp build logs --all -n my-namespace`,
+		Args:         commands.OptionalArgsWithUsage(1),
 		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if all && len(args) != 0 {
+				return fmt.Errorf("image name cannot be provided with --all\n\n%s", cmd.UsageString())
+			}
+			if !all && len(args) == 0 {
+				return fmt.Errorf("accepts 1 arg(s), received 0\n\n%s", cmd.UsageString())
+			}
+			if all && buildNumber != "" {
+				return fmt.Errorf("build number cannot be provided with --all\n\n%s", cmd.UsageString())
+			}
+			return opts.Validate()
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
 			}
 
-			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(cmd.Context(), metav1.ListOptions{
-				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
-			})
-			if err != nil {
-				return err
-			}
+			selector := build.AllBuildsSelector()
+			if !all {
+				buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(cmd.Context(), metav1.ListOptions{
+					LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
+				})
+				if err != nil {
+					return err
+				}
+
+				if len(buildList.Items) == 0 {
+					return errors.New("no builds found")
+				}
 
-			if len(buildList.Items) == 0 {
-				return errors.New("no builds found")
-			} else {
 				sort.Slice(buildList.Items, build.Sort(buildList.Items))
 				bld, err := findBuild(buildList, buildNumber)
 				if err != nil {
 					return err
 				}
-				return logs.NewBuildLogsClient(cs.K8sClient).Tail(context.Background(), cmd.OutOrStdout(), args[0], bld.Labels[v1alpha2.BuildNumberLabel], cs.Namespace)
+				selector = build.ImageBuildSelector(args[0], bld.Labels[v1alpha2.BuildNumberLabel])
+			}
+
+			var writer io.Writer = cmd.OutOrStdout()
+			opts.Color = true
+			if file != "" {
+				fh, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer fh.Close()
+
+				writer = fh
+				opts.Color = false
 			}
+			opts.Running = all
+			opts.Prefix = opts.Prefix || all
+
+			return build.NewLogsClient(cs.K8sClient).Tail(cmd.Context(), writer, cs.Namespace, selector, opts)
 		},
 	}
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number")
+	cmd.Flags().StringVar(&opts.Phase, "phase", "", fmt.Sprintf("only print logs of a build phase (%s)", strings.Join(build.Phases, ", ")))
+	cmd.Flags().BoolVar(&opts.Follow, "follow", true, "wait for the build to complete and stream new logs")
+	cmd.Flags().BoolVar(&opts.Timestamps, "timestamps", false, "prefix each line with its timestamp")
+	cmd.Flags().BoolVar(&opts.Prefix, "prefix", false, "prefix each line with its build phase")
+	cmd.Flags().StringVar(&file, "file", "", "save the logs to a file instead of printing them")
+	cmd.Flags().BoolVar(&all, "all", false, "follow the logs of every running build in the namespace")
 
 	return cmd
 }
@@ -4,11 +4,20 @@
 package build_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/sclevine/spec"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands/build"
 	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
@@ -29,6 +38,111 @@ func testBuildLogsCommand(t *testing.T, when spec.G, it spec.S) {
 		return build.NewLogsCommand(clientSetProvider)
 	}
 
+	k8sCmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *fake.Clientset) *cobra.Command {
+		return build.NewLogsCommand(testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet))
+	}
+
+	makePod := func(name, buildName, buildNumber string, phase corev1.PodPhase, containers ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: defaultNamespace,
+				Labels: map[string]string{
+					v1alpha2.ImageLabel:       image,
+					v1alpha2.BuildNumberLabel: buildNumber,
+					v1alpha2.BuildLabel:       buildName,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+		for _, container := range containers {
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{Name: container})
+			pod.Status.InitContainerStatuses = append(pod.Status.InitContainerStatuses, corev1.ContainerStatus{
+				Name:  container,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			})
+		}
+		return pod
+	}
+
+	buildObjs := testhelpers.BuildsToRuntimeObjs(testhelpers.MakeTestBuilds(image, defaultNamespace))
+	completedPod := makePod("pod-three", "build-three", "3", corev1.PodSucceeded, "detect", "build", "export")
+	otherPod := makePod("pod-two", "build-two", "2", corev1.PodSucceeded, "detect")
+	runningPod := makePod("other-pod", "other-build", "1", corev1.PodRunning, "detect")
+
+	when("the build has completed", func() {
+		it("prints the logs of every phase", func() {
+			testhelpers.CommandTest{
+				Objects: append([]runtime.Object{completedPod, otherPod}, buildObjs...),
+				Args:    []string{image, "-n", defaultNamespace},
+				ExpectedOutput: "\033[0;36m===> DETECT\033[0m\nfake logs\n" +
+					"\033[0;36m===> BUILD\033[0m\nfake logs\n" +
+					"\033[0;36m===> EXPORT\033[0m\nfake logs\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+		})
+
+		it("prints the logs of one phase with a prefix", func() {
+			testhelpers.CommandTest{
+				Objects:        append([]runtime.Object{completedPod, otherPod}, buildObjs...),
+				Args:           []string{image, "--phase", "build", "--prefix", "--follow=false", "-n", defaultNamespace},
+				ExpectedOutput: "[build] fake logs\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+		})
+
+		it("prints the logs of an older build", func() {
+			testhelpers.CommandTest{
+				Objects:        append([]runtime.Object{completedPod, otherPod}, buildObjs...),
+				Args:           []string{image, "-b", "2", "--prefix", "-n", defaultNamespace},
+				ExpectedOutput: "[detect] fake logs\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+		})
+
+		it("saves the logs to a file", func() {
+			dir, err := ioutil.TempDir("", "build-logs")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "build.log")
+
+			testhelpers.CommandTest{
+				Objects: append([]runtime.Object{completedPod}, buildObjs...),
+				Args:    []string{image, "--phase", "export", "--file", file, "-n", defaultNamespace},
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+
+			contents, err := ioutil.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, "===> EXPORT\nfake logs\n", string(contents))
+		})
+	})
+
+	it("prints the logs of every running build with --all", func() {
+		testhelpers.CommandTest{
+			Objects:        append([]runtime.Object{completedPod, runningPod}, buildObjs...),
+			Args:           []string{"--all", "--follow=false", "-n", defaultNamespace},
+			ExpectedOutput: "[other-build/detect] fake logs\n",
+		}.TestK8sAndKpack(t, k8sCmdFunc)
+	})
+
+	it("errors when an image is provided with --all", func() {
+		cmd := build.NewLogsCommand(testhelpers.GetFakeKpackProvider(fake.NewSimpleClientset(), defaultNamespace))
+		cmd.SetArgs([]string{image, "--all"})
+		cmd.SetOut(ioutil.Discard)
+		cmd.SetErr(ioutil.Discard)
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "image name cannot be provided with --all")
+	})
+
+	it("errors with an invalid phase", func() {
+		testhelpers.CommandTest{
+			Args:                []string{image, "--phase", "compile"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: invalid phase 'compile', must be one of: prepare, detect, analyze, restore, build, export, rebase, completion\n",
+		}.TestK8sAndKpack(t, k8sCmdFunc)
+	})
+
 	when("getting build logs", func() {
 		when("in the default namespace", func() {
 			when("the build does not exist", func() {
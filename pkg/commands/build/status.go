@@ -6,11 +6,14 @@ package build
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/buildchange"
@@ -19,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	bomlib "github.com/vmware-tanzu/kpack-cli/pkg/bom"
 	"github.com/vmware-tanzu/kpack-cli/pkg/build"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -35,6 +39,8 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider, rup registry.Util
 		namespace   string
 		buildNumber string
 		bom         bool
+		bomFormat   string
+		bomFile     string
 		tlsConfig   registry.TLSConfig
 	)
 
@@ -49,11 +55,29 @@ The namespace defaults to the kubernetes current-context namespace.
 When using the --bom flag, only the built image's bill of materials will be printed.
 Using the --bom flag will read metadata from the build's built image in the registry
 Therefore, you must have credentials to access the registry on your machine when using the --bom flag.
---registry-ca-cert-path and --registry-verify-certs are only used when using the --bom flag.`,
-		Example:      "kp build status my-image\nkp build status my-image -b 2 -n my-namespace",
+--registry-ca-cert-path and --registry-verify-certs are only used when using the --bom flag.
+
+The --bom-format flag converts the buildpack bill of materials to CycloneDX or SPDX JSON
+and the --bom-file flag writes it to a file instead of stdout. Both imply the --bom flag.`,
+		Example: `kp build status my-image
+kp build status my-image -b 2 -n my-namespace
+kp build status my-image --bom
+kp build status my-image -b 2 --bom-format cyclonedx --bom-file bom.cdx.json`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bomlib.ValidateFormat(bomFormat); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("bom-format") || bomFile != "" {
+				bom = true
+			}
+
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -75,8 +99,12 @@ Therefore, you must have credentials to access the registry on your machine when
 					return err
 				}
 
+				if !printer.IsTable() {
+					return printer.PrintObj(&bld)
+				}
+
 				if bom {
-					return displayBOM(authn.DefaultKeychain, cmd, bld, rup, tlsConfig)
+					return displayBOM(authn.DefaultKeychain, cmd, bld, rup, tlsConfig, bomFormat, bomFile)
 				} else {
 					return displayBuildStatus(cmd, bld)
 				}
@@ -86,6 +114,9 @@ Therefore, you must have credentials to access the registry on your machine when
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number")
 	cmd.Flags().BoolVar(&bom, "bom", false, "only print the built image bill of materials")
+	cmd.Flags().StringVar(&bomFormat, "bom-format", bomlib.RawFormat, "bill of materials format: "+strings.Join(bomlib.Formats, ", "))
+	cmd.Flags().StringVar(&bomFile, "bom-file", "", "write the bill of materials to a file")
+	commands.SetOutputFlag(cmd)
 	commands.SetTLSFlags(cmd, &tlsConfig)
 
 	return cmd
@@ -263,7 +294,7 @@ func reasonsAndChanges(changesJson string) (string, string, error) {
 	return reasonsStr, changesStr, nil
 }
 
-func displayBOM(keychain authn.Keychain, cmd *cobra.Command, bld v1alpha2.Build, rup registry.UtilProvider, tlsConfig registry.TLSConfig) error {
+func displayBOM(keychain authn.Keychain, cmd *cobra.Command, bld v1alpha2.Build, rup registry.UtilProvider, tlsConfig registry.TLSConfig, format, file string) error {
 	cond := bld.Status.GetCondition(corev1alpha1.ConditionSucceeded)
 	if cond == nil || !cond.IsTrue() {
 		return errors.Errorf("build has failed or has not finished")
@@ -301,10 +332,45 @@ func displayBOM(keychain authn.Keychain, cmd *cobra.Command, bld v1alpha2.Build,
 		return errors.New("could not find bom on build image metadata")
 	}
 
-	output, err := json.Marshal(bom)
+	raw, err := json.Marshal(bom)
+	if err != nil {
+		return err
+	}
+
+	subject, err := bomSubject(bld, image)
+	if err != nil {
+		return err
+	}
+
+	output, err := bomlib.Convert(format, subject, raw)
 	if err != nil {
 		return err
 	}
 
-	return h.Printlnf(string(output))
+	if file == "" {
+		return h.Printlnf("%s", output)
+	}
+
+	if err := ioutil.WriteFile(file, append(output, '\n'), 0644); err != nil {
+		return err
+	}
+	return h.PrintResult("Bill of materials written to '%s'", file)
+}
+
+func bomSubject(bld v1alpha2.Build, image v1.Image) (bomlib.Subject, error) {
+	ref, err := name.ParseReference(bld.Status.LatestImage)
+	if err != nil {
+		return bomlib.Subject{}, err
+	}
+
+	digest, err := image.Digest()
+	if err != nil {
+		return bomlib.Subject{}, err
+	}
+
+	return bomlib.Subject{
+		Name:    ref.Context().Name(),
+		Digest:  digest.String(),
+		Created: bld.Status.GetCondition(corev1alpha1.ConditionSucceeded).LastTransitionTime.Inner.Time,
+	}, nil
 }
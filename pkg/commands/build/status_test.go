@@ -4,6 +4,10 @@
 package build_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"text/template"
@@ -15,6 +19,7 @@ import (
 	"github.com/sclevine/spec"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -824,6 +829,100 @@ bp-id-2         bp-version-2         mysupercoolsite2.com
 				}.TestKpack(t, cmdFunc)
 			})
 		})
+
+		when("using the --bom-format flag", func() {
+			builds := testhelpers.BuildsToRuntimeObjs(testhelpers.MakeTestBuilds(image, defaultNamespace))
+
+			bomCmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+				clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+
+				fakeFetcher := registryfakes.Fetcher{}
+				fakeFetcher.AddImage("repo.com/image-1:tag", registryfakes.NewFakeLabeledImage(
+					"io.buildpacks.build.metadata",
+					`{"bom":[{"name":"some-dependency","metadata":{"version":"1.2.3","licenses":[{"type":"MIT"}]},"buildpack":{"id":"some-buildpack","version":"4.5.6"}}]}`,
+					"some-digest",
+				))
+
+				fakeRegistryUtilProvider := &registryfakes.UtilProvider{
+					FakeFetcher: &fakeFetcher,
+				}
+				return build.NewStatusCommand(clientSetProvider, fakeRegistryUtilProvider)
+			}
+
+			const expectedCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.3",
+  "version": 1,
+  "metadata": {
+    "tools": [
+      {
+        "name": "kp"
+      }
+    ],
+    "component": {
+      "type": "container",
+      "name": "repo.com/image-1",
+      "version": "sha256:some-digest"
+    }
+  },
+  "components": [
+    {
+      "type": "library",
+      "name": "some-dependency",
+      "version": "1.2.3",
+      "licenses": [
+        {
+          "license": {
+            "id": "MIT"
+          }
+        }
+      ],
+      "properties": [
+        {
+          "name": "kp:buildpack",
+          "value": "some-buildpack@4.5.6"
+        }
+      ]
+    }
+  ]
+}
+`
+
+			it("prints the converted bom", func() {
+				testhelpers.CommandTest{
+					Objects:        builds,
+					Args:           []string{image, "-b", "1", "--bom-format", "cyclonedx"},
+					ExpectedOutput: expectedCycloneDX,
+				}.TestKpack(t, bomCmdFunc)
+			})
+
+			it("writes the converted bom to a file", func() {
+				dir, err := ioutil.TempDir("", "bom-test")
+				require.NoError(t, err)
+				defer os.RemoveAll(dir)
+
+				bomFile := filepath.Join(dir, "bom.json")
+
+				testhelpers.CommandTest{
+					Objects:        builds,
+					Args:           []string{image, "-b", "1", "--bom-format", "cyclonedx", "--bom-file", bomFile},
+					ExpectedOutput: fmt.Sprintf("Bill of materials written to '%s'\n", bomFile),
+				}.TestKpack(t, bomCmdFunc)
+
+				contents, err := ioutil.ReadFile(bomFile)
+				require.NoError(t, err)
+				require.Equal(t, expectedCycloneDX, string(contents))
+			})
+
+			it("errors on unsupported formats", func() {
+				testhelpers.CommandTest{
+					Objects:             builds,
+					Args:                []string{image, "--bom-format", "xml"},
+					ExpectErr:           true,
+					ExpectedErrorOutput: "Error: unsupported bom format 'xml', must be one of: raw, cyclonedx, spdx\n",
+				}.TestKpack(t, bomCmdFunc)
+			})
+		})
 	})
 }
 
@@ -37,6 +37,9 @@ The builder will be created only if it does not exist in the provided namespace.
 A buildpack order must be provided with either the path to an order yaml or via the --buildpack flag.
 Multiple buildpacks provided via the --buildpack flag will be added to the same order group. 
 
+The order is validated against the buildpacks of the ClusterStore before the builder is applied.
+Buildpacks or versions missing from the store are reported as errors and buildpacks that do not support the stack as warnings.
+
 The namespace defaults to the kubernetes current-context namespace.`,
 		Example: `kp builder create my-builder --tag my-registry.com/my-builder-tag --order /path/to/order.yaml --stack tiny --store my-store
 kp builder create my-builder --tag my-registry.com/my-builder-tag --order /path/to/order.yaml
@@ -80,6 +83,8 @@ type CommandFlags struct {
 	store      string
 	order      string
 	buildpacks []string
+	orderEdits builder.OrderEdits
+	showOrder  bool
 }
 
 func create(ctx context.Context, name string, flags CommandFlags, ch *commands.CommandHelper, cs k8s.ClientSet, w commands.ResourceWaiter) (err error) {
@@ -124,6 +129,11 @@ func create(ctx context.Context, name string, flags CommandFlags, ch *commands.C
 		}
 	}
 
+	err = commands.ValidateOrder(ctx, ch, cs, bldr.Spec.Order, bldr.Spec.Store.Name, bldr.Spec.Stack.Name)
+	if err != nil {
+		return err
+	}
+
 	err = k8s.SetLastAppliedCfg(bldr)
 	if err != nil {
 		return err
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -30,6 +31,11 @@ The namespace defaults to the kubernetes current-context namespace.`,
 		Example:      "kp builder list\nkp builder list -n my-namespace",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -44,36 +50,41 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return errors.New("no builders found")
 			} else {
 				sort.Slice(builderList.Items, Sort(builderList.Items))
-				return displayClusterBuildersTable(cmd, builderList)
+				return displayClusterBuildersTable(printer, builderList)
 			}
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayClusterBuildersTable(cmd *cobra.Command, builderList *v1alpha2.BuilderList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Name", "Ready", "Stack", "Image")
-	if err != nil {
-		return err
+func displayClusterBuildersTable(printer *commands.OutputPrinter, builderList *v1alpha2.BuilderList) error {
+	var objs []runtime.Object
+	for i := range builderList.Items {
+		objs = append(objs, &builderList.Items[i])
 	}
 
-	for _, bldr := range builderList.Items {
-		err := writer.AddRow(
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "Name"},
+		{Header: "Ready"},
+		{Header: "Stack"},
+		{Header: "Image"},
+		{Header: "Cluster Stack", Wide: true},
+		{Header: "Cluster Store", Wide: true},
+	}, func(obj runtime.Object) []string {
+		bldr := obj.(*v1alpha2.Builder)
+		return []string{
 			bldr.ObjectMeta.Name,
-			getStatus(bldr),
+			getStatus(*bldr),
 			bldr.Status.Stack.ID,
 			bldr.Status.LatestImage,
-		)
-
-		if err != nil {
-			return err
+			bldr.Spec.Stack.Name,
+			bldr.Spec.Store.Name,
 		}
-	}
-
-	return writer.Write()
+	})
 }
 
 func Sort(builds []v1alpha2.Builder) func(i int, j int) bool {
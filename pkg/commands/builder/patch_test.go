@@ -167,6 +167,54 @@ func testBuilderPatchCommand(t *testing.T, when spec.G, it spec.S) {
 		}.TestKpack(t, cmdFunc)
 	})
 
+	it("patches a Builder with order edits", func() {
+		bldr.Namespace = defaultNamespace
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				bldr,
+			},
+			Args: []string{
+				bldr.Name,
+				"--remove-buildpack", "1:org.cloudfoundry.nodejs",
+				"--add-buildpack", "2:org.cloudfoundry.procfile",
+			},
+			ExpectedOutput: `Builder "test-builder" patched
+`,
+			ExpectPatches: []string{
+				`{"spec":{"order":[{"group":[{"id":"org.cloudfoundry.go"},{"id":"org.cloudfoundry.procfile"}]}]}}`,
+			},
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("does not patch when the order cannot be resolved by the store", func() {
+		store := &v1alpha2.ClusterStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-store"},
+			Status: v1alpha2.ClusterStoreStatus{
+				Buildpacks: []corev1alpha1.StoreBuildpack{
+					{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "org.cloudfoundry.nodejs", Version: "1.0.0"}},
+					{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "org.cloudfoundry.go", Version: "1.0.0"}},
+				},
+			},
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				bldr,
+				store,
+			},
+			Args: []string{
+				bldr.Name,
+				"--pin-buildpack", "org.cloudfoundry.go@2.0.0",
+				"-n", bldr.Namespace,
+			},
+			ExpectErr: true,
+			ExpectedErrorOutput: `Error: order cannot be resolved by ClusterStore "some-store":
+  buildpack "org.cloudfoundry.go" version "2.0.0" not found, closest available versions: 1.0.0
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
 	it("returns error when buildpack and order flags are used together", func() {
 		bldr.Namespace = defaultNamespace
 
@@ -33,6 +33,11 @@ The namespace defaults to the kubernetes current-context namespace.`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -43,11 +48,16 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return err
 			}
 
+			if !printer.IsTable() {
+				return printer.PrintObj(bldr)
+			}
+
 			return displayBuilderStatus(bldr, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
@@ -0,0 +1,92 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/builder"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+const OrderEditsHelp = `The order can be edited incrementally instead of replaced. Groups are numbered from 1 as shown by the status command:
+
+  "--add-buildpack <group>:<buildpack>[@<version>]" to add a buildpack to the end of a group.
+  "--remove-buildpack <group>:<buildpack>" to remove a buildpack from a group, empty groups are removed.
+  "--insert-group <position>:<buildpack>[,<buildpack>...]" to insert a new group at a position.
+  "--optional-buildpack <group>:<buildpack>" and "--required-buildpack <group>:<buildpack>" to change whether a buildpack is optional.
+  "--pin-buildpack <buildpack>@<version>" and "--unpin-buildpack <buildpack>" to change the version of a buildpack in every group.
+
+Group numbers refer to the order before the edits and groups are inserted after all other edits.
+The "--show-order" flag prints the difference between the current and the new order.`
+
+func SetOrderEditFlags(cmd *cobra.Command, edits *builder.OrderEdits, showOrder *bool) {
+	cmd.Flags().StringArrayVar(&edits.AddBuildpacks, "add-buildpack", nil, "add a buildpack to an order group in the form of '<group>:<buildpack>[@<version>]'")
+	cmd.Flags().StringArrayVar(&edits.RemoveBuildpacks, "remove-buildpack", nil, "remove a buildpack from an order group in the form of '<group>:<buildpack>'")
+	cmd.Flags().StringArrayVar(&edits.InsertGroups, "insert-group", nil, "insert an order group in the form of '<position>:<buildpack>[,<buildpack>...]'")
+	cmd.Flags().StringArrayVar(&edits.OptionalBuildpacks, "optional-buildpack", nil, "mark a buildpack of an order group optional in the form of '<group>:<buildpack>'")
+	cmd.Flags().StringArrayVar(&edits.RequiredBuildpacks, "required-buildpack", nil, "mark a buildpack of an order group required in the form of '<group>:<buildpack>'")
+	cmd.Flags().StringArrayVar(&edits.PinBuildpacks, "pin-buildpack", nil, "pin the version of a buildpack in every order group in the form of '<buildpack>@<version>'")
+	cmd.Flags().StringArrayVar(&edits.UnpinBuildpacks, "unpin-buildpack", nil, "remove the version of a buildpack in every order group")
+	cmd.Flags().BoolVar(showOrder, "show-order", false, "print the difference between the current and the new buildpack order")
+}
+
+// PrintOrderChange prints the difference between two builder orders.
+func PrintOrderChange(ch *CommandHelper, before, after []corev1alpha1.OrderEntry) error {
+	diff, err := Differ{}.Diff(builder.FormatOrder(before), builder.FormatOrder(after))
+	if err != nil {
+		return err
+	}
+
+	if diff == "" {
+		return ch.Printlnf("Order unchanged")
+	}
+
+	_, err = ch.OutOrErrWriter().Write([]byte(diff))
+	return err
+}
+
+// ValidateOrder resolves a builder order against its ClusterStore and ClusterStack before the builder is applied.
+// Validation is skipped when the store does not exist or has not resolved any buildpacks yet.
+func ValidateOrder(ctx context.Context, ch *CommandHelper, cs k8s.ClientSet, order []corev1alpha1.OrderEntry, storeName, stackName string) error {
+	store, err := cs.KpackClient.KpackV1alpha2().ClusterStores().Get(ctx, storeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if len(store.Status.Buildpacks) == 0 {
+		return nil
+	}
+
+	var stack *v1alpha2.ClusterStack
+	stack, err = cs.KpackClient.KpackV1alpha2().ClusterStacks().Get(ctx, stackName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		stack = nil
+	} else if err != nil {
+		return err
+	}
+
+	validation := builder.ValidateOrder(order, store, stack)
+
+	for _, warning := range validation.Warnings {
+		if err := ch.Printlnf("WARNING: %s", warning); err != nil {
+			return err
+		}
+	}
+
+	if len(validation.Errors) > 0 {
+		return errors.Errorf("order cannot be resolved by ClusterStore %q:\n  %s", storeName, strings.Join(validation.Errors, "\n  "))
+	}
+	return nil
+}
@@ -40,6 +40,9 @@ The cluster builder will be created only if it does not exist.
 A buildpack order must be provided with either the path to an order yaml or via the --buildpack flag.
 Multiple buildpacks provided via the --buildpack flag will be added to the same order group. 
 
+The order is validated against the buildpacks of the ClusterStore before the builder is applied.
+Buildpacks or versions missing from the store are reported as errors and buildpacks that do not support the stack as warnings.
+
 Tag when not specified, defaults to a combination of the default repository and specified builder name.
 The default repository is read from the "default.repository" key in the "kp-config" ConfigMap within "kpack" namespace.
 `,
@@ -82,6 +85,8 @@ type CommandFlags struct {
 	store      string
 	order      string
 	buildpacks []string
+	orderEdits builder.OrderEdits
+	showOrder  bool
 }
 
 func create(ctx context.Context, name string, flags CommandFlags, ch *commands.CommandHelper, cs k8s.ClientSet, waiter commands.ResourceWaiter) error {
@@ -137,6 +142,11 @@ func create(ctx context.Context, name string, flags CommandFlags, ch *commands.C
 		}
 	}
 
+	err = commands.ValidateOrder(ctx, ch, cs, cb.Spec.Order, cb.Spec.Store.Name, cb.Spec.Stack.Name)
+	if err != nil {
+		return err
+	}
+
 	err = k8s.SetLastAppliedCfg(cb)
 	if err != nil {
 		return err
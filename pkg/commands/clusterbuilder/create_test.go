@@ -515,4 +515,101 @@ status:
 		})
 	})
 
+	when("the store has resolved buildpacks", func() {
+		storeBuildpack := func(id, version, stackId string) corev1alpha1.StoreBuildpack {
+			return corev1alpha1.StoreBuildpack{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{Id: id, Version: version},
+				Stacks:        []corev1alpha1.BuildpackStack{{ID: stackId}},
+			}
+		}
+
+		store := &v1alpha2.ClusterStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-store"},
+			Status: v1alpha2.ClusterStoreStatus{
+				Buildpacks: []corev1alpha1.StoreBuildpack{
+					storeBuildpack("org.cloudfoundry.nodejs", "1.0.0", "some-stack-id"),
+					storeBuildpack("org.cloudfoundry.go", "1.0.0", "some-stack-id"),
+					storeBuildpack("org.cloudfoundry.go", "1.2.0", "some-stack-id"),
+				},
+			},
+		}
+
+		stack := &v1alpha2.ClusterStack{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-stack"},
+			Status: v1alpha2.ClusterStackStatus{
+				ResolvedClusterStack: v1alpha2.ResolvedClusterStack{Id: "some-stack-id"},
+			},
+		}
+
+		it("creates a ClusterBuilder when the order can be resolved", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					config,
+					store,
+					stack,
+				},
+				Args: []string{
+					expectedBuilder.Name,
+					"--tag", expectedBuilder.Spec.Tag,
+					"--stack", expectedBuilder.Spec.Stack.Name,
+					"--store", expectedBuilder.Spec.Store.Name,
+					"--order", "./testdata/order.yaml",
+				},
+				ExpectedOutput: `ClusterBuilder "test-builder" created
+`,
+				ExpectCreates: []runtime.Object{
+					expectedBuilder,
+				},
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("fails before creating when buildpacks are missing from the store", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					config,
+					store,
+					stack,
+				},
+				Args: []string{
+					expectedBuilder.Name,
+					"--tag", expectedBuilder.Spec.Tag,
+					"--stack", expectedBuilder.Spec.Stack.Name,
+					"--store", expectedBuilder.Spec.Store.Name,
+					"--buildpack", "org.cloudfoundry.go@1.1.0,org.cloudfoundry.ruby",
+				},
+				ExpectErr: true,
+				ExpectedErrorOutput: `Error: order cannot be resolved by ClusterStore "some-store":
+  buildpack "org.cloudfoundry.go" version "1.1.0" not found, closest available versions: 1.0.0, 1.2.0
+  buildpack "org.cloudfoundry.ruby" not found
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("warns when buildpacks do not support the stack", func() {
+			otherStack := stack.DeepCopy()
+			otherStack.Status.Id = "some-other-stack-id"
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					config,
+					store,
+					otherStack,
+				},
+				Args: []string{
+					expectedBuilder.Name,
+					"--tag", expectedBuilder.Spec.Tag,
+					"--stack", expectedBuilder.Spec.Stack.Name,
+					"--store", expectedBuilder.Spec.Store.Name,
+					"--order", "./testdata/order.yaml",
+				},
+				ExpectedOutput: `WARNING: buildpack "org.cloudfoundry.nodejs@1.0.0" does not support stack "some-other-stack-id" of ClusterStack "some-stack"
+WARNING: buildpack "org.cloudfoundry.go@1.2.0" does not support stack "some-other-stack-id" of ClusterStack "some-stack"
+ClusterBuilder "test-builder" created
+`,
+				ExpectCreates: []runtime.Object{
+					expectedBuilder,
+				},
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+	})
 }
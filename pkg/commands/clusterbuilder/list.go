@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -25,6 +26,11 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Example:      "kp cb list",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
 				return err
@@ -39,34 +45,39 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return errors.New("no clusterbuilders found")
 			} else {
 				sort.Slice(clusterBuilderList.Items, Sort(clusterBuilderList.Items))
-				return displayClusterBuildersTable(cmd, clusterBuilderList)
+				return displayClusterBuildersTable(printer, clusterBuilderList)
 			}
 		},
 	}
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayClusterBuildersTable(cmd *cobra.Command, builderList *v1alpha2.ClusterBuilderList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Name", "Ready", "Stack", "Image")
-	if err != nil {
-		return err
+func displayClusterBuildersTable(printer *commands.OutputPrinter, builderList *v1alpha2.ClusterBuilderList) error {
+	var objs []runtime.Object
+	for i := range builderList.Items {
+		objs = append(objs, &builderList.Items[i])
 	}
 
-	for _, bldr := range builderList.Items {
-		err := writer.AddRow(
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "Name"},
+		{Header: "Ready"},
+		{Header: "Stack"},
+		{Header: "Image"},
+		{Header: "Cluster Stack", Wide: true},
+		{Header: "Cluster Store", Wide: true},
+	}, func(obj runtime.Object) []string {
+		bldr := obj.(*v1alpha2.ClusterBuilder)
+		return []string{
 			bldr.ObjectMeta.Name,
-			getStatus(bldr),
+			getStatus(*bldr),
 			bldr.Status.Stack.ID,
 			bldr.Status.LatestImage,
-		)
-
-		if err != nil {
-			return err
+			bldr.Spec.Stack.Name,
+			bldr.Spec.Store.Name,
 		}
-	}
-
-	return writer.Write()
+	})
 }
 
 func Sort(builds []v1alpha2.ClusterBuilder) func(i int, j int) bool {
@@ -29,11 +29,18 @@ func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dyn
 		Short: "Patch an existing cluster builder configuration",
 		Long: `Patch an existing clusterbuilder configuration by providing command line arguments.
 
-A buildpack order must be provided with either the path to an order yaml or via the --buildpack flag.
-Multiple buildpacks provided via the --buildpack flag will be added to the same order group.`,
+A buildpack order can be replaced with either the path to an order yaml or via the --buildpack flag.
+Multiple buildpacks provided via the --buildpack flag will be added to the same order group.
+
+The order is validated against the buildpacks of the ClusterStore before the builder is applied.
+Buildpacks or versions missing from the store are reported as errors and buildpacks that do not support the stack as warnings.
+
+` + commands.OrderEditsHelp,
 		Example: `kp cb patch my-builder --order /path/to/order.yaml --stack tiny --store my-store
 kp cb patch my-builder --order /path/to/order.yaml
-kp cb patch my-builder --buildpack my-buildpack-id --buildpack my-other-buildpack@1.0.1`,
+kp cb patch my-builder --buildpack my-buildpack-id --buildpack my-other-buildpack@1.0.1
+kp cb patch my-builder --add-buildpack 2:my-buildpack-id --insert-group 1:my-other-buildpack,my-optional-buildpack --show-order
+kp cb patch my-builder --optional-buildpack 1:my-buildpack-id --pin-buildpack my-other-buildpack@1.0.1 --dry-run --show-order`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -64,6 +71,7 @@ kp cb patch my-builder --buildpack my-buildpack-id --buildpack my-other-buildpac
 	cmd.Flags().StringVar(&flags.store, "store", "", "buildpack store to use")
 	cmd.Flags().StringVarP(&flags.order, "order", "o", "", "path to buildpack order yaml")
 	cmd.Flags().StringSliceVarP(&flags.buildpacks, "buildpack", "b", []string{}, "buildpack id and optional version in the form of either '<buildpack>@<version>' or '<buildpack>'\n  repeat for each buildpack in order, or supply once with comma-separated list")
+	commands.SetOrderEditFlags(cmd, &flags.orderEdits, &flags.showOrder)
 	commands.SetDryRunOutputFlags(cmd)
 	return cmd
 }
@@ -100,6 +108,31 @@ func patch(ctx context.Context, cb *v1alpha2.ClusterBuilder, flags CommandFlags,
 		patchedCb.Spec.Order = builder.CreateOrder(flags.buildpacks)
 	}
 
+	if !flags.orderEdits.IsEmpty() {
+		if len(flags.buildpacks) > 0 || flags.order != "" {
+			return fmt.Errorf("cannot use --order or --buildpack with order edits")
+		}
+
+		order, err := flags.orderEdits.Apply(cb.Spec.Order)
+		if err != nil {
+			return err
+		}
+
+		patchedCb.Spec.Order = order
+	}
+
+	if flags.showOrder {
+		if err := commands.PrintOrderChange(ch, cb.Spec.Order, patchedCb.Spec.Order); err != nil {
+			return err
+		}
+	}
+
+	if flags.order != "" || len(flags.buildpacks) > 0 || !flags.orderEdits.IsEmpty() || flags.store != "" || flags.stack != "" {
+		if err := commands.ValidateOrder(ctx, ch, cs, patchedCb.Spec.Order, patchedCb.Spec.Store.Name, patchedCb.Spec.Stack.Name); err != nil {
+			return err
+		}
+	}
+
 	patch, err := k8s.CreatePatch(cb, patchedCb)
 	if err != nil {
 		return err
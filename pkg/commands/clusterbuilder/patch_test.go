@@ -6,6 +6,7 @@ package clusterbuilder_test
 import (
 	"testing"
 
+	"github.com/mgutz/ansi"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
@@ -142,6 +143,76 @@ func testClusterBuilderPatchCommand(t *testing.T, when spec.G, it spec.S) {
 		}.TestKpack(t, cmdFunc)
 	})
 
+	it("patches a ClusterBuilder with order edits", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				builder,
+			},
+			Args: []string{
+				builder.Name,
+				"--add-buildpack", "2:org.cloudfoundry.procfile@1.0.0",
+				"--optional-buildpack", "2:org.cloudfoundry.procfile",
+				"--insert-group", "1:org.cloudfoundry.java,org.cloudfoundry.procfile",
+				"--pin-buildpack", "org.cloudfoundry.go@2.0.0",
+			},
+			ExpectedOutput: `ClusterBuilder "test-builder" patched
+`,
+			ExpectPatches: []string{
+				`{"spec":{"order":[{"group":[{"id":"org.cloudfoundry.java"},{"id":"org.cloudfoundry.procfile"}]},{"group":[{"id":"org.cloudfoundry.nodejs"}]},{"group":[{"id":"org.cloudfoundry.go","version":"2.0.0"},{"id":"org.cloudfoundry.procfile","optional":true,"version":"1.0.0"}]}]}}`,
+			},
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("prints the order change with --show-order", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				builder,
+			},
+			Args: []string{
+				builder.Name,
+				"--pin-buildpack", "org.cloudfoundry.go@2.0.0",
+				"--show-order",
+				"--dry-run",
+			},
+			ExpectedOutput: `  Group #1
+    org.cloudfoundry.nodejs
+  Group #2
+` + ansi.Color("-", "red") + " " + ansi.Color("  org.cloudfoundry.go", "red") + `
+` + ansi.Color("+", "green") + " " + ansi.Color("  org.cloudfoundry.go@2.0.0", "green") + `
+ClusterBuilder "test-builder" patched (dry run)
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("returns an error for an invalid order edit", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				builder,
+			},
+			Args: []string{
+				builder.Name,
+				"--remove-buildpack", "3:org.cloudfoundry.go",
+			},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: invalid group 3, the order has 2 groups\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("returns an error when order edits are used with the buildpack flag", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				builder,
+			},
+			Args: []string{
+				builder.Name,
+				"--buildpack", "org.cloudfoundry.test-bp",
+				"--add-buildpack", "1:org.cloudfoundry.go",
+			},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: cannot use --order or --buildpack with order edits\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
 	it("returns error when buildpack and order flags are used together", func() {
 
 		testhelpers.CommandTest{
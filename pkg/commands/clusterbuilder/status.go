@@ -28,6 +28,11 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
 				return err
@@ -38,9 +43,14 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
+			if !printer.IsTable() {
+				return printer.PrintObj(bldr)
+			}
+
 			return displayBuilderStatus(bldr, cmd.OutOrStdout())
 		},
 	}
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
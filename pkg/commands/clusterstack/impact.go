@@ -0,0 +1,180 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterstack
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/clusterstack"
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func NewImpactCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var (
+		buildImageRef string
+		runImageRef   string
+		tlsCfg        registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "impact <name>",
+		Short: "Display the impact of a cluster stack update",
+		Long: `Prints the cluster builders and builders in every namespace that use a specific cluster-scoped stack
+and the number of image rebases and rebuilds an update of the stack will trigger, grouped by namespace.
+
+kpack rebases images when the run image changes and rebuilds them when the stack id changes.
+Images that have not been built yet are rebuilt.
+
+When "--build-image" and "--run-image" are provided, the impact of updating to those images is shown.
+Otherwise, the impact of a run image update is shown.
+No images are uploaded and the stack is not updated.`,
+		Example: `kp clusterstack impact my-stack
+kp clusterstack impact my-stack --build-image my-registry.com/build --run-image my-registry.com/run`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (buildImageRef == "") != (runImageRef == "") {
+				return errors.New("--build-image and --run-image must be provided together")
+			}
+
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			stack, err := cs.KpackClient.KpackV1alpha2().ClusterStacks().Get(ctx, args[0], metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			if buildImageRef == "" {
+				return displayImpact(ctx, cmd.OutOrStdout(), stack, clusterstack.StackChange{RunImageChanged: true}, cs)
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, false), rup.Fetcher(tlsCfg))
+			return displayUpdateImpact(ctx, authn.DefaultKeychain, stack, buildImageRef, runImageRef, factory, cs, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&buildImageRef, "build-image", "b", "", "build image tag or local tar file path to update to")
+	cmd.Flags().StringVarP(&runImageRef, "run-image", "r", "", "run image tag or local tar file path to update to")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
+
+// displayUpdateImpact displays the impact of updating the stack to the images without uploading them.
+func displayUpdateImpact(ctx context.Context, keychain authn.Keychain, stack *v1alpha2.ClusterStack, buildImageRef, runImageRef string, factory *clusterstack.Factory, cs k8s.ClientSet, out io.Writer) error {
+	stackId, err := factory.Uploader.ValidateStackIDs(keychain, buildImageRef, runImageRef)
+	if err != nil {
+		return err
+	}
+
+	kpConfig := config.NewKpConfigProvider(cs).GetKpConfig(ctx)
+
+	relocatedBuildImageRef, err := factory.RelocatedBuildImage(keychain, kpConfig, buildImageRef)
+	if err != nil {
+		return err
+	}
+
+	relocatedRunImageRef, err := factory.RelocatedRunImage(keychain, kpConfig, runImageRef)
+	if err != nil {
+		return err
+	}
+
+	change, err := clusterstack.NewStackChange(stack, relocatedBuildImageRef, relocatedRunImageRef, stackId)
+	if err != nil {
+		return err
+	}
+
+	return displayImpact(ctx, out, stack, change, cs)
+}
+
+func displayImpact(ctx context.Context, out io.Writer, stack *v1alpha2.ClusterStack, change clusterstack.StackChange, cs k8s.ClientSet) error {
+	impact, err := clusterstack.AnalyzeImpact(ctx, cs.KpackClient, stack.Name, change)
+	if err != nil {
+		return err
+	}
+
+	statusWriter := commands.NewStatusWriter(out)
+	if err := statusWriter.AddBlock("",
+		"Stack", stack.Name,
+		"Changes", changeText(change),
+	); err != nil {
+		return err
+	}
+	if err := statusWriter.Write(); err != nil {
+		return err
+	}
+
+	builderWriter, err := commands.NewTableWriter(out, "Builder Kind", "Namespace", "Name")
+	if err != nil {
+		return err
+	}
+
+	for _, b := range impact.Builders {
+		if err := builderWriter.AddRow(b.Kind, b.Namespace, b.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := builderWriter.Write(); err != nil {
+		return err
+	}
+
+	imageWriter, err := commands.NewTableWriter(out, "Namespace", "Images", "Rebases", "Rebuilds")
+	if err != nil {
+		return err
+	}
+
+	for _, n := range append(impact.Namespaces, total(impact)) {
+		if err := imageWriter.AddRow(n.Namespace, strconv.Itoa(n.Images), strconv.Itoa(n.Rebases), strconv.Itoa(n.Rebuilds)); err != nil {
+			return err
+		}
+	}
+
+	return imageWriter.Write()
+}
+
+func total(impact clusterstack.Impact) clusterstack.NamespaceImpact {
+	t := impact.Total()
+	t.Namespace = "Total"
+	return t
+}
+
+func changeText(change clusterstack.StackChange) string {
+	var changes []string
+	if change.BuildImageChanged {
+		changes = append(changes, "Build Image")
+	}
+	if change.RunImageChanged {
+		changes = append(changes, "Run Image")
+	}
+	if change.IdChanged {
+		changes = append(changes, "Stack Id")
+	}
+
+	if len(changes) == 0 {
+		return "None"
+	}
+	return strings.Join(changes, ", ")
+}
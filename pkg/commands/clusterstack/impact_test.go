@@ -0,0 +1,190 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterstack_test
+
+import (
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	clusterstackcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/clusterstack"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestImpactCommand(t *testing.T) {
+	spec.Run(t, "TestImpactCommand", testImpactCommand)
+}
+
+func testImpactCommand(t *testing.T, when spec.G, it spec.S) {
+	fakeRegistryUtilProvider := &registryfakes.UtilProvider{
+		FakeFetcher: registryfakes.NewStackImagesFetcher(
+			registryfakes.StackInfo{
+				StackID: "new-stack-id",
+				BuildImg: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/repo/new-build",
+					Digest: "new-build-image-digest",
+				},
+				RunImg: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/repo/new-run",
+					Digest: "run-image-digest",
+				},
+			},
+		),
+	}
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository": "default-registry.io/default-repo",
+		},
+	}
+
+	stack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-stack",
+		},
+		Status: v1alpha2.ClusterStackStatus{
+			ResolvedClusterStack: v1alpha2.ResolvedClusterStack{
+				Id: "stack-id",
+				BuildImage: v1alpha2.ClusterStackStatusImage{
+					LatestImage: "default-registry.io/default-repo/build@sha256:build-image-digest",
+				},
+				RunImage: v1alpha2.ClusterStackStatusImage{
+					LatestImage: "default-registry.io/default-repo/run@sha256:run-image-digest",
+				},
+			},
+		},
+	}
+
+	clusterBuilder := &v1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-cluster-builder",
+		},
+		Spec: v1alpha2.ClusterBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "some-stack"},
+			},
+		},
+	}
+
+	otherClusterBuilder := &v1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other-cluster-builder",
+		},
+		Spec: v1alpha2.ClusterBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "other-stack"},
+			},
+		},
+	}
+
+	builder := &v1alpha2.Builder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-builder",
+			Namespace: "namespace-b",
+		},
+		Spec: v1alpha2.NamespacedBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "some-stack"},
+			},
+		},
+	}
+
+	image := func(namespace, name, builderKind, builderName, latestImage string) *v1alpha2.Image {
+		return &v1alpha2.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha2.ImageSpec{
+				Builder: corev1.ObjectReference{Kind: builderKind, Name: builderName},
+			},
+			Status: v1alpha2.ImageStatus{
+				LatestImage: latestImage,
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		config,
+		stack,
+		clusterBuilder,
+		otherClusterBuilder,
+		builder,
+		image("namespace-a", "built-image", v1alpha2.ClusterBuilderKind, "some-cluster-builder", "some-registry.io/repo/built@sha256:digest"),
+		image("namespace-a", "unbuilt-image", v1alpha2.ClusterBuilderKind, "some-cluster-builder", ""),
+		image("namespace-b", "builder-image", v1alpha2.BuilderKind, "some-builder", "some-registry.io/repo/builder@sha256:digest"),
+		image("namespace-c", "same-name-builder-image", v1alpha2.BuilderKind, "some-builder", "some-registry.io/repo/other@sha256:digest"),
+		image("namespace-c", "other-stack-image", v1alpha2.ClusterBuilderKind, "other-cluster-builder", "some-registry.io/repo/other@sha256:digest"),
+	}
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+		return clusterstackcmds.NewImpactCommand(clientSetProvider, fakeRegistryUtilProvider)
+	}
+
+	it("displays the rebases and rebuilds of a run image update by namespace", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{"some-stack"},
+			ExpectedOutput: `Stack:      some-stack
+Changes:    Run Image
+
+BUILDER KIND      NAMESPACE      NAME
+ClusterBuilder                   some-cluster-builder
+Builder           namespace-b    some-builder
+
+NAMESPACE      IMAGES    REBASES    REBUILDS
+namespace-a    2         1          1
+namespace-b    1         1          0
+Total          3         2          1
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("displays the impact of updating to specific images", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args: []string{
+				"some-stack",
+				"--build-image", "some-registry.io/repo/new-build",
+				"--run-image", "some-registry.io/repo/new-run",
+			},
+			ExpectedOutput: `Stack:      some-stack
+Changes:    Build Image, Stack Id
+
+BUILDER KIND      NAMESPACE      NAME
+ClusterBuilder                   some-cluster-builder
+Builder           namespace-b    some-builder
+
+NAMESPACE      IMAGES    REBASES    REBUILDS
+namespace-a    2         0          2
+namespace-b    1         0          1
+Total          3         0          3
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when only one of the images is provided", func() {
+		testhelpers.CommandTest{
+			Objects:             objects,
+			Args:                []string{"some-stack", "--build-image", "some-registry.io/repo/new-build"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: --build-image and --run-image must be provided together\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
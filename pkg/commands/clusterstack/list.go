@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -22,6 +23,11 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Example:      "kp clusterstack list",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
 				return err
@@ -35,29 +41,32 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			if len(stackList.Items) == 0 {
 				return errors.New("no clusterstacks found")
 			} else {
-				return displayStacksTable(cmd, stackList)
+				return displayStacksTable(printer, stackList)
 			}
 
 		},
 	}
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayStacksTable(cmd *cobra.Command, stackList *v1alpha2.ClusterStackList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY", "ID")
-	if err != nil {
-		return err
-	}
-
-	for _, s := range stackList.Items {
-		err := writer.AddRow(s.Name, getReadyText(s), s.Status.Id)
-		if err != nil {
-			return err
-		}
+func displayStacksTable(printer *commands.OutputPrinter, stackList *v1alpha2.ClusterStackList) error {
+	var objs []runtime.Object
+	for i := range stackList.Items {
+		objs = append(objs, &stackList.Items[i])
 	}
 
-	return writer.Write()
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "NAME"},
+		{Header: "READY"},
+		{Header: "ID"},
+		{Header: "BUILD IMAGE", Wide: true},
+		{Header: "RUN IMAGE", Wide: true},
+	}, func(obj runtime.Object) []string {
+		s := obj.(*v1alpha2.ClusterStack)
+		return []string{s.Name, getReadyText(*s), s.Status.Id, s.Status.BuildImage.LatestImage, s.Status.RunImage.LatestImage}
+	})
 }
 
 func getReadyText(s v1alpha2.ClusterStack) string {
@@ -30,6 +30,11 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
 				return err
@@ -40,11 +45,17 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
+			if !printer.IsTable() {
+				return printer.PrintObj(stack)
+			}
+
+			verbose = verbose || printer.IsWide()
 			return displayStackStatus(cmd.OutOrStdout(), stack, verbose)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "display mixins")
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
@@ -79,6 +79,30 @@ Mixins:         mixin1, mixin2
 			}.TestKpack(t, cmdFunc)
 		})
 
+		it("includes mixins with the wide output format", func() {
+			const expectedOutput = `Status:         Unknown
+Id:             some-stack-id
+Run Image:      some-build-image
+Build Image:    some-run-image
+Mixins:         mixin1, mixin2
+
+`
+
+			testhelpers.CommandTest{
+				Objects:        append([]runtime.Object{stck}),
+				Args:           []string{"some-stack", "-o", "wide"},
+				ExpectedOutput: expectedOutput,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("prints the stack with a jsonpath output format", func() {
+			testhelpers.CommandTest{
+				Objects:        append([]runtime.Object{stck}),
+				Args:           []string{"some-stack", "-o", "jsonpath={.kind}/{.status.id}"},
+				ExpectedOutput: "ClusterStack/some-stack-id",
+			}.TestKpack(t, cmdFunc)
+		})
+
 		when("the status is not ready", func() {
 			it("prints the status message", func() {
 				stck.Status.Conditions = append(stck.Status.Conditions, corev1alpha1.Condition{
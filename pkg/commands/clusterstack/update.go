@@ -33,6 +33,7 @@ func NewUpdateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.Util
 	var (
 		buildImageRef string
 		runImageRef   string
+		showImpact    bool
 		tlsCfg        registry.TLSConfig
 	)
 
@@ -42,9 +43,13 @@ func NewUpdateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.Util
 		Long: `Updates the run and build images of a specific cluster-scoped stack.
 
 The run and build images will be uploaded to the the registry configured on your stack.
-Therefore, you must have credentials to access the registry on your machine.`,
+Therefore, you must have credentials to access the registry on your machine.
+
+Use "--show-impact" to display the builders using the stack and the image rebases and rebuilds the update will trigger
+instead of updating the stack. See "kp clusterstack impact" for details.`,
 		Example: `kp clusterstack update my-stack --build-image my-registry.com/build --run-image my-registry.com/run
-kp clusterstack update my-stack --build-image ../path/to/build.tar --run-image ../path/to/run.tar`,
+kp clusterstack update my-stack --build-image ../path/to/build.tar --run-image ../path/to/run.tar
+kp clusterstack update my-stack --build-image my-registry.com/build --run-image my-registry.com/run --show-impact`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,6 +70,11 @@ kp clusterstack update my-stack --build-image ../path/to/build.tar --run-image .
 				return err
 			}
 
+			if showImpact {
+				factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, false), rup.Fetcher(tlsCfg))
+				return displayUpdateImpact(ctx, authn.DefaultKeychain, stack, buildImageRef, runImageRef, factory, cs, cmd.OutOrStdout())
+			}
+
 			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
 
 			return update(ctx, authn.DefaultKeychain, stack, buildImageRef, runImageRef, factory, ch, cs, newWaiter(cs.DynamicClient))
@@ -73,6 +83,7 @@ kp clusterstack update my-stack --build-image ../path/to/build.tar --run-image .
 
 	cmd.Flags().StringVarP(&buildImageRef, "build-image", "b", "", "build image tag or local tar file path")
 	cmd.Flags().StringVarP(&runImageRef, "run-image", "r", "", "run image tag or local tar file path")
+	cmd.Flags().BoolVar(&showImpact, "show-impact", false, "display the impact of the update without updating the stack")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
 	_ = cmd.MarkFlagRequired("build-image")
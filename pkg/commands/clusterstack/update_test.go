@@ -148,6 +148,59 @@ ClusterStack "stack-name" updated
 		require.Len(t, fakeWaiter.WaitCalls, 1)
 	})
 
+	it("displays the impact of the update without updating the stack with --show-impact", func() {
+		builder := &v1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "some-builder",
+			},
+			Spec: v1alpha2.ClusterBuilderSpec{
+				BuilderSpec: v1alpha2.BuilderSpec{
+					Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "stack-name"},
+				},
+			},
+		}
+
+		image := &v1alpha2.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-image",
+				Namespace: "some-namespace",
+			},
+			Spec: v1alpha2.ImageSpec{
+				Builder: corev1.ObjectReference{Kind: v1alpha2.ClusterBuilderKind, Name: "some-builder"},
+			},
+			Status: v1alpha2.ImageStatus{
+				LatestImage: "some-registry.io/repo/some-image@sha256:some-digest",
+			},
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				config,
+				stack,
+				builder,
+				image,
+			},
+			Args: []string{
+				"stack-name",
+				"--build-image", "some-registry.io/repo/new-build",
+				"--run-image", "some-registry.io/repo/new-run",
+				"--show-impact",
+			},
+			ExpectedOutput: `Stack:      stack-name
+Changes:    Build Image, Run Image
+
+BUILDER KIND      NAMESPACE    NAME
+ClusterBuilder                 some-builder
+
+NAMESPACE         IMAGES    REBASES    REBUILDS
+some-namespace    1         1          0
+Total             1         1          0
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+		require.Len(t, fakeWaiter.WaitCalls, 0)
+	})
+
 	it("does not add stack images with the same digest", func() {
 		fakeFetcher.AddStackImages(registryfakes.StackInfo{
 			StackID: "stack-id",
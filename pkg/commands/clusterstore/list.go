@@ -5,11 +5,13 @@ package clusterstore
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -22,6 +24,11 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Long:    "Prints a table of the most important information about cluster-scoped stores",
 		Example: "kp clusterstore list",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
 				return err
@@ -35,30 +42,31 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			if len(storeList.Items) == 0 {
 				return errors.New("no ClusterStores found")
 			} else {
-				return displayStoresTable(cmd, storeList)
+				return displayStoresTable(printer, storeList)
 			}
 
 		},
 		SilenceUsage: true,
 	}
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayStoresTable(cmd *cobra.Command, storeList *v1alpha2.ClusterStoreList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY")
-	if err != nil {
-		return err
-	}
-
-	for _, s := range storeList.Items {
-		err := writer.AddRow(s.Name, getReadyText(s))
-		if err != nil {
-			return err
-		}
+func displayStoresTable(printer *commands.OutputPrinter, storeList *v1alpha2.ClusterStoreList) error {
+	var objs []runtime.Object
+	for i := range storeList.Items {
+		objs = append(objs, &storeList.Items[i])
 	}
 
-	return writer.Write()
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "NAME"},
+		{Header: "READY"},
+		{Header: "BUILDPACKS", Wide: true},
+	}, func(obj runtime.Object) []string {
+		s := obj.(*v1alpha2.ClusterStore)
+		return []string{s.Name, getReadyText(*s), strconv.Itoa(len(s.Status.Buildpacks))}
+	})
 }
 
 func getReadyText(s v1alpha2.ClusterStore) string {
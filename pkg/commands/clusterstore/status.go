@@ -31,6 +31,11 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
 				return err
@@ -41,6 +46,11 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
+			if !printer.IsTable() {
+				return printer.PrintObj(store)
+			}
+
+			verbose = verbose || printer.IsWide()
 			if verbose {
 				return displayBuildpackagesDetailed(cmd.OutOrStdout(), store)
 			} else {
@@ -50,6 +60,7 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "includes buildpacks and detection order")
+	commands.SetOutputFlag(cmd)
 	return cmd
 }
 
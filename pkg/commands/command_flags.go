@@ -9,11 +9,22 @@ import (
 	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
 )
 
+const caCertPathFlag = "registry-ca-cert-path"
+
 func SetTLSFlags(cmd *cobra.Command, cfg *registry.TLSConfig) {
-	cmd.Flags().StringVar(&cfg.CaCertPath, "registry-ca-cert-path", "", "add CA certificate for registry API (format: /tmp/ca.crt)")
+	cmd.Flags().StringVar(&cfg.CaCertPath, caCertPathFlag, "", "add CA certificate for registry API (format: /tmp/ca.crt)")
 	cmd.Flags().BoolVar(&cfg.VerifyCerts, "registry-verify-certs", true, "set whether to verify server's certificate chain and host name")
 }
 
+// SetDefaultCaCertPath sets the registry-ca-cert-path flag of the command to path when the flag is not provided.
+func SetDefaultCaCertPath(cmd *cobra.Command, path string) error {
+	flag := cmd.Flags().Lookup(caCertPathFlag)
+	if flag == nil || flag.Changed || path == "" {
+		return nil
+	}
+	return flag.Value.Set(path)
+}
+
 func SetDryRunOutputFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(DryRunFlag, false, `perform validation with no side-effects; no objects are sent to the server.
   The --dry-run flag can be used in combination with the --output flag to
@@ -0,0 +1,77 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func TestCommandFlags(t *testing.T) {
+	spec.Run(t, "TestCommandFlags", testCommandFlags)
+}
+
+func testCommandFlags(t *testing.T, when spec.G, it spec.S) {
+	when("SetDefaultCaCertPath", func() {
+		var (
+			cmd    *cobra.Command
+			tlsCfg registry.TLSConfig
+		)
+
+		it.Before(func() {
+			tlsCfg = registry.TLSConfig{}
+			cmd = &cobra.Command{}
+			commands.SetTLSFlags(cmd, &tlsCfg)
+		})
+
+		it("uses the path when the flag is not provided", func() {
+			require.NoError(t, cmd.ParseFlags([]string{}))
+			require.NoError(t, commands.SetDefaultCaCertPath(cmd, "/path/to/ca.crt"))
+			require.Equal(t, "/path/to/ca.crt", tlsCfg.CaCertPath)
+		})
+
+		it("keeps the provided flag", func() {
+			require.NoError(t, cmd.ParseFlags([]string{"--registry-ca-cert-path", "/other/ca.crt"}))
+			require.NoError(t, commands.SetDefaultCaCertPath(cmd, "/path/to/ca.crt"))
+			require.Equal(t, "/other/ca.crt", tlsCfg.CaCertPath)
+		})
+
+		it("ignores commands without tls flags", func() {
+			require.NoError(t, commands.SetDefaultCaCertPath(&cobra.Command{}, "/path/to/ca.crt"))
+		})
+	})
+	when("GetWaitTimeout", func() {
+		var cmd *cobra.Command
+
+		it.Before(func() {
+			cmd = &cobra.Command{}
+			cmd.Flags().Duration(commands.WaitTimeoutFlag, commands.DefaultWaitTimeout, "")
+		})
+
+		it("returns the timeout when the flag is set", func() {
+			require.NoError(t, cmd.ParseFlags([]string{"--wait-timeout", "30m"}))
+
+			timeout, set, err := commands.GetWaitTimeout(cmd)
+			require.NoError(t, err)
+			require.True(t, set)
+			require.Equal(t, 30*time.Minute, timeout)
+		})
+
+		it("returns the default timeout as not set when the flag is not provided", func() {
+			require.NoError(t, cmd.ParseFlags([]string{}))
+
+			timeout, set, err := commands.GetWaitTimeout(cmd)
+			require.NoError(t, err)
+			require.False(t, set)
+			require.Equal(t, commands.DefaultWaitTimeout, timeout)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"reflect"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -204,6 +205,16 @@ func GetBoolFlag(name string, cmd *cobra.Command) (bool, error) {
 	return value, nil
 }
 
+// GetWaitTimeout returns the value of the wait-timeout flag of the root command and whether it was set.
+// Waits for image builds are only limited when it is set, as builds can take longer than DefaultWaitTimeout.
+func GetWaitTimeout(cmd *cobra.Command) (time.Duration, bool, error) {
+	if !cmd.Flags().Changed(WaitTimeoutFlag) {
+		return DefaultWaitTimeout, false, nil
+	}
+	timeout, err := cmd.Flags().GetDuration(WaitTimeoutFlag)
+	return timeout, true, err
+}
+
 func GetStringFlag(name string, cmd *cobra.Command) (string, error) {
 	flag := cmd.Flags().Lookup(name)
 	if flag == nil {
@@ -230,6 +241,7 @@ func getTypeToGVKLookup() map[reflect.Type]schema.GroupVersionKind {
 		reflect.TypeOf(&v1.ServiceAccount{}):       v1GV.WithKind("ServiceAccount"),
 		reflect.TypeOf(&v1.ConfigMap{}):            v1GV.WithKind("ConfigMap"),
 		reflect.TypeOf(&v1alpha2.Image{}):          buildGV.WithKind("Image"),
+		reflect.TypeOf(&v1alpha2.Build{}):          buildGV.WithKind("Build"),
 		reflect.TypeOf(&v1alpha2.Builder{}):        buildGV.WithKind(v1alpha2.BuilderKind),
 		reflect.TypeOf(&v1alpha2.ClusterStack{}):   buildGV.WithKind(v1alpha2.ClusterStackKind),
 		reflect.TypeOf(&v1alpha2.ClusterStore{}):   buildGV.WithKind(v1alpha2.ClusterStoreKind),
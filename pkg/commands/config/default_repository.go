@@ -20,6 +20,8 @@ This data is stored in a config map in the kpack namespace called kp-config.
 The kp-config config map also contains a service account that contains the secrets required to write to the default repository.
 
 If this config map doesn't exist, it will automatically be created by running this command, using the default service account in the kpack namespace as the default service account.
+
+A "kp-config" config map in another namespace can only override "default.clusterbuilder" for that namespace, see "kp config list".
 `,
 		Example: `kp config default-repository
 kp config default-repository my-registry.com/my-default-repo`,
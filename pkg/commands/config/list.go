@@ -0,0 +1,82 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the resolved kp config",
+		Long: `Prints a table of the kp config values and the source each value is read from.
+
+Values are read from the following sources, the first source that sets a value is used:
+
+  1. The "kp-config" ConfigMap in the namespace, only for "default.clusterbuilder" to override the cluster builder of "kp image create" in that namespace.
+  2. The "kp-config" ConfigMap in the "kpack" namespace, which is set with "kp config default-repository" and "kp config default-service-account".
+  3. The user config file "$HOME/.kp/config.yaml", or the file in the "KP_CONFIG" env var, for client side defaults.
+
+The service account name and namespace are always read from the same source.
+The service account namespace defaults to the namespace of the ConfigMap, or "kpack" for the user config file.
+
+The supported keys are:
+
+  "default.repository" the location where imported and cluster-level resources are stored.
+  "default.repository.serviceaccount" and "default.repository.serviceaccount.namespace" the service account with the secrets to write to the default repository.
+  "default.clusterbuilder" the cluster builder used by "kp image create" when no builder is provided.
+  "default.namespace" the namespace used instead of the kubernetes current-context namespace, only read from the user config file.
+  "registry.ca-cert-path" the CA certificate used when "--registry-ca-cert-path" is not provided, only read from the user config file.
+
+The user config file is a yaml map of keys to values.
+
+The namespace defaults to the kubernetes current-context namespace.`,
+		Example: `kp config list
+kp config list -n my-namespace`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			kpConfig, err := config.NewNamespacedKpConfigProvider(cs).ResolveKpConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			tableWriter, err := commands.NewTableWriter(cmd.OutOrStdout(), "Key", "Value", "Source")
+			if err != nil {
+				return err
+			}
+
+			found := false
+			for _, v := range kpConfig.Values() {
+				if v.Value == "" {
+					continue
+				}
+
+				found = true
+				if err := tableWriter.AddRow(v.Key, v.Value, v.Source); err != nil {
+					return err
+				}
+			}
+
+			if !found {
+				return errors.New("no kp config found")
+			}
+
+			return tableWriter.Write()
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	return cmd
+}
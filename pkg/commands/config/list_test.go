@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestListCommand(t *testing.T) {
+	spec.Run(t, "TestListCommand", testListCommand)
+}
+
+func testListCommand(t *testing.T, when spec.G, it spec.S) {
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, _ *kpackfakes.Clientset) *cobra.Command {
+		return NewListCommand(testhelpers.GetFakeK8sProvider(k8sClientSet, "some-default-namespace"))
+	}
+
+	it.Before(func() {
+		require.NoError(t, os.Setenv("KP_CONFIG", ""))
+	})
+
+	it.After(func() {
+		require.NoError(t, os.Unsetenv("KP_CONFIG"))
+	})
+
+	it("lists the resolved values and their source", func() {
+		clusterConfig := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kp-config",
+				Namespace: "kpack",
+			},
+			Data: map[string]string{
+				"default.repository":                "some-repo",
+				"default.repository.serviceaccount": "some-sa",
+			},
+		}
+
+		namespaceConfig := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kp-config",
+				Namespace: "some-namespace",
+			},
+			Data: map[string]string{
+				"default.repository":     "some-team-repo",
+				"default.clusterbuilder": "some-team-builder",
+			},
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{clusterConfig, namespaceConfig},
+			Args:    []string{"-n", "some-namespace"},
+			ExpectedOutput: `KEY                                            VALUE                SOURCE
+default.repository                             some-repo            ConfigMap "kpack/kp-config"
+default.repository.serviceaccount              some-sa              ConfigMap "kpack/kp-config"
+default.repository.serviceaccount.namespace    kpack                ConfigMap "kpack/kp-config"
+default.clusterbuilder                         some-team-builder    ConfigMap "some-namespace/kp-config"
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("returns an error when no config is set", func() {
+		testhelpers.CommandTest{
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: no kp config found\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
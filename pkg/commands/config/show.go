@@ -0,0 +1,63 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+func NewShowCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the kp config",
+		Long: `Prints the resolved kp config, including the defaults used for values that are not set.
+
+Use "kp config list" to show the source of each value.
+
+The namespace defaults to the kubernetes current-context namespace.`,
+		Example: `kp config show
+kp config show -n my-namespace`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			kpConfig, err := config.NewNamespacedKpConfigProvider(cs).ResolveKpConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			repo, _ := kpConfig.DefaultRepository()
+			serviceAccount := kpConfig.ServiceAccount()
+
+			clusterBuilder := kpConfig.DefaultClusterBuilder()
+			if clusterBuilder == "" {
+				clusterBuilder = "default"
+			}
+
+			statusWriter := commands.NewStatusWriter(cmd.OutOrStdout())
+			err = statusWriter.AddBlock("",
+				"Default Repository", repo,
+				"Service Account", serviceAccount.Namespace+"/"+serviceAccount.Name,
+				"Default Cluster Builder", clusterBuilder,
+				"Default Namespace", kpConfig.DefaultNamespace(),
+				"Registry CA Cert Path", kpConfig.RegistryCaCertPath(),
+			)
+			if err != nil {
+				return err
+			}
+
+			return statusWriter.Write()
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	return cmd
+}
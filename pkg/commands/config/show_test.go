@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestShowCommand(t *testing.T) {
+	spec.Run(t, "TestShowCommand", testShowCommand)
+}
+
+func testShowCommand(t *testing.T, when spec.G, it spec.S) {
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, _ *kpackfakes.Clientset) *cobra.Command {
+		return NewShowCommand(testhelpers.GetFakeK8sProvider(k8sClientSet, "some-default-namespace"))
+	}
+
+	it.Before(func() {
+		require.NoError(t, os.Setenv("KP_CONFIG", ""))
+	})
+
+	it.After(func() {
+		require.NoError(t, os.Unsetenv("KP_CONFIG"))
+	})
+
+	it("shows the resolved config", func() {
+		kpConfig := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kp-config",
+				Namespace: "kpack",
+			},
+			Data: map[string]string{
+				"default.repository":                "some-repo",
+				"default.repository.serviceaccount": "some-sa",
+				"default.clusterbuilder":            "some-builder",
+			},
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig},
+			ExpectedOutput: `Default Repository:         some-repo
+Service Account:            kpack/some-sa
+Default Cluster Builder:    some-builder
+Default Namespace:          --
+Registry CA Cert Path:      --
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("shows the defaults when the config is not set", func() {
+		testhelpers.CommandTest{
+			ExpectedOutput: `Default Repository:         --
+Service Account:            kpack/default
+Default Cluster Builder:    default
+Default Namespace:          --
+Registry CA Cert Path:      --
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+func NewUnsetCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Unset a kp config value",
+		Long: `Remove a value from the kp-config config map in the kpack namespace, or from the namespace override with "--namespace".
+
+Unsetting "default.repository.serviceaccount" or "default.repository.serviceaccount.namespace" removes both values.
+Values of the user config file cannot be unset with this command, edit the file instead.
+
+Use "kp config list" to show the supported keys and where each value is read from.`,
+		Example: `kp config unset default.repository
+kp config unset default.clusterbuilder -n my-namespace`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			unset, err := config.NewKpConfigProvider(cs).Unset(cmd.Context(), namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if !unset {
+				return ch.Printlnf("kp-config unset (no change)")
+			}
+			return ch.Printlnf("kp-config unset")
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of the kp-config config map to unset the value in (default \"kpack\")")
+	return cmd
+}
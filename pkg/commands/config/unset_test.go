@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestUnsetCommand(t *testing.T) {
+	spec.Run(t, "TestUnsetCommand", testUnsetCommand)
+}
+
+func testUnsetCommand(t *testing.T, when spec.G, it spec.S) {
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, _ *kpackfakes.Clientset) *cobra.Command {
+		return NewUnsetCommand(testhelpers.GetFakeClusterProvider(k8sClientSet, nil))
+	}
+
+	kpConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository":                          "some-repo",
+			"default.repository.serviceaccount":           "some-sa",
+			"default.repository.serviceaccount.namespace": "some-namespace",
+		},
+	}
+
+	it("removes the service account name and namespace", func() {
+		testhelpers.CommandTest{
+			Objects:        []runtime.Object{kpConfig},
+			Args:           []string{"default.repository.serviceaccount"},
+			ExpectedOutput: "kp-config unset\n",
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "kp-config",
+							Namespace: "kpack",
+						},
+						Data: map[string]string{
+							"default.repository": "some-repo",
+						},
+					},
+				},
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("removes a value from a namespace config map", func() {
+		teamConfig := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kp-config",
+				Namespace: "some-team",
+			},
+			Data: map[string]string{
+				"default.clusterbuilder": "some-builder",
+			},
+		}
+
+		testhelpers.CommandTest{
+			Objects:        []runtime.Object{kpConfig, teamConfig},
+			Args:           []string{"default.clusterbuilder", "-n", "some-team"},
+			ExpectedOutput: "kp-config unset\n",
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "kp-config",
+							Namespace: "some-team",
+						},
+						Data: map[string]string{},
+					},
+				},
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("does not update the config map when the value is not set", func() {
+		testhelpers.CommandTest{
+			Objects:        []runtime.Object{kpConfig},
+			Args:           []string{"default.clusterbuilder"},
+			ExpectedOutput: "kp-config unset (no change)\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("returns an error for unknown keys", func() {
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{kpConfig},
+			Args:                []string{"default.repo"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: unknown key \"default.repo\", must be one of default.repository, default.repository.serviceaccount, default.repository.serviceaccount.namespace, default.clusterbuilder, default.namespace, registry.ca-cert-path\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
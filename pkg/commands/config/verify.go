@@ -0,0 +1,101 @@
+package config
+
+import (
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+)
+
+func NewVerifyCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var tlsCfg registry.TLSConfig
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the default repository can be written to",
+		Long: `Verify the default repository is reachable and writable with the secrets of the default service account.
+
+A small probe image is pushed to the default repository and deleted afterwards.
+Registries that do not allow deleting images keep the probe image, which is reported as a warning.
+
+Authorization and TLS problems are reported before they cause an import or builder to fail.`,
+		Example: `kp config verify
+kp config verify --registry-ca-cert-path /path/to/ca.crt`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			kpConfig, err := config.NewKpConfigProvider(cs).ResolveKpConfig(ctx)
+			if err != nil {
+				return err
+			}
+
+			repo, err := kpConfig.DefaultRepository()
+			if err != nil {
+				return err
+			}
+
+			repoRef, err := name.NewRepository(repo, name.WeakValidation)
+			if err != nil {
+				return errors.Wrapf(err, "invalid default repository %q", repo)
+			}
+
+			serviceAccount := kpConfig.ServiceAccount()
+			serviceAccountName := serviceAccount.Namespace + "/" + serviceAccount.Name
+
+			if err := ch.Printlnf("Verifying default repository %q with service account %q", repo, serviceAccountName); err != nil {
+				return err
+			}
+
+			keychain, err := secret.NewServiceAccountKeychain(ctx, cs.K8sClient, serviceAccount)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read the secrets of service account %q", serviceAccountName)
+			}
+
+			if !keychain.HasCredentials(repoRef.RegistryStr()) {
+				return errors.Errorf("service account %q has no credentials for registry %q, use \"kp secret create\" to add them", serviceAccountName, repoRef.RegistryStr())
+			}
+
+			verifier := rup.RepositoryVerifier(tlsCfg)
+
+			probe, err := verifier.PushProbe(ctx, keychain, repo)
+			if err != nil {
+				return err
+			}
+
+			if err := ch.Printlnf("Pushed probe image %q", probe); err != nil {
+				return err
+			}
+
+			if err := verifier.DeleteProbe(ctx, keychain, probe); err != nil {
+				err = ch.Printlnf("WARNING: could not delete probe image %q: %s", probe, err)
+			} else {
+				err = ch.Printlnf("Deleted probe image %q", probe)
+			}
+			if err != nil {
+				return err
+			}
+
+			return ch.Printlnf("Default repository verified")
+		},
+	}
+
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestVerifyCommand(t *testing.T) {
+	spec.Run(t, "TestVerifyCommand", testVerifyCommand)
+}
+
+func testVerifyCommand(t *testing.T, when spec.G, it spec.S) {
+	var verifier *registryfakes.RepositoryVerifier
+
+	kpConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository":                "some-registry.io/some-project",
+			"default.repository.serviceaccount": "some-sa",
+		},
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-sa",
+			Namespace: "kpack",
+		},
+		Secrets: []corev1.ObjectReference{{Name: "some-registry-secret"}},
+	}
+
+	registrySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-registry-secret",
+			Namespace: "kpack",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"some-registry.io":{"username":"some-user","password":"some-password"}}}`),
+		},
+	}
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, _ *kpackfakes.Clientset) *cobra.Command {
+		return NewVerifyCommand(testhelpers.GetFakeK8sProvider(k8sClientSet, "some-default-namespace"), registryfakes.UtilProvider{
+			FakeRepositoryVerifier: verifier,
+		})
+	}
+
+	it.Before(func() {
+		verifier = &registryfakes.RepositoryVerifier{}
+		require.NoError(t, os.Setenv("KP_CONFIG", ""))
+	})
+
+	it.After(func() {
+		require.NoError(t, os.Unsetenv("KP_CONFIG"))
+	})
+
+	it("pushes and deletes a probe image with the service account credentials", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig, serviceAccount, registrySecret},
+			ExpectedOutput: `Verifying default repository "some-registry.io/some-project" with service account "kpack/some-sa"
+Pushed probe image "some-registry.io/some-project@sha256:some-probe-digest"
+Deleted probe image "some-registry.io/some-project@sha256:some-probe-digest"
+Default repository verified
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Equal(t, []string{"some-registry.io/some-project@sha256:some-probe-digest"}, verifier.DeletedProbes)
+	})
+
+	it("warns when the probe image cannot be deleted", func() {
+		verifier.DeleteErr = errors.New("some delete error")
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig, serviceAccount, registrySecret},
+			ExpectedOutput: `Verifying default repository "some-registry.io/some-project" with service account "kpack/some-sa"
+Pushed probe image "some-registry.io/some-project@sha256:some-probe-digest"
+WARNING: could not delete probe image "some-registry.io/some-project@sha256:some-probe-digest": some delete error
+Default repository verified
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("fails when the probe image cannot be pushed", func() {
+		verifier.PushErr = errors.New(`not authorized to push to "some-registry.io/some-project"`)
+
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{kpConfig, serviceAccount, registrySecret},
+			ExpectErr:           true,
+			ExpectedOutput:      "Verifying default repository \"some-registry.io/some-project\" with service account \"kpack/some-sa\"\n",
+			ExpectedErrorOutput: "Error: not authorized to push to \"some-registry.io/some-project\"\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("fails when the service account has no credentials for the registry", func() {
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{kpConfig, &corev1.ServiceAccount{ObjectMeta: serviceAccount.ObjectMeta}},
+			ExpectErr:           true,
+			ExpectedOutput:      "Verifying default repository \"some-registry.io/some-project\" with service account \"kpack/some-sa\"\n",
+			ExpectedErrorOutput: "Error: service account \"kpack/some-sa\" has no credentials for registry \"some-registry.io\", use \"kp secret create\" to add them\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Empty(t, verifier.PushedProbes)
+	})
+
+	it("fails when the default repository is not set", func() {
+		testhelpers.CommandTest{
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: failed to get default repository: use \"kp config default-repository\" to set\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
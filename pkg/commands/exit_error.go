@@ -0,0 +1,17 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func NewExitError(code int, message string) ExitError {
+	return ExitError{Code: code, Message: message}
+}
+
+func (e ExitError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,22 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"context"
+	"io"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+type FakeBuildWaiter struct {
+	Calls       []*v1alpha2.Build
+	LatestImage string
+	Err         error
+}
+
+func (f *FakeBuildWaiter) Wait(_ context.Context, _ io.Writer, bld *v1alpha2.Build) (string, error) {
+	f.Calls = append(f.Calls, bld)
+	return f.LatestImage, f.Err
+}
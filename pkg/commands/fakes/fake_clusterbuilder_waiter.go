@@ -5,6 +5,7 @@ package fakes
 
 import (
 	"context"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	watchTools "k8s.io/client-go/tools/watch"
@@ -17,9 +18,12 @@ type WaitCall struct {
 
 type FakeWaiter struct {
 	WaitCalls []WaitCall
+	mutex     sync.Mutex
 }
 
 func (f *FakeWaiter) Wait(ctx context.Context, ob runtime.Object, checks ...watchTools.ConditionFunc) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	f.WaitCalls = append(f.WaitCalls, WaitCall{
 		Object:      ob,
 		ExtraChecks: checks,
@@ -11,10 +11,11 @@ import (
 )
 
 type FakeImageWaiter struct {
-	Calls []*v1alpha2.Image
+	Calls       []*v1alpha2.Image
+	LatestImage string
 }
 
 func (f *FakeImageWaiter) Wait(ctx context.Context, writer io.Writer, image *v1alpha2.Image) (string, error) {
 	f.Calls = append(f.Calls, image)
-	return "", nil
+	return f.LatestImage, nil
 }
@@ -0,0 +1,215 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+const defaultBulkWorkers = 4
+
+type ConfirmationProvider interface {
+	Confirm(message string, okayResponses ...string) (bool, error)
+}
+
+// bulkOptions select the images of a bulk operation with a label selector and list filters instead of a name.
+type bulkOptions struct {
+	selector      string
+	filters       []string
+	allNamespaces bool
+	workers       int
+	rate          float64
+	force         bool
+}
+
+// errBulkCancelled is the error of the images that were not operated on because the operation was cancelled.
+var errBulkCancelled = errors.New("cancelled")
+
+type bulkResult struct {
+	image  v1alpha2.Image
+	result string
+	err    error
+}
+
+func setBulkFlags(cmd *cobra.Command, opts *bulkOptions) {
+	cmd.Flags().StringVarP(&opts.selector, "selector", "l", "", "kubernetes label selector of the images to operate on instead of a name")
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", nil, `filter of the images to operate on instead of a name, see "kp image list --help" for the supported filters`)
+	cmd.Flags().BoolVarP(&opts.allNamespaces, "all-namespaces", "A", false, "operate on images in all namespaces, only with --selector or --filter")
+	cmd.Flags().IntVar(&opts.workers, "workers", defaultBulkWorkers, "number of images to operate on concurrently, only with --selector or --filter")
+	cmd.Flags().Float64Var(&opts.rate, "rate", 0, "maximum number of images to operate on per second, 0 for no limit, only with --selector or --filter")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "skip the confirmation of operations on multiple images")
+}
+
+func (o bulkOptions) isBulk() bool {
+	return o.selector != "" || len(o.filters) > 0
+}
+
+// validateBulkArgs checks that a command gets either an image name or bulk selection flags.
+func validateBulkArgs(args []string, opts bulkOptions) error {
+	switch {
+	case len(args) == 0 && !opts.isBulk():
+		return errors.New("an image name, --selector or --filter is required")
+	case len(args) > 0 && opts.isBulk():
+		return errors.New("an image name cannot be used with --selector or --filter")
+	case len(args) > 0 && opts.allNamespaces:
+		return errors.New("all-namespaces can only be used with --selector or --filter")
+	}
+	return nil
+}
+
+func selectImages(ctx context.Context, cs k8s.ClientSet, opts bulkOptions) ([]v1alpha2.Image, error) {
+	namespace := cs.Namespace
+	if opts.allNamespaces {
+		namespace = ""
+	}
+
+	imageList, err := cs.KpackClient.KpackV1alpha2().Images(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: opts.selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var latestBuilds map[string]*v1alpha2.Build
+	if filtersUseBuilds(opts.filters) {
+		if latestBuilds, err = getLatestBuilds(ctx, cs, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	imageList, err = filterImageList(imageList, latestBuilds, opts.filters, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(imageList.Items) == 0 {
+		return nil, errors.New("no images found")
+	}
+
+	sort.SliceStable(imageList.Items, func(i, j int) bool {
+		if imageList.Items[i].Namespace != imageList.Items[j].Namespace {
+			return imageList.Items[i].Namespace < imageList.Items[j].Namespace
+		}
+		return imageList.Items[i].Name < imageList.Items[j].Name
+	})
+	return imageList.Items, nil
+}
+
+func confirmBulk(ch *commands.CommandHelper, confirmationProvider ConfirmationProvider, opts bulkOptions, action string, images []v1alpha2.Image) (bool, error) {
+	if opts.force || ch.IsDryRun() {
+		return true, nil
+	}
+
+	if err := ch.Printlnf("The following %d images will be %s:", len(images), action); err != nil {
+		return false, err
+	}
+	for _, img := range images {
+		if err := ch.Printlnf("\t%s/%s", img.Namespace, img.Name); err != nil {
+			return false, err
+		}
+	}
+
+	return confirmationProvider.Confirm("Please confirm by typing 'y': ")
+}
+
+// runBulk runs the operation on every image with at most opts.workers operations at a time, started at most opts.rate times a second.
+func runBulk(ctx context.Context, images []v1alpha2.Image, opts bulkOptions, op func(ctx context.Context, img v1alpha2.Image) (string, error)) []bulkResult {
+	workers := opts.workers
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+
+	var ticker *time.Ticker
+	if opts.rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / opts.rate))
+		defer ticker.Stop()
+	}
+
+	results := make([]bulkResult, len(images))
+	var wg sync.WaitGroup
+	for i, img := range images {
+		if ticker != nil && i > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+			}
+		}
+
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+
+		if ctx.Err() != nil {
+			results[i] = bulkResult{image: img, err: errBulkCancelled}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, img v1alpha2.Image) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := op(ctx, img)
+			results[i] = bulkResult{image: img, result: result, err: err}
+		}(i, img)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func printBulkSummary(w io.Writer, results []bulkResult) error {
+	writer, err := commands.NewTableWriter(w, "Namespace", "Name", "Result")
+	if err != nil {
+		return err
+	}
+
+	failed, cancelled := 0, 0
+	for _, r := range results {
+		result := r.result
+		switch {
+		case r.err == errBulkCancelled:
+			cancelled++
+			result = r.err.Error()
+		case r.err != nil:
+			failed++
+			result = fmt.Sprintf("failed: %s", r.err)
+		}
+
+		if err := writer.AddRow(r.image.Namespace, r.image.Name, result); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Write(); err != nil {
+		return err
+	}
+
+	switch {
+	case failed > 0 && cancelled > 0:
+		return errors.Errorf("%d of %d images failed and %d were cancelled", failed, len(results), cancelled)
+	case failed > 0:
+		return errors.Errorf("%d of %d images failed", failed, len(results))
+	case cancelled > 0:
+		return errors.Errorf("%d of %d images were cancelled", cancelled, len(results))
+	}
+	return nil
+}
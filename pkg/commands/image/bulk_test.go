@@ -0,0 +1,64 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunBulk(t *testing.T) {
+	spec.Run(t, "TestRunBulk", testRunBulk)
+}
+
+func testRunBulk(t *testing.T, when spec.G, it spec.S) {
+	images := []v1alpha2.Image{
+		{ObjectMeta: v1.ObjectMeta{Name: "image-1", Namespace: "some-namespace"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "image-2", Namespace: "some-namespace"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "image-3", Namespace: "some-namespace"}},
+	}
+
+	it("runs the operation on every image", func() {
+		results := runBulk(context.Background(), images, bulkOptions{workers: 2}, func(ctx context.Context, img v1alpha2.Image) (string, error) {
+			return "done", nil
+		})
+
+		out := &bytes.Buffer{}
+		require.NoError(t, printBulkSummary(out, results))
+		require.Equal(t, `NAMESPACE         NAME       RESULT
+some-namespace    image-1    done
+some-namespace    image-2    done
+some-namespace    image-3    done
+
+`, out.String())
+	})
+
+	it("stops scheduling operations once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var operated []string
+		results := runBulk(ctx, images, bulkOptions{workers: 1}, func(ctx context.Context, img v1alpha2.Image) (string, error) {
+			operated = append(operated, img.Name)
+			cancel()
+			return "done", nil
+		})
+		require.Equal(t, []string{"image-1"}, operated)
+
+		out := &bytes.Buffer{}
+		require.EqualError(t, printBulkSummary(out, results), "2 of 3 images were cancelled")
+		require.Equal(t, `NAMESPACE         NAME       RESULT
+some-namespace    image-1    done
+some-namespace    image-2    cancelled
+some-namespace    image-3    cancelled
+
+`, out.String())
+	})
+}
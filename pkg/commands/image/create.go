@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
 	"github.com/vmware-tanzu/kpack-cli/pkg/image"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
 	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
@@ -43,9 +44,16 @@ Local source code will be pushed to the same registry provided for the image tag
 Therefore, you must have credentials to access the registry on your machine.
 --registry-ca-cert-path and --registry-verify-certs are only used for local source type.
 
+Local source files matched by the ".gitignore" and ".kpignore" files of the local path and its directories,
+or by an "--exclude" pattern, and the ".git" directory are not uploaded. Patterns use the gitignore syntax.
+A dry run lists the local source files that would be uploaded with their total size.
+
 Environment variables may be provided by using the "--env" flag.
 For each environment variable, supply the "--env" flag followed by the key value pair.
-For example, "--env key1=value1 --env key2=value2 ...".`,
+For example, "--env key1=value1 --env key2=value2 ...".
+
+The image uses the "default" cluster builder when no builder is provided,
+unless a "default.clusterbuilder" is configured, see "kp config list".`,
 		Example: `kp image create my-image --tag my-registry.com/my-repo --git https://my-repo.com/my-app.git --git-revision my-branch
 kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-host.com/my-blob
 kp image create my-image --tag my-registry.com/my-repo --local-path /path/to/local/source/code
@@ -71,6 +79,11 @@ kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-ho
 			factory.Printer = ch
 
 			ctx := cmd.Context()
+
+			if factory.Builder == "" && factory.ClusterBuilder == "" {
+				factory.ClusterBuilder = config.NewNamespacedKpConfigProvider(cs).GetKpConfig(ctx).DefaultClusterBuilder()
+			}
+
 			img, err := create(ctx, name, tag, &factory, ch, cs)
 			if err != nil {
 				return err
@@ -91,6 +104,7 @@ kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-ho
 	cmd.Flags().StringVar(&factory.GitRevision, "git-revision", "", "git revision such as commit, tag, or branch (default \"main\")")
 	cmd.Flags().StringVar(&factory.Blob, "blob", "", "source code blob url")
 	cmd.Flags().StringVar(&factory.LocalPath, "local-path", "", "path to local source code")
+	cmd.Flags().StringArrayVar(&factory.Excludes, "exclude", []string{}, "gitignore style pattern of local source files to exclude (can be set more than once)")
 	cmd.Flags().StringVar(&subPath, "sub-path", "", "build code at the sub path located within the source code directory")
 	cmd.Flags().StringVarP(&factory.Builder, "builder", "b", "", "builder name")
 	cmd.Flags().StringVarP(&factory.ClusterBuilder, "cluster-builder", "c", "", "cluster builder name")
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	cmdFakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
 	imgcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
@@ -47,7 +48,7 @@ func testImageCreateCommand(t *testing.T, when spec.G, it spec.S) {
 	fakeImageWaiter := &cmdFakes.FakeImageWaiter{}
 
 	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
-		clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+		clientSetProvider := testhelpers.GetFakeK8sAndKpackProvider(k8sfakes.NewSimpleClientset(), clientSet, defaultNamespace)
 		return imgcmds.NewCreateCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
 			return fakeImageWaiter
 		})
@@ -151,6 +152,48 @@ Image "some-image" created
 					},
 				}.TestKpack(t, cmdFunc)
 			})
+
+			it("uses the default cluster builder of the kp config when no builder is provided", func() {
+				expectedImage.Spec.Builder.Name = "some-team-builder"
+				require.NoError(t, setLastAppliedAnnotation(expectedImage))
+
+				namespaceConfig := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "kp-config",
+						Namespace: namespace,
+					},
+					Data: map[string]string{
+						"default.clusterbuilder": "some-team-builder",
+					},
+				}
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						namespaceConfig,
+					},
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--git", "some-git-url",
+						"--git-revision", "some-git-rev",
+						"--sub-path", "some-sub-path",
+						"--env", "some-key=some-val",
+						"--cache-size", "2G",
+						"-n", namespace,
+					},
+					ExpectedOutput: `Creating Image...
+Image "some-image" created
+`,
+					ExpectCreates: []runtime.Object{
+						expectedImage,
+					},
+				}.TestK8sAndKpack(t, func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *fake.Clientset) *cobra.Command {
+					clientSetProvider := testhelpers.GetFakeK8sAndKpackProvider(k8sClientSet, kpackClientSet, defaultNamespace)
+					return imgcmds.NewCreateCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
+						return fakeImageWaiter
+					})
+				})
+			})
 		})
 
 		when("the image config is invalid", func() {
@@ -4,8 +4,10 @@
 package image
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -13,37 +15,75 @@ import (
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
 )
 
-func NewDeleteCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func NewDeleteCommand(clientSetProvider k8s.ClientSetProvider, confirmationProvider ConfirmationProvider) *cobra.Command {
 	var (
 		namespace string
+		bulk      bulkOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "delete <name>",
+		Use:   "delete [name]",
 		Short: "Delete an image",
 		Long: `Delete an image and its associated image builds in the provided namespace.
 
-namespace defaults to the kubernetes current-context namespace.`,
-		Example: "kp image delete my-image",
-		Args:    commands.ExactArgsWithUsage(1),
+namespace defaults to the kubernetes current-context namespace.
+
+Multiple images can be deleted with the "--selector" and "--filter" flags instead of a name.
+The images are listed for confirmation unless the "--force" flag is used, and a summary of the result for every image is printed.`,
+		Example: `kp image delete my-image
+kp image delete --selector team=payments --filter ready=false`,
+		Args: commands.OptionalArgsWithUsage(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkArgs(args, bulk); err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
 			}
 
-			err = cs.KpackClient.KpackV1alpha2().Images(cs.Namespace).Delete(cmd.Context(), args[0], metav1.DeleteOptions{})
+			ctx := cmd.Context()
+
+			if !bulk.isBulk() {
+				err = cs.KpackClient.KpackV1alpha2().Images(cs.Namespace).Delete(ctx, args[0], metav1.DeleteOptions{})
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Image %q deleted\n", args[0])
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Image %q deleted\n", args[0])
-			return err
+			images, err := selectImages(ctx, cs, bulk)
+			if err != nil {
+				return err
+			}
+
+			confirmed, err := confirmBulk(ch, confirmationProvider, bulk, "deleted", images)
+			if err != nil {
+				return err
+			}
+
+			if !confirmed {
+				return ch.Printlnf("Skipping Image deletion")
+			}
+
+			results := runBulk(ctx, images, bulk, func(ctx context.Context, img v1alpha2.Image) (string, error) {
+				return "deleted", cs.KpackClient.KpackV1alpha2().Images(img.Namespace).Delete(ctx, img.Name, metav1.DeleteOptions{})
+			})
+			return printBulkSummary(cmd.OutOrStderr(), results)
 		},
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	setBulkFlags(cmd, &bulk)
 
 	return cmd
 }
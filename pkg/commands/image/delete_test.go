@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgotesting "k8s.io/client-go/testing"
 
+	cmdFakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
 	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
 )
@@ -27,7 +28,7 @@ func testImageDeleteCommand(t *testing.T, when spec.G, it spec.S) {
 
 	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
 		clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-		return image.NewDeleteCommand(clientSetProvider)
+		return image.NewDeleteCommand(clientSetProvider, cmdFakes.NewFakeConfirmationProvider(true, nil))
 	}
 
 	when("a namespace is provided", func() {
@@ -125,4 +126,38 @@ func testImageDeleteCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("images are selected with --selector", func() {
+		it("deletes every matching image", func() {
+			newImage := func(name string, labels map[string]string) *v1alpha2.Image {
+				return &v1alpha2.Image{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      name,
+						Namespace: defaultNamespace,
+						Labels:    labels,
+					},
+				}
+			}
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					newImage("some-image", map[string]string{"team": "payments"}),
+					newImage("other-image", map[string]string{"team": "checkout"}),
+				},
+				Args: []string{"--selector", "team=payments", "--force"},
+				ExpectDeletes: []clientgotesting.DeleteActionImpl{
+					{
+						ActionImpl: clientgotesting.ActionImpl{
+							Namespace: defaultNamespace,
+						},
+						Name: "some-image",
+					},
+				},
+				ExpectedOutput: `NAMESPACE                 NAME          RESULT
+some-default-namespace    some-image    deleted
+
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+	})
 }
@@ -0,0 +1,129 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/image"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+type SourceWatcher interface {
+	Watch(ctx context.Context, dir string, excludes []string, debounce time.Duration) (<-chan struct{}, error)
+}
+
+func NewDevCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newImageWaiter func(k8s.ClientSet) ImageWaiter, watcher SourceWatcher) *cobra.Command {
+	var (
+		namespace string
+		localPath string
+		excludes  []string
+		debounce  time.Duration
+		tlsCfg    registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dev <name>",
+		Short: "Rebuild an image from local source code on every change",
+		Long: `Uploads local source code to an existing image, tails the resulting build logs
+and repeats every time the source code changes until interrupted.
+This will fail if the image does not exist in the provided namespace.
+
+The namespace defaults to the kubernetes current-context namespace.
+The local path defaults to the current directory.
+
+Local source code will be pushed to the same registry as the existing image tag.
+Therefore, you must have credentials to access the registry on your machine.
+
+Local source files matched by the ".gitignore" and ".kpignore" files of the local path and its directories,
+or by an "--exclude" pattern, and the ".git" directory are not uploaded and do not trigger a rebuild.
+
+Changes are uploaded once no file has changed for the "--debounce" duration.
+A failed build does not stop the command, the next change triggers a new build.`,
+		Example: `kp image dev my-image
+kp image dev my-image --local-path /path/to/local/source/code --exclude '*.md'
+kp image dev my-image -n my-namespace --debounce 5s`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			name := args[0]
+
+			if _, err := cs.KpackClient.KpackV1alpha2().Images(cs.Namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+				return err
+			}
+
+			changes, err := watcher.Watch(ctx, localPath, excludes, debounce)
+			if err != nil {
+				return err
+			}
+
+			for {
+				if err := devBuild(ctx, cmd, ch, cs, name, image.Factory{
+					SourceUploader: rup.SourceUploader(ch.Writer(), tlsCfg, true),
+					Printer:        ch,
+					LocalPath:      localPath,
+					Excludes:       excludes,
+				}, newImageWaiter); err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					if err := ch.Printlnf("Error: %s", err); err != nil {
+						return err
+					}
+				}
+
+				if err := ch.PrintStatus("Watching '%s' for changes...", localPath); err != nil {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case _, ok := <-changes:
+					if !ok {
+						return nil
+					}
+				}
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().StringVar(&localPath, "local-path", ".", "path to local source code")
+	cmd.Flags().StringArrayVar(&excludes, "exclude", []string{}, "gitignore style pattern of local source files to exclude (can be set more than once)")
+	cmd.Flags().DurationVar(&debounce, "debounce", 2*time.Second, "time without changes to wait for before uploading")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
+
+func devBuild(ctx context.Context, cmd *cobra.Command, ch *commands.CommandHelper, cs k8s.ClientSet, name string, factory image.Factory, newImageWaiter func(k8s.ClientSet) ImageWaiter) error {
+	img, err := cs.KpackClient.KpackV1alpha2().Images(cs.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	patched, img, err := patch(ctx, img, &factory, ch, cs)
+	if err != nil || !patched {
+		return err
+	}
+
+	_, err = newImageWaiter(cs).Wait(ctx, cmd.OutOrStdout(), img)
+	return err
+}
@@ -0,0 +1,144 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmdFakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
+	imgcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestImageDevCommand(t *testing.T) {
+	spec.Run(t, "TestImageDevCommand", testImageDevCommand)
+}
+
+type fakeSourceWatcher struct {
+	changes  int
+	dir      string
+	excludes []string
+	debounce time.Duration
+}
+
+func (f *fakeSourceWatcher) Watch(ctx context.Context, dir string, excludes []string, debounce time.Duration) (<-chan struct{}, error) {
+	f.dir, f.excludes, f.debounce = dir, excludes, debounce
+
+	changes := make(chan struct{}, f.changes)
+	for i := 0; i < f.changes; i++ {
+		changes <- struct{}{}
+	}
+	close(changes)
+	return changes, nil
+}
+
+type failingImageWaiter struct{}
+
+func (failingImageWaiter) Wait(ctx context.Context, writer io.Writer, image *v1alpha2.Image) (string, error) {
+	return "", errors.New("build failed")
+}
+
+func testImageDevCommand(t *testing.T, when spec.G, it spec.S) {
+	const defaultNamespace = "some-default-namespace"
+
+	registryUtilProvider := registryfakes.UtilProvider{}
+	fakeImageWaiter := &cmdFakes.FakeImageWaiter{}
+	watcher := &fakeSourceWatcher{changes: 1}
+
+	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+		return imgcmds.NewDevCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
+			return fakeImageWaiter
+		}, watcher)
+	}
+
+	existingImage := &v1alpha2.Image{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-image",
+			Namespace: defaultNamespace,
+		},
+		Spec: v1alpha2.ImageSpec{
+			Tag: "some-registry.io/some-repo",
+			Source: corev1alpha1.SourceConfig{
+				Registry: &corev1alpha1.Registry{
+					Image: "some-registry.io/some-repo-source:old-source-id",
+				},
+			},
+		},
+	}
+
+	it("uploads the local source, waits for the build and watches for changes", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				existingImage,
+			},
+			Args: []string{"some-image", "--local-path", "some-path", "--exclude", "*.md", "--debounce", "5s"},
+			ExpectedOutput: `Patching Image...
+	Uploading 'some-registry.io/some-repo-source:source-id'
+Image "some-image" patched
+Watching 'some-path' for changes...
+Patching Image...
+	Uploading 'some-registry.io/some-repo-source:source-id'
+Image "some-image" patched (no change)
+Watching 'some-path' for changes...
+`,
+			ExpectPatches: []string{
+				`{"spec":{"source":{"registry":{"image":"some-registry.io/some-repo-source:source-id"}}}}`,
+			},
+		}.TestKpack(t, cmdFunc)
+
+		require.Equal(t, "some-path", watcher.dir)
+		require.Equal(t, []string{"*.md"}, watcher.excludes)
+		require.Equal(t, 5*time.Second, watcher.debounce)
+		require.Len(t, fakeImageWaiter.Calls, 1)
+	})
+
+	it("keeps watching when a build fails", func() {
+		failingCmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+			return imgcmds.NewDevCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
+				return failingImageWaiter{}
+			}, &fakeSourceWatcher{})
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				existingImage,
+			},
+			Args: []string{"some-image", "--local-path", "some-path"},
+			ExpectedOutput: `Patching Image...
+	Uploading 'some-registry.io/some-repo-source:source-id'
+Image "some-image" patched
+Error: build failed
+Watching 'some-path' for changes...
+`,
+			ExpectPatches: []string{
+				`{"spec":{"source":{"registry":{"image":"some-registry.io/some-repo-source:source-id"}}}}`,
+			},
+		}.TestKpack(t, failingCmdFunc)
+	})
+
+	it("errors when the image does not exist", func() {
+		testhelpers.CommandTest{
+			Args:                []string{"some-image"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: images.kpack.io \"some-image\" not found\n",
+		}.TestKpack(t, cmdFunc)
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildchange"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+)
+
+const (
+	timeFormat  = "2006-01-02 15:04:05"
+	shortDigest = len("sha256:") + 12
+)
+
+// parseSince accepts a duration relative to now or an RFC3339 timestamp or date.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, since); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("invalid since '%s', must be a duration (e.g. 24h) or a timestamp (e.g. 2021-01-02 or 2021-01-02T15:04:05Z)", since)
+}
+
+func filterBuildsSince(builds []v1alpha2.Build, since time.Time) []v1alpha2.Build {
+	var filtered []v1alpha2.Build
+	for _, b := range builds {
+		if !b.CreationTimestamp.Time.Before(since) {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
+
+func displayBuildHistory(out io.Writer, builds []v1alpha2.Build) error {
+	tableWriter, err := commands.NewTableWriter(out, "Build", "Status", "Reasons", "Started", "Finished", "Duration", "Image", "Builder", "Run Image")
+	if err != nil {
+		return err
+	}
+
+	for _, b := range builds {
+		reasons, err := getReasons(b)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read reasons of build '%s'", b.Name)
+		}
+
+		err = tableWriter.AddRow(
+			getId(&b),
+			getBuildStatus(b),
+			reasons,
+			b.CreationTimestamp.Time.Format(timeFormat),
+			getFinished(b),
+			getDuration(b),
+			getImageVersion(b.Status.LatestImage),
+			getImageVersion(b.Spec.Builder.Image),
+			getImageVersion(b.Status.Stack.RunImage),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tableWriter.Write()
+}
+
+func getReasons(b v1alpha2.Build) (string, error) {
+	changesJson, ok := b.Annotations[v1alpha2.BuildChangesAnnotation]
+	if !ok {
+		return b.Annotations[v1alpha2.BuildReasonAnnotation], nil
+	}
+
+	var changes []buildchange.GenericChange
+	if err := json.Unmarshal([]byte(changesJson), &changes); err != nil {
+		return "", err
+	}
+
+	var reasons []string
+	for _, change := range changes {
+		reasons = append(reasons, change.Reason)
+	}
+	return strings.Join(reasons, ","), nil
+}
+
+func getBuildStatus(b v1alpha2.Build) string {
+	cond := b.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+	switch {
+	case cond.IsTrue():
+		return "SUCCESS"
+	case cond.IsFalse():
+		return "FAILURE"
+	case cond.IsUnknown():
+		return "BUILDING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func getFinished(b v1alpha2.Build) string {
+	if b.IsRunning() {
+		return ""
+	}
+	return b.Status.GetCondition(corev1alpha1.ConditionSucceeded).LastTransitionTime.Inner.Format(timeFormat)
+}
+
+func getDuration(b v1alpha2.Build) string {
+	if b.IsRunning() {
+		return ""
+	}
+	finished := b.Status.GetCondition(corev1alpha1.ConditionSucceeded).LastTransitionTime.Inner.Time
+	return finished.Sub(b.CreationTimestamp.Time).Round(time.Second).String()
+}
+
+// getImageVersion shortens digest references to their digest so that history rows stay readable.
+func getImageVersion(ref string) string {
+	i := strings.LastIndex(ref, "@")
+	if i < 0 {
+		return ref
+	}
+
+	digest := ref[i+1:]
+	if len(digest) > shortDigest {
+		return digest[:shortDigest]
+	}
+	return digest
+}
@@ -6,10 +6,37 @@ package image
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
 )
 
 type ImageWaiter interface {
 	Wait(ctx context.Context, writer io.Writer, image *v1alpha2.Image) (string, error)
 }
+
+type BuildWaiter interface {
+	Wait(ctx context.Context, writer io.Writer, bld *v1alpha2.Build) (string, error)
+}
+
+type timeoutImageWaiter struct {
+	waiter  ImageWaiter
+	timeout time.Duration
+}
+
+// NewTimeoutImageWaiter returns an ImageWaiter that fails when the waiter does not finish within the timeout.
+func NewTimeoutImageWaiter(waiter ImageWaiter, timeout time.Duration) ImageWaiter {
+	return timeoutImageWaiter{waiter: waiter, timeout: timeout}
+}
+
+func (w timeoutImageWaiter) Wait(ctx context.Context, writer io.Writer, image *v1alpha2.Image) (string, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	latestImage, err := w.waiter.Wait(waitCtx, writer, image)
+	if err != nil && ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+		return "", errors.Errorf("timed out after %s waiting for Image %q", w.timeout, image.Name)
+	}
+	return latestImage, err
+}
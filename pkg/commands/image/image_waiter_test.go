@@ -0,0 +1,51 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
+)
+
+func TestTimeoutImageWaiter(t *testing.T) {
+	spec.Run(t, "TestTimeoutImageWaiter", testTimeoutImageWaiter)
+}
+
+type blockingImageWaiter struct{}
+
+func (blockingImageWaiter) Wait(ctx context.Context, _ io.Writer, _ *v1alpha2.Image) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func testTimeoutImageWaiter(t *testing.T, when spec.G, it spec.S) {
+	img := &v1alpha2.Image{ObjectMeta: metav1.ObjectMeta{Name: "some-image"}}
+
+	it("fails when the image is not ready within the timeout", func() {
+		waiter := image.NewTimeoutImageWaiter(blockingImageWaiter{}, 10*time.Millisecond)
+
+		_, err := waiter.Wait(context.Background(), ioutil.Discard, img)
+		require.EqualError(t, err, `timed out after 10ms waiting for Image "some-image"`)
+	})
+
+	it("returns the cancellation of the parent context", func() {
+		waiter := image.NewTimeoutImageWaiter(blockingImageWaiter{}, time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := waiter.Wait(ctx, ioutil.Discard, img)
+		require.Equal(t, context.Canceled, err)
+	})
+}
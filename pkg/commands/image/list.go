@@ -4,13 +4,17 @@
 package image
 
 import (
+	"context"
+	"fmt"
 	"sort"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -21,6 +25,10 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		namespace     string
 		allNamespaces bool
 		filters       []string
+		selector      string
+		fieldSelector string
+		sortBy        string
+		limit         int
 	)
 
 	cmd := &cobra.Command{
@@ -28,12 +36,26 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Short: "List images",
 		Long: `Prints a table of the most important information about images in the provided namespace.
 
-The namespace defaults to the kubernetes current-context namespace.`,
+The namespace defaults to the kubernetes current-context namespace.
+
+Images must match every "--filter" flag. A filter flag matches when any of its "|" separated filters match.
+A filter negated with "!=" matches the images the filter does not match.
+The git-url, git-revision, tag and stack-id filters accept shell patterns such as "github.com/my-org/*".
+The git-url filter matches the url with and without its scheme, "github.com/my-org/*" matches "https://github.com/my-org/app".
+The tag filter matches the repository of the image tag and the stack-id filter matches the stack of the latest build.
+The last-build-age filter matches images whose latest build was created more (">") or less ("<") than a duration ago.`,
 		Example: `kp image list
 kp image list -A
 kp image list -n my-namespace
-kp image list --filter ready=true --filter latest-reason=commit,trigger`,
+kp image list --filter ready=true --filter latest-reason=commit,trigger
+kp image list -A --filter 'ready!=true|latest-reason=stack' --sort-by last-build --limit 20
+kp image list -l team=payments --filter 'git-url=https://github.com/my-org/*' --filter 'last-build-age>720h'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -47,24 +69,47 @@ kp image list --filter ready=true --filter latest-reason=commit,trigger`,
 				imagesNamespace = cs.Namespace
 			}
 
-			imageList, err := cs.KpackClient.KpackV1alpha2().Images(imagesNamespace).List(cmd.Context(), metav1.ListOptions{})
+			less, ok := imageSorts[sortBy]
+			if !ok {
+				return errors.Errorf("invalid sort-by '%s', must be one of: name, age, last-build", sortBy)
+			}
+
+			if limit < 0 {
+				return errors.New("limit must be a positive number")
+			}
+
+			imageList, err := cs.KpackClient.KpackV1alpha2().Images(imagesNamespace).List(cmd.Context(), metav1.ListOptions{
+				LabelSelector: selector,
+				FieldSelector: fieldSelector,
+			})
 			if err != nil {
 				return err
 			}
 
-			imageList, err = filterImageList(imageList, filters)
+			var latestBuilds map[string]*v1alpha2.Build
+			if sortBy == "last-build" || filtersUseBuilds(filters) {
+				if latestBuilds, err = getLatestBuilds(cmd.Context(), cs, imagesNamespace); err != nil {
+					return err
+				}
+			}
+
+			imageList, err = filterImageList(imageList, latestBuilds, filters, time.Now())
 			if err != nil {
 				return err
 			}
 
 			sort.SliceStable(imageList.Items, func(i, j int) bool {
-				return imageList.Items[i].Name < imageList.Items[j].Name
+				return less(imageList.Items[i], imageList.Items[j], latestBuilds)
 			})
 
+			if limit > 0 && len(imageList.Items) > limit {
+				imageList.Items = imageList.Items[:limit]
+			}
+
 			if len(imageList.Items) == 0 {
 				return errors.New("no images found")
 			} else {
-				return displayImagesTable(cmd, imageList)
+				return displayImagesTable(printer, imageList)
 			}
 
 		},
@@ -78,26 +123,90 @@ Multiple values can be provided using comma separation.
 Supported filters and values:
   builder=string
   clusterbuilder=string
+  git-url=pattern
+  git-revision=pattern
+  tag=pattern
+  stack-id=pattern
   latest-reason=commit,trigger,config,stack,buildpack
-  ready=true,false,unknown`)
+  ready=true,false,unknown
+  last-build-age>duration, last-build-age<duration`)
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "kubernetes label selector to filter images on (e.g. team=payments)")
+	cmd.Flags().StringVar(&fieldSelector, "field-selector", "", "kubernetes field selector to filter images on (e.g. metadata.name=my-image)")
+	cmd.Flags().StringVar(&sortBy, "sort-by", "name", "sort images by name, age (newest first) or last-build (most recent first)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "maximum number of images to list")
+	commands.SetOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayImagesTable(cmd *cobra.Command, imageList *v1alpha2.ImageList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY", "LATEST REASON", "LATEST IMAGE", "NAMESPACE")
-	if err != nil {
-		return err
+func displayImagesTable(printer *commands.OutputPrinter, imageList *v1alpha2.ImageList) error {
+	var objs []runtime.Object
+	for i := range imageList.Items {
+		objs = append(objs, &imageList.Items[i])
 	}
 
-	for _, img := range imageList.Items {
-		err := writer.AddRow(img.Name, getReadyText(img), img.Status.LatestBuildReason, img.Status.LatestImage, img.Namespace)
-		if err != nil {
-			return err
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "NAME"},
+		{Header: "READY"},
+		{Header: "LATEST REASON"},
+		{Header: "LATEST IMAGE"},
+		{Header: "NAMESPACE"},
+		{Header: "TAG", Wide: true},
+		{Header: "BUILDER", Wide: true},
+		{Header: "LATEST BUILD", Wide: true},
+	}, func(obj runtime.Object) []string {
+		img := obj.(*v1alpha2.Image)
+		return []string{
+			img.Name,
+			getReadyText(*img),
+			img.Status.LatestBuildReason,
+			img.Status.LatestImage,
+			img.Namespace,
+			img.Spec.Tag,
+			getBuilderText(*img),
+			img.Status.LatestBuildRef,
 		}
+	})
+}
+
+var imageSorts = map[string]func(a, b v1alpha2.Image, latestBuilds map[string]*v1alpha2.Build) bool{
+	"name": func(a, b v1alpha2.Image, _ map[string]*v1alpha2.Build) bool {
+		return a.Name < b.Name
+	},
+	"age": func(a, b v1alpha2.Image, _ map[string]*v1alpha2.Build) bool {
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	},
+	"last-build": func(a, b v1alpha2.Image, latestBuilds map[string]*v1alpha2.Build) bool {
+		return lastBuildTime(a, latestBuilds).After(lastBuildTime(b, latestBuilds))
+	},
+}
+
+func lastBuildTime(img v1alpha2.Image, latestBuilds map[string]*v1alpha2.Build) time.Time {
+	if bld, ok := latestBuilds[latestBuildKey(img)]; ok {
+		return bld.CreationTimestamp.Time
 	}
+	return time.Time{}
+}
 
-	return writer.Write()
+func getLatestBuilds(ctx context.Context, cs k8s.ClientSet, namespace string) (map[string]*v1alpha2.Build, error) {
+	buildList, err := cs.KpackClient.KpackV1alpha2().Builds(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	latestBuilds := map[string]*v1alpha2.Build{}
+	for i := range buildList.Items {
+		bld := &buildList.Items[i]
+		latestBuilds[bld.Namespace+"/"+bld.Name] = bld
+	}
+	return latestBuilds, nil
+}
+
+func getBuilderText(img v1alpha2.Image) string {
+	if img.Spec.Builder.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", img.Spec.Builder.Kind, img.Spec.Builder.Name)
 }
 
 func getReadyText(img v1alpha2.Image) string {
@@ -5,32 +5,43 @@ package image
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// filter matches an image and the latest build of the image, which is nil when the image has no build.
 type filter struct {
-	filterFunc func(image v1alpha2.Image, values []string) bool
+	filterFunc func(image v1alpha2.Image, latestBuild *v1alpha2.Build, values []string) bool
 	values     []string
+	negate     bool
+	usesBuild  bool
 }
 
-func filterImageList(images *v1alpha2.ImageList, flags []string) (*v1alpha2.ImageList, error) {
-	filters, err := parseFilters(flags)
+// filterGroup matches when any of its filters match.
+type filterGroup []filter
+
+var filterRegex = regexp.MustCompile(`^([a-z-]+)(!=|=|>|<)(.+)$`)
+
+func filterImageList(images *v1alpha2.ImageList, latestBuilds map[string]*v1alpha2.Build, flags []string, now time.Time) (*v1alpha2.ImageList, error) {
+	groups, err := parseFilters(flags, now)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(filters) == 0 {
+	if len(groups) == 0 {
 		return images, nil
 	}
 
 	var filteredItems []v1alpha2.Image
 	for _, item := range images.Items {
-		if matchesAll(item, filters) {
+		if matchesAll(item, latestBuilds[latestBuildKey(item)], groups) {
 			filteredItems = append(filteredItems, item)
 		}
 	}
@@ -39,53 +50,113 @@ func filterImageList(images *v1alpha2.ImageList, flags []string) (*v1alpha2.Imag
 	return images, nil
 }
 
-func parseFilters(flags []string) ([]filter, error) {
-	var (
-		filters             []filter
-		builderRegex        = regexp.MustCompile(`^builder=(.+)$`)
-		clusterBuilderRegex = regexp.MustCompile(`^clusterbuilder=(.+)$`)
-		latestReasonRegex   = regexp.MustCompile(`^latest-reason=(.+)$`)
-		statusRegex         = regexp.MustCompile(`^ready=(.+)$`)
-	)
+func filtersUseBuilds(flags []string) bool {
+	groups, err := parseFilters(flags, time.Now())
+	if err != nil {
+		return false
+	}
 
-	for _, flag := range flags {
-		m := builderRegex.FindStringSubmatch(flag)
-		if len(m) == 2 {
-			filters = append(filters, filter{values: m[1:], filterFunc: func(image v1alpha2.Image, values []string) bool {
-				return image.Spec.Builder.Kind == v1alpha2.BuilderKind && image.Spec.Builder.Name == values[0]
-			}})
-			continue
+	for _, g := range groups {
+		for _, f := range g {
+			if f.usesBuild {
+				return true
+			}
 		}
+	}
+	return false
+}
 
-		m = clusterBuilderRegex.FindStringSubmatch(flag)
-		if len(m) == 2 {
-			filters = append(filters, filter{values: m[1:], filterFunc: func(image v1alpha2.Image, values []string) bool {
-				return image.Spec.Builder.Kind == v1alpha2.ClusterBuilderKind && image.Spec.Builder.Name == values[0]
-			}})
-			continue
+func parseFilters(flags []string, now time.Time) ([]filterGroup, error) {
+	var groups []filterGroup
+	for _, flag := range flags {
+		var group filterGroup
+		for _, alternative := range strings.Split(flag, "|") {
+			f, err := parseFilter(alternative, now)
+			if err != nil {
+				return nil, fmt.Errorf(`invalid filter argument "%s"`, flag)
+			}
+			group = append(group, f)
 		}
+		groups = append(groups, group)
+	}
 
-		m = latestReasonRegex.FindStringSubmatch(flag)
-		if len(m) == 2 {
-			filters = append(filters, filter{values: strings.Split(m[1], ","), filterFunc: matchesLatestReason})
-			continue
+	return groups, nil
+}
+
+func parseFilter(arg string, now time.Time) (filter, error) {
+	m := filterRegex.FindStringSubmatch(arg)
+	if len(m) != 4 {
+		return filter{}, fmt.Errorf(`invalid filter argument "%s"`, arg)
+	}
+	key, op, value := m[1], m[2], m[3]
+
+	if key == "last-build-age" {
+		if op != ">" && op != "<" {
+			return filter{}, fmt.Errorf(`invalid filter argument "%s"`, arg)
 		}
 
-		m = statusRegex.FindStringSubmatch(flag)
-		if len(m) == 2 {
-			filters = append(filters, filter{values: strings.Split(m[1], ","), filterFunc: matchesStatus})
-			continue
+		age, err := time.ParseDuration(value)
+		if err != nil {
+			return filter{}, err
 		}
 
-		return nil, fmt.Errorf(`invalid filter argument "%s"`, flag)
+		return filter{usesBuild: true, filterFunc: func(image v1alpha2.Image, latestBuild *v1alpha2.Build, _ []string) bool {
+			if latestBuild == nil {
+				return false
+			}
+			buildAge := now.Sub(latestBuild.CreationTimestamp.Time)
+			if op == ">" {
+				return buildAge > age
+			}
+			return buildAge < age
+		}}, nil
+	}
+
+	if op != "=" && op != "!=" {
+		return filter{}, fmt.Errorf(`invalid filter argument "%s"`, arg)
 	}
 
-	return filters, nil
+	f := filter{values: strings.Split(value, ","), negate: op == "!="}
+	switch key {
+	case "builder":
+		f.values = []string{value}
+		f.filterFunc = func(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
+			return image.Spec.Builder.Kind == v1alpha2.BuilderKind && image.Spec.Builder.Name == values[0]
+		}
+	case "clusterbuilder":
+		f.values = []string{value}
+		f.filterFunc = func(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
+			return image.Spec.Builder.Kind == v1alpha2.ClusterBuilderKind && image.Spec.Builder.Name == values[0]
+		}
+	case "latest-reason":
+		f.filterFunc = matchesLatestReason
+	case "ready":
+		f.filterFunc = matchesStatus
+	case "git-url":
+		f.filterFunc = func(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
+			return image.Spec.Source.Git != nil && matchesGitURL(image.Spec.Source.Git.URL, values)
+		}
+	case "git-revision":
+		f.filterFunc = func(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
+			return image.Spec.Source.Git != nil && matchesGlob(image.Spec.Source.Git.Revision, values)
+		}
+	case "tag":
+		f.filterFunc = matchesTagRepository
+	case "stack-id":
+		f.usesBuild = true
+		f.filterFunc = func(image v1alpha2.Image, latestBuild *v1alpha2.Build, values []string) bool {
+			return latestBuild != nil && matchesGlob(latestBuild.Status.Stack.ID, values)
+		}
+	default:
+		return filter{}, fmt.Errorf(`invalid filter argument "%s"`, arg)
+	}
+
+	return f, nil
 }
 
-func matchesAll(image v1alpha2.Image, fs []filter) bool {
-	for _, f := range fs {
-		if !f.filterFunc(image, f.values) {
+func matchesAll(image v1alpha2.Image, latestBuild *v1alpha2.Build, groups []filterGroup) bool {
+	for _, g := range groups {
+		if !g.matches(image, latestBuild) {
 			return false
 		}
 	}
@@ -93,7 +164,17 @@ func matchesAll(image v1alpha2.Image, fs []filter) bool {
 	return true
 }
 
-func matchesStatus(image v1alpha2.Image, values []string) bool {
+func (g filterGroup) matches(image v1alpha2.Image, latestBuild *v1alpha2.Build) bool {
+	for _, f := range g {
+		if f.filterFunc(image, latestBuild, f.values) != f.negate {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesStatus(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
 	contains := func(q corev1.ConditionStatus) bool {
 		for _, v := range values {
 			if strings.ToLower(string(q)) == strings.ToLower(v) {
@@ -114,7 +195,7 @@ func matchesStatus(image v1alpha2.Image, values []string) bool {
 	}
 }
 
-func matchesLatestReason(image v1alpha2.Image, values []string) bool {
+func matchesLatestReason(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
 	for _, v := range values {
 		for _, reason := range strings.Split(image.Status.LatestBuildReason, ",") {
 			if strings.ToLower(reason) == strings.ToLower(v) {
@@ -125,3 +206,42 @@ func matchesLatestReason(image v1alpha2.Image, values []string) bool {
 
 	return false
 }
+
+func matchesTagRepository(image v1alpha2.Image, _ *v1alpha2.Build, values []string) bool {
+	ref, err := name.ParseReference(image.Spec.Tag, name.WeakValidation)
+	if err != nil {
+		return false
+	}
+	return matchesGlob(ref.Context().Name(), values)
+}
+
+// matchesGitURL matches the git url with and without its scheme and user, so that "github.com/my-org/*"
+// matches "https://github.com/my-org/app".
+func matchesGitURL(url string, patterns []string) bool {
+	if matchesGlob(url, patterns) {
+		return true
+	}
+
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+len("://"):]
+	}
+	if i := strings.Index(url, "@"); i >= 0 && i < strings.IndexAny(url+"/", ":/") {
+		url = url[i+1:]
+	}
+	return matchesGlob(url, patterns)
+}
+
+// matchesGlob matches the value against shell patterns, such as "github.com/my-org/*".
+func matchesGlob(value string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func latestBuildKey(image v1alpha2.Image) string {
+	return image.Namespace + "/" + image.Status.LatestBuildRef
+}
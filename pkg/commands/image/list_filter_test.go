@@ -5,6 +5,7 @@ package image
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -75,7 +76,7 @@ func testFilter(t *testing.T, when spec.G, it spec.S) {
 
 	when("the builder filter is specified", func() {
 		it("filters images", func() {
-			imgs, err := filterImageList(images, []string{"builder=some-builder"})
+			imgs, err := filterImageList(images, nil, []string{"builder=some-builder"}, time.Now())
 			require.NoError(t, err)
 
 			require.Len(t, imgs.Items, 1)
@@ -85,7 +86,7 @@ func testFilter(t *testing.T, when spec.G, it spec.S) {
 
 	when("the clusterbuilder filter is specified", func() {
 		it("filters images", func() {
-			imgs, err := filterImageList(images, []string{"clusterbuilder=some-cluster-builder"})
+			imgs, err := filterImageList(images, nil, []string{"clusterbuilder=some-cluster-builder"}, time.Now())
 			require.NoError(t, err)
 
 			require.Len(t, imgs.Items, 1)
@@ -95,7 +96,7 @@ func testFilter(t *testing.T, when spec.G, it spec.S) {
 
 	when("the status filter is specified", func() {
 		it("filters images", func() {
-			imgs, err := filterImageList(images, []string{"ready=true,some-other-status"})
+			imgs, err := filterImageList(images, nil, []string{"ready=true,some-other-status"}, time.Now())
 			require.NoError(t, err)
 
 			require.Len(t, imgs.Items, 1)
@@ -105,7 +106,7 @@ func testFilter(t *testing.T, when spec.G, it spec.S) {
 
 	when("the latest-reason filter is specified", func() {
 		it("filters images", func() {
-			imgs, err := filterImageList(images, []string{"latest-reason=commit,some-other-build-reason"})
+			imgs, err := filterImageList(images, nil, []string{"latest-reason=commit,some-other-build-reason"}, time.Now())
 			require.NoError(t, err)
 
 			require.Len(t, imgs.Items, 1)
@@ -150,7 +151,7 @@ func testFilter(t *testing.T, when spec.G, it spec.S) {
 
 	when("multiple filters are specified", func() {
 		it("filters images matching all criteria", func() {
-			imgs, err := filterImageList(imagesWithSameBuilder, []string{"builder=some-builder", "latest-reason=commit"})
+			imgs, err := filterImageList(imagesWithSameBuilder, nil, []string{"builder=some-builder", "latest-reason=commit"}, time.Now())
 			require.NoError(t, err)
 
 			require.Len(t, imgs.Items, 1)
@@ -158,9 +159,165 @@ func testFilter(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("a filter is negated", func() {
+		it("filters images not matching the filter", func() {
+			imgs, err := filterImageList(images, nil, []string{"ready!=true"}, time.Now())
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 3)
+			require.Equal(t, "test-image-1", imgs.Items[0].ObjectMeta.Name)
+			require.Equal(t, "test-image-2", imgs.Items[1].ObjectMeta.Name)
+			require.Equal(t, "test-image-4", imgs.Items[2].ObjectMeta.Name)
+		})
+	})
+
+	when("a filter has alternatives", func() {
+		it("filters images matching any alternative", func() {
+			imgs, err := filterImageList(images, nil, []string{"builder=some-builder|ready=true"}, time.Now())
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 2)
+			require.Equal(t, "test-image-1", imgs.Items[0].ObjectMeta.Name)
+			require.Equal(t, "test-image-3", imgs.Items[1].ObjectMeta.Name)
+		})
+	})
+
+	now := time.Date(2021, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	sourceImages := &v1alpha2.ImageList{
+		Items: []v1alpha2.Image{
+			{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test-image-1",
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha2.ImageSpec{
+					Tag: "registry.io/team-a/app-1",
+					Source: corev1alpha1.SourceConfig{
+						Git: &corev1alpha1.Git{
+							URL:      "https://github.com/team-a/app-1",
+							Revision: "main",
+						},
+					},
+				},
+				Status: v1alpha2.ImageStatus{
+					LatestBuildRef: "test-image-1-build-1",
+				},
+			},
+			{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test-image-2",
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha2.ImageSpec{
+					Tag: "registry.io/team-b/app-2:latest",
+					Source: corev1alpha1.SourceConfig{
+						Git: &corev1alpha1.Git{
+							URL:      "https://github.com/team-b/app-2",
+							Revision: "release-1",
+						},
+					},
+				},
+				Status: v1alpha2.ImageStatus{
+					LatestBuildRef: "test-image-2-build-3",
+				},
+			},
+			{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test-image-3",
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha2.ImageSpec{
+					Tag: "registry.io/team-a/app-3",
+				},
+			},
+		},
+	}
+
+	latestBuilds := map[string]*v1alpha2.Build{
+		"some-namespace/test-image-1-build-1": {
+			ObjectMeta: v1.ObjectMeta{
+				CreationTimestamp: v1.NewTime(now.Add(-48 * time.Hour)),
+			},
+			Status: v1alpha2.BuildStatus{
+				Stack: corev1alpha1.BuildStack{ID: "io.buildpacks.stacks.bionic"},
+			},
+		},
+		"some-namespace/test-image-2-build-3": {
+			ObjectMeta: v1.ObjectMeta{
+				CreationTimestamp: v1.NewTime(now.Add(-time.Hour)),
+			},
+			Status: v1alpha2.BuildStatus{
+				Stack: corev1alpha1.BuildStack{ID: "io.paketo.stacks.tiny"},
+			},
+		},
+	}
+
+	when("the git filters are specified", func() {
+		it("filters images matching the patterns", func() {
+			imgs, err := filterImageList(sourceImages, latestBuilds, []string{"git-url=https://github.com/team-b/*", "git-revision=release-*"}, now)
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 1)
+			require.Equal(t, "test-image-2", imgs.Items[0].ObjectMeta.Name)
+		})
+
+		it("matches git urls without the scheme", func() {
+			imgs, err := filterImageList(sourceImages, latestBuilds, []string{"git-url=github.com/team-a/*"}, now)
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 1)
+			require.Equal(t, "test-image-1", imgs.Items[0].ObjectMeta.Name)
+		})
+	})
+
+	when("the tag filter is specified", func() {
+		it("filters images by tag repository", func() {
+			imgs, err := filterImageList(sourceImages, latestBuilds, []string{"tag=registry.io/team-a/*"}, now)
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 2)
+			require.Equal(t, "test-image-1", imgs.Items[0].ObjectMeta.Name)
+			require.Equal(t, "test-image-3", imgs.Items[1].ObjectMeta.Name)
+		})
+	})
+
+	when("the stack-id filter is specified", func() {
+		it("filters images by the stack of the latest build", func() {
+			imgs, err := filterImageList(sourceImages, latestBuilds, []string{"stack-id=io.paketo.stacks.tiny"}, now)
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 1)
+			require.Equal(t, "test-image-2", imgs.Items[0].ObjectMeta.Name)
+		})
+	})
+
+	when("the last-build-age filter is specified", func() {
+		it("filters images with an older latest build", func() {
+			imgs, err := filterImageList(sourceImages, latestBuilds, []string{"last-build-age>24h"}, now)
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 1)
+			require.Equal(t, "test-image-1", imgs.Items[0].ObjectMeta.Name)
+		})
+
+		it("filters images with a newer latest build", func() {
+			imgs, err := filterImageList(sourceImages, latestBuilds, []string{"last-build-age<24h"}, now)
+			require.NoError(t, err)
+
+			require.Len(t, imgs.Items, 1)
+			require.Equal(t, "test-image-2", imgs.Items[0].ObjectMeta.Name)
+		})
+
+		it("returns an error for an invalid duration", func() {
+			_, err := filterImageList(sourceImages, latestBuilds, []string{"last-build-age>1month"}, now)
+			require.EqualError(t, err, "invalid filter argument \"last-build-age>1month\"")
+		})
+	})
+
 	when("an invalid filter is specified", func() {
 		it("returns a helpful error message", func() {
-			_, err := filterImageList(imagesWithSameBuilder, []string{"some-invalid-filter=some-value"})
+			_, err := filterImageList(imagesWithSameBuilder, nil, []string{"some-invalid-filter=some-value"}, time.Now())
 			require.Error(t, err, "invalid filter argument \"some-invalid-filter=some-value\"")
 		})
 	})
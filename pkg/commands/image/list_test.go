@@ -5,6 +5,7 @@ package image_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -355,4 +356,120 @@ test-image-4    False      COMMIT           test-registry.io/test-image-4@sha256
 			})
 		})
 	})
+
+	when("an output format is provided", func() {
+		img := &v1alpha2.Image{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "test-image-1",
+				Namespace: defaultNamespace,
+			},
+			Spec: v1alpha2.ImageSpec{
+				Tag: "test-registry.io/test-image-1",
+				Builder: corev1.ObjectReference{
+					Kind: v1alpha2.ClusterBuilderKind,
+					Name: "some-cluster-builder",
+				},
+			},
+			Status: v1alpha2.ImageStatus{
+				LatestBuildReason: "COMMIT",
+				LatestBuildRef:    "test-image-1-build-1",
+				LatestImage:       "test-registry.io/test-image-1@sha256:abcdef123",
+			},
+		}
+
+		it("adds the wide columns with wide", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{img},
+				Args:    []string{"-o", "wide"},
+				ExpectedOutput: `NAME            READY      LATEST REASON    LATEST IMAGE                                      NAMESPACE                 TAG                              BUILDER                                LATEST BUILD
+test-image-1    Unknown    COMMIT           test-registry.io/test-image-1@sha256:abcdef123    some-default-namespace    test-registry.io/test-image-1    ClusterBuilder/some-cluster-builder    test-image-1-build-1
+
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("prints the image names with name", func() {
+			testhelpers.CommandTest{
+				Objects:        []runtime.Object{img},
+				Args:           []string{"-o", "name"},
+				ExpectedOutput: "image.kpack.io/test-image-1\n",
+			}.TestKpack(t, cmdFunc)
+		})
+	})
+
+	when("sorting and limiting images", func() {
+		newImage := func(name string, created time.Time, latestBuild string, labels map[string]string) *v1alpha2.Image {
+			return &v1alpha2.Image{
+				ObjectMeta: v1.ObjectMeta{
+					Name:              name,
+					Namespace:         defaultNamespace,
+					CreationTimestamp: v1.NewTime(created),
+					Labels:            labels,
+				},
+				Status: v1alpha2.ImageStatus{
+					LatestBuildRef: latestBuild,
+					LatestImage:    "test-registry.io/" + name + "@sha256:abcdef123",
+				},
+			}
+		}
+		newBuild := func(name string, created time.Time) *v1alpha2.Build {
+			return &v1alpha2.Build{
+				ObjectMeta: v1.ObjectMeta{
+					Name:              name,
+					Namespace:         defaultNamespace,
+					CreationTimestamp: v1.NewTime(created),
+				},
+			}
+		}
+
+		now := time.Now()
+		objects := []runtime.Object{
+			newImage("test-image-1", now.Add(-3*time.Hour), "test-image-1-build-1", map[string]string{"team": "a"}),
+			newImage("test-image-2", now.Add(-time.Hour), "test-image-2-build-1", map[string]string{"team": "b"}),
+			newImage("test-image-3", now.Add(-2*time.Hour), "test-image-3-build-1", map[string]string{"team": "a"}),
+			newBuild("test-image-1-build-1", now.Add(-10*time.Minute)),
+			newBuild("test-image-2-build-1", now.Add(-30*time.Minute)),
+			newBuild("test-image-3-build-1", now.Add(-20*time.Minute)),
+		}
+
+		it("sorts images by age", func() {
+			testhelpers.CommandTest{
+				Objects: objects,
+				Args:    []string{"--sort-by", "age", "-o", "name"},
+				ExpectedOutput: `image.kpack.io/test-image-2
+image.kpack.io/test-image-3
+image.kpack.io/test-image-1
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("sorts images by last build and limits the results", func() {
+			testhelpers.CommandTest{
+				Objects: objects,
+				Args:    []string{"--sort-by", "last-build", "--limit", "2", "-o", "name"},
+				ExpectedOutput: `image.kpack.io/test-image-1
+image.kpack.io/test-image-3
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("filters images with a label selector", func() {
+			testhelpers.CommandTest{
+				Objects: objects,
+				Args:    []string{"-l", "team=a", "-o", "name"},
+				ExpectedOutput: `image.kpack.io/test-image-1
+image.kpack.io/test-image-3
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("returns an error for an invalid sort", func() {
+			testhelpers.CommandTest{
+				Objects:             objects,
+				Args:                []string{"--sort-by", "size"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: invalid sort-by 'size', must be one of: name, age, last-build\n",
+			}.TestKpack(t, cmdFunc)
+		})
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -18,16 +19,17 @@ import (
 	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
 )
 
-func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newImageWaiter func(k8s.ClientSet) ImageWaiter) *cobra.Command {
+func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newImageWaiter func(k8s.ClientSet) ImageWaiter, confirmationProvider ConfirmationProvider) *cobra.Command {
 	var (
 		namespace string
 		subPath   string
 		factory   image.Factory
 		tlsCfg    registry.TLSConfig
+		bulk      bulkOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "patch <name>",
+		Use:   "patch [name]",
 		Short: "Patch an existing image configuration",
 		Long: `Patch an existing image configuration by providing command line arguments.
 This will fail if the image does not exist in the provided namespace.
@@ -43,6 +45,10 @@ The flags for this command determine how the build will retrieve source code:
 Local source code will be pushed to the same registry as the existing image tag.
 Therefore, you must have credentials to access the registry on your machine.
 
+Local source files matched by the ".gitignore" and ".kpignore" files of the local path and its directories,
+or by an "--exclude" pattern, and the ".git" directory are not uploaded. Patterns use the gitignore syntax.
+A dry run lists the local source files that would be uploaded with their total size.
+
 Environment variables may be provided by using the "--env" flag.
 For each environment variable, supply the "--env" flag followed by the key value pair.
 For example, "--env key1=value1 --env key2=value2 ...".
@@ -52,15 +58,24 @@ For each environment variable, supply the "--delete-env" flag followed by the va
 For example, "--delete-env key1 --delete-env key2 ...".
 
 The --cache-size flag can only be used to increase the size of the existing cache.
+
+Multiple images can be patched with the "--selector" and "--filter" flags instead of a name.
+The images are listed for confirmation unless the "--force" flag is used, and a summary of the result for every image is printed.
+Local source code, "--wait" and "--output" cannot be used when patching multiple images.
 `,
 		Example: `kp image patch my-image --git-revision my-other-branch
 kp image patch my-image --blob https://my-blob-host.com/my-blob
 kp image patch my-image --local-path /path/to/local/source/code
 kp image patch my-image --local-path /path/to/local/source/code --builder my-builder
-kp image patch my-image --env foo=bar --env color=red --delete-env apple --delete-env potato`,
-		Args:         commands.ExactArgsWithUsage(1),
+kp image patch my-image --env foo=bar --env color=red --delete-env apple --delete-env potato
+kp image patch --filter clusterbuilder=old --cluster-builder new -A`,
+		Args:         commands.OptionalArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkArgs(args, bulk); err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -73,6 +88,14 @@ kp image patch my-image --env foo=bar --env color=red --delete-env apple --delet
 
 			ctx := cmd.Context()
 
+			if cmd.Flag("sub-path").Changed {
+				factory.SubPath = &subPath
+			}
+
+			if bulk.isBulk() {
+				return bulkPatch(ctx, cmd, ch, cs, factory, confirmationProvider, bulk)
+			}
+
 			img, err := cs.KpackClient.KpackV1alpha2().Images(cs.Namespace).Get(ctx, args[0], metav1.GetOptions{})
 			if err != nil {
 				return err
@@ -81,10 +104,6 @@ kp image patch my-image --env foo=bar --env color=red --delete-env apple --delet
 			factory.SourceUploader = rup.SourceUploader(ch.Writer(), tlsCfg, ch.CanChangeState())
 			factory.Printer = ch
 
-			if cmd.Flag("sub-path").Changed {
-				factory.SubPath = &subPath
-			}
-
 			patched, img, err := patch(ctx, img, &factory, ch, cs)
 			if err != nil {
 				return err
@@ -105,6 +124,7 @@ kp image patch my-image --env foo=bar --env color=red --delete-env apple --delet
 	cmd.Flags().StringVar(&factory.GitRevision, "git-revision", "", "git revision such as commit, tag, or branch (default \"main\")")
 	cmd.Flags().StringVar(&factory.Blob, "blob", "", "source code blob url")
 	cmd.Flags().StringVar(&factory.LocalPath, "local-path", "", "path to local source code")
+	cmd.Flags().StringArrayVar(&factory.Excludes, "exclude", []string{}, "gitignore style pattern of local source files to exclude (can be set more than once)")
 	cmd.Flags().StringVar(&subPath, "sub-path", "", "build code at the sub path located within the source code directory")
 	cmd.Flags().StringVar(&factory.Builder, "builder", "", "builder name")
 	cmd.Flags().StringVar(&factory.ClusterBuilder, "cluster-builder", "", "cluster builder name")
@@ -114,9 +134,54 @@ kp image patch my-image --env foo=bar --env color=red --delete-env apple --delet
 	cmd.Flags().BoolP("wait", "w", false, "wait for image patch to be reconciled and tail resulting build logs")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	setBulkFlags(cmd, &bulk)
 	return cmd
 }
 
+func bulkPatch(ctx context.Context, cmd *cobra.Command, ch *commands.CommandHelper, cs k8s.ClientSet, factory image.Factory, confirmationProvider ConfirmationProvider, bulk bulkOptions) error {
+	switch {
+	case factory.LocalPath != "":
+		return errors.New("local-path cannot be used with --selector or --filter")
+	case ch.ShouldWait():
+		return errors.New("wait cannot be used with --selector or --filter")
+	case cmd.Flags().Changed(commands.OutputFlag):
+		return errors.New("output cannot be used with --selector or --filter")
+	}
+
+	images, err := selectImages(ctx, cs, bulk)
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := confirmBulk(ch, confirmationProvider, bulk, "patched", images)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return ch.Printlnf("Skipping Image patch")
+	}
+
+	results := runBulk(ctx, images, bulk, func(ctx context.Context, img v1alpha2.Image) (string, error) {
+		f := factory
+		_, patch, err := f.MakePatch(&img)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case len(patch) == 0:
+			return "no change", nil
+		case ch.IsDryRun():
+			return "patched (dry run)", nil
+		}
+
+		_, err = cs.KpackClient.KpackV1alpha2().Images(img.Namespace).Patch(ctx, img.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		return "patched", err
+	})
+	return printBulkSummary(cmd.OutOrStderr(), results)
+}
+
 func patch(ctx context.Context, img *v1alpha2.Image, factory *image.Factory, ch *commands.CommandHelper, cs k8s.ClientSet) (bool, *v1alpha2.Image, error) {
 	if err := ch.PrintStatus("Patching Image..."); err != nil {
 		return false, nil, err
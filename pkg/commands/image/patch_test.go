@@ -32,12 +32,13 @@ func testImagePatchCommand(t *testing.T, when spec.G, it spec.S) {
 
 	registryUtilProvider := registryfakes.UtilProvider{}
 	fakeImageWaiter := &cmdFakes.FakeImageWaiter{}
+	fakeConfirmationProvider := cmdFakes.NewFakeConfirmationProvider(true, nil)
 
 	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
 		clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
 		return imgcmds.NewPatchCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
 			return fakeImageWaiter
-		})
+		}, fakeConfirmationProvider)
 	}
 
 	existingImage := &v1alpha2.Image{
@@ -648,4 +649,57 @@ status: {}
 			})
 		})
 	})
+
+	when("images are selected with --filter", func() {
+		otherImage := existingImage.DeepCopy()
+		otherImage.Name = "other-image"
+		otherImage.Namespace = "other-namespace"
+
+		unrelatedImage := existingImage.DeepCopy()
+		unrelatedImage.Name = "unrelated-image"
+		unrelatedImage.Spec.Builder.Name = "other-ccb"
+
+		it("patches every matching image and prints a summary", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					existingImage,
+					otherImage,
+					unrelatedImage,
+				},
+				Args: []string{"--filter", "clusterbuilder=some-ccb", "--cluster-builder", "new-ccb", "-A", "--workers", "1", "--force"},
+				ExpectedOutput: `NAMESPACE                 NAME           RESULT
+other-namespace           other-image    patched
+some-default-namespace    some-image     patched
+
+`,
+				ExpectPatches: []string{
+					`{"spec":{"builder":{"name":"new-ccb"}}}`,
+				},
+			}.TestKpack(t, cmdFunc)
+			assert.False(t, fakeConfirmationProvider.WasRequested())
+		})
+
+		it("does not patch images with --dry-run", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					existingImage,
+					unrelatedImage,
+				},
+				Args: []string{"--filter", "clusterbuilder=some-ccb", "--cluster-builder", "new-ccb", "--dry-run"},
+				ExpectedOutput: `NAMESPACE                 NAME          RESULT
+some-default-namespace    some-image    patched (dry run)
+
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("errors with local source", func() {
+			testhelpers.CommandTest{
+				Objects:             []runtime.Object{existingImage},
+				Args:                []string{"--filter", "clusterbuilder=some-ccb", "--local-path", "some-path"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: local-path cannot be used with --selector or --filter\n",
+			}.TestKpack(t, cmdFunc)
+		})
+	})
 }
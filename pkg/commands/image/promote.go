@@ -0,0 +1,155 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"sort"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/build"
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+const (
+	PromotedFromAnnotation = "kpack.io/promoted-from"
+	BuildNumberAnnotation  = "kpack.io/build-number"
+	GitRevisionAnnotation  = "kpack.io/git-revision"
+)
+
+func NewPromoteCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var (
+		fromNamespace string
+		fromContext   string
+		buildNumber   string
+		toTag         string
+		provenance    bool
+		tlsCfg        registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "promote <name> --to-tag <tag>",
+		Short: "Promote a built image to another tag",
+		Long: `Copies the image of the latest successful build of an image to another tag.
+The copy is done by digest so the promoted image is identical to the built image, across registries if needed.
+
+The build defaults to the latest successful build and can be chosen with the --build flag.
+The namespace defaults to the kubernetes current-context namespace.
+The --from-context flag reads the image from another kubeconfig context to promote between clusters.
+
+The --provenance flag adds the source image, build number and git revision as annotations to the image manifest.
+The annotations change the digest of the promoted image.`,
+		Example: `kp image promote my-image --to-tag my-registry.com/prod/my-image
+kp image promote my-image --from-namespace dev --to-tag my-registry.com/prod/my-image --provenance
+kp image promote my-image --from-context dev-cluster --from-namespace dev -b 3 --to-tag my-registry.com/prod/my-image`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromContext != "" {
+				p, ok := clientSetProvider.(k8s.ContextClientSetProvider)
+				if !ok {
+					return errors.New("--from-context is not supported")
+				}
+				clientSetProvider = p.ForContext(fromContext)
+			}
+
+			cs, err := clientSetProvider.GetClientSet(fromNamespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			name := args[0]
+
+			if _, err := cs.KpackClient.KpackV1alpha2().Images(cs.Namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+				return err
+			}
+
+			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(ctx, metav1.ListOptions{
+				LabelSelector: v1alpha2.ImageLabel + "=" + name,
+			})
+			if err != nil {
+				return err
+			}
+
+			sort.Slice(buildList.Items, build.Sort(buildList.Items))
+			bld, err := findSuccessfulBuild(buildList.Items, name, buildNumber)
+			if err != nil {
+				return err
+			}
+
+			if err := ch.PrintStatus("Promoting Image %q build %s in namespace %q...", name, getId(bld), cs.Namespace); err != nil {
+				return err
+			}
+
+			keychain := authn.DefaultKeychain
+			img, err := rup.Fetcher(tlsCfg).Fetch(keychain, bld.Status.LatestImage)
+			if err != nil {
+				return err
+			}
+
+			if provenance {
+				annotations := map[string]string{
+					PromotedFromAnnotation: bld.Status.LatestImage,
+					BuildNumberAnnotation:  getId(bld),
+				}
+				if bld.Spec.Source.Git != nil {
+					annotations[GitRevisionAnnotation] = bld.Spec.Source.Git.Revision
+				}
+				img = mutate.Annotations(img, annotations).(v1.Image)
+			}
+
+			ref, err := rup.ImageWriter(ch.Writer(), tlsCfg, ch.CanChangeState()).Write(keychain, img, toTag)
+			if err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Image %q promoted to '%s'", name, ref)
+		},
+	}
+
+	cmd.Flags().StringVarP(&fromNamespace, "from-namespace", "n", "", "kubernetes namespace of the image")
+	cmd.Flags().StringVar(&fromContext, "from-context", "", "kubeconfig context of the image, defaults to the current context")
+	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number, defaults to the latest successful build")
+	cmd.Flags().StringVar(&toTag, "to-tag", "", "tag to promote the image to")
+	cmd.Flags().BoolVar(&provenance, "provenance", false, "add provenance annotations to the promoted image")
+	cmd.Flags().Bool(commands.DryRunFlag, false, "perform validation with no side-effects; no images are uploaded")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	_ = cmd.MarkFlagRequired("to-tag")
+	return cmd
+}
+
+func findSuccessfulBuild(builds []v1alpha2.Build, name, buildNumber string) (*v1alpha2.Build, error) {
+	if buildNumber == "" {
+		if bld := getLastSuccessfulBuild(builds); bld != nil {
+			return bld, nil
+		}
+		return nil, errors.Errorf("no successful builds found for Image %q", name)
+	}
+
+	for i := range builds {
+		if getId(&builds[i]) != buildNumber {
+			continue
+		}
+		if !builds[i].IsSuccess() {
+			return nil, errors.Errorf("build %q has not succeeded", buildNumber)
+		}
+		return &builds[i], nil
+	}
+
+	return nil, errors.Errorf("build %q not found", buildNumber)
+}
@@ -0,0 +1,144 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"fmt"
+	"testing"
+
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestImagePromoteCommand(t *testing.T) {
+	spec.Run(t, "TestImagePromoteCommand", testImagePromoteCommand)
+}
+
+func testImagePromoteCommand(t *testing.T, when spec.G, it spec.S) {
+	const (
+		defaultNamespace = "some-default-namespace"
+		namespace        = "dev"
+		imageName        = "test-image"
+	)
+
+	builtImage := registryfakes.NewFakeLabeledImage("some-label", "some-value", "some-digest")
+
+	fetcher := &registryfakes.Fetcher{}
+	fetcher.AddImage("repo.com/image-1:tag", builtImage)
+
+	fakeRegistryUtilProvider := &registryfakes.UtilProvider{
+		FakeFetcher: fetcher,
+	}
+
+	img := &v1alpha2.Image{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      imageName,
+			Namespace: namespace,
+		},
+	}
+
+	objects := append([]runtime.Object{img}, testhelpers.BuildsToRuntimeObjs(testhelpers.MakeTestBuilds(imageName, namespace))...)
+
+	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+		return image.NewPromoteCommand(clientSetProvider, fakeRegistryUtilProvider)
+	}
+
+	it("promotes the latest successful build to the tag", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{imageName, "-n", namespace, "--to-tag", "prod-registry.io/prod/app"},
+			ExpectedOutput: `Promoting Image "test-image" build 1 in namespace "dev"...
+	Uploading 'prod-registry.io/prod/app:latest'
+Image "test-image" promoted to 'prod-registry.io/prod/app@sha256:some-digest'
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("promotes the build to the exact tag", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{imageName, "-n", namespace, "--to-tag", "prod-registry.io/prod/app:v1"},
+			ExpectedOutput: `Promoting Image "test-image" build 1 in namespace "dev"...
+	Uploading 'prod-registry.io/prod/app:v1'
+Image "test-image" promoted to 'prod-registry.io/prod/app@sha256:some-digest'
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("does not upload the image with --dry-run", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{imageName, "-n", namespace, "--to-tag", "prod-registry.io/prod/app", "--dry-run"},
+			ExpectedOutput: `Promoting Image "test-image" build 1 in namespace "dev"... (dry run)
+	Skipping 'prod-registry.io/prod/app:latest'
+Image "test-image" promoted to 'prod-registry.io/prod/app@sha256:some-digest' (dry run)
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("annotates the promoted image with its provenance", func() {
+		annotated := mutate.Annotations(builtImage, map[string]string{
+			image.PromotedFromAnnotation: "repo.com/image-1:tag",
+			image.BuildNumberAnnotation:  "1",
+		}).(ggcrv1.Image)
+		digest, err := annotated.Digest()
+		require.NoError(t, err)
+
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{imageName, "-n", namespace, "--to-tag", "prod-registry.io/prod/app", "--provenance"},
+			ExpectedOutput: fmt.Sprintf(`Promoting Image "test-image" build 1 in namespace "dev"...
+	Uploading 'prod-registry.io/prod/app:latest'
+Image "test-image" promoted to 'prod-registry.io/prod/app@%[1]s'
+`, digest),
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("reads the image from another context with --from-context", func() {
+		contextCmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+			clientSetProvider := testhelpers.GetFakeKpackProvider(fake.NewSimpleClientset(), defaultNamespace).
+				WithContext("dev-cluster", testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+			return image.NewPromoteCommand(clientSetProvider, fakeRegistryUtilProvider)
+		}
+
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{imageName, "--from-context", "dev-cluster", "-n", namespace, "--to-tag", "prod-registry.io/prod/app"},
+			ExpectedOutput: `Promoting Image "test-image" build 1 in namespace "dev"...
+	Uploading 'prod-registry.io/prod/app:latest'
+Image "test-image" promoted to 'prod-registry.io/prod/app@sha256:some-digest'
+`,
+		}.TestKpack(t, contextCmdFunc)
+	})
+
+	it("errors when the build has not succeeded", func() {
+		testhelpers.CommandTest{
+			Objects:             objects,
+			Args:                []string{imageName, "-n", namespace, "-b", "2", "--to-tag", "prod-registry.io/prod/app"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: build \"2\" has not succeeded\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("errors when the image has no successful builds", func() {
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{img},
+			Args:                []string{imageName, "-n", namespace, "--to-tag", "prod-registry.io/prod/app"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: no successful builds found for Image \"test-image\"\n",
+		}.TestKpack(t, cmdFunc)
+	})
+}
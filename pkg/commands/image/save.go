@@ -45,6 +45,10 @@ The flags for this command determine how the build will retrieve source code:
 Local source code will be pushed to the same registry provided for the image tag.
 Therefore, you must have credentials to access the registry on your machine.
 
+Local source files matched by the ".gitignore" and ".kpignore" files of the local path and its directories,
+or by an "--exclude" pattern, and the ".git" directory are not uploaded. Patterns use the gitignore syntax.
+A dry run lists the local source files that would be uploaded with their total size.
+
 Environment variables may be provided by using the "--env" flag.
 For each environment variable, supply the "--env" flag followed by the key value pair.
 For example, "--env key1=value1 --env key2=value2 ...".`,
@@ -114,6 +118,7 @@ kp image save my-image --tag my-registry.com/my-repo --blob https://my-blob-host
 	cmd.Flags().StringVar(&factory.GitRevision, "git-revision", "", "git revision such as commit, tag, or branch (default \"main\")")
 	cmd.Flags().StringVar(&factory.Blob, "blob", "", "source code blob url")
 	cmd.Flags().StringVar(&factory.LocalPath, "local-path", "", "path to local source code")
+	cmd.Flags().StringArrayVar(&factory.Excludes, "exclude", []string{}, "gitignore style pattern of local source files to exclude (can be set more than once)")
 	cmd.Flags().StringVar(&subPath, "sub-path", "", "build code at the sub path located within the source code directory")
 	cmd.Flags().StringVar(&factory.CacheSize, "cache-size", "", "cache size as a kubernetes quantity (default \"2G\")")
 	cmd.Flags().StringVarP(&factory.Builder, "builder", "b", "", "builder name")
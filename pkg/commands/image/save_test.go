@@ -765,7 +765,7 @@ status: {}
 			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
 			return imgcmds.NewPatchCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
 				return fakeImageWaiter
-			})
+			}, cmdFakes.NewFakeConfirmationProvider(true, nil))
 		}
 
 		existingImage := &v1alpha2.Image{
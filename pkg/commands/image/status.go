@@ -6,6 +6,7 @@ package image
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -21,6 +22,8 @@ import (
 func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	var (
 		namespace string
+		history   bool
+		since     string
 	)
 
 	cmd := &cobra.Command{
@@ -28,11 +31,33 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		Short: "Display status of an image",
 		Long: `Prints detailed information about the status of a specific image in the provided namespace.
 
+With "--history", every retained build of the image is listed with its reasons, start and finish time, duration,
+resulting image and the builder and run image it used. "--since" limits the history to builds created after
+a duration ago or a timestamp and implies "--history".
+
 The namespace defaults to the kubernetes current-context namespace.`,
-		Example:      "kp image status my-image\nkp image status my-other-image -n my-namespace",
+		Example: `kp image status my-image
+kp image status my-other-image -n my-namespace
+kp image status my-image --history
+kp image status my-image --since 72h
+kp image status my-image --since 2021-06-01`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var sinceTime time.Time
+			if since != "" {
+				var err error
+				if sinceTime, err = parseSince(since, time.Now()); err != nil {
+					return err
+				}
+				history = true
+			}
+
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -45,6 +70,10 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return err
 			}
 
+			if !printer.IsTable() {
+				return printer.PrintObj(image)
+			}
+
 			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(ctx, metav1.ListOptions{
 				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
 			})
@@ -53,11 +82,21 @@ The namespace defaults to the kubernetes current-context namespace.`,
 			}
 
 			sort.Slice(buildList.Items, build.Sort(buildList.Items))
-			return displayImageStatus(cmd, image, buildList.Items)
+			if err := displayImageStatus(cmd, image, buildList.Items); err != nil {
+				return err
+			}
+
+			if !history {
+				return nil
+			}
+			return displayBuildHistory(cmd.OutOrStdout(), filterBuildsSince(buildList.Items, sinceTime))
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().BoolVar(&history, "history", false, "display every retained build of the image")
+	commands.SetOutputFlag(cmd)
+	cmd.Flags().StringVar(&since, "since", "", "only display builds created after a duration ago (e.g. 24h) or a timestamp (e.g. 2021-06-01)")
 
 	return cmd
 }
@@ -5,6 +5,7 @@ package image_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -378,4 +379,127 @@ Build Reason:    --
 			}.TestKpack(t, cmdFunc)
 		})
 	})
+
+	when("--history is provided", func() {
+		image := &v1alpha2.Image{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      imageName,
+				Namespace: defaultNamespace,
+			},
+			Spec: v1alpha2.ImageSpec{
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "some-cluster-builder",
+				},
+			},
+		}
+
+		started := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+		historyBuild := func(number string, created time.Time, duration time.Duration, status corev1.ConditionStatus, annotations map[string]string) *v1alpha2.Build {
+			return &v1alpha2.Build{
+				ObjectMeta: v1.ObjectMeta{
+					Name:              "build-" + number,
+					Namespace:         defaultNamespace,
+					CreationTimestamp: v1.Time{Time: created},
+					Labels: map[string]string{
+						v1alpha2.ImageLabel:       imageName,
+						v1alpha2.BuildNumberLabel: number,
+					},
+					Annotations: annotations,
+				},
+				Spec: v1alpha2.BuildSpec{
+					Builder: corev1alpha1.BuildBuilderSpec{
+						Image: "some-repo.com/my-builder@sha256:0123456789abcdef0123456789abcdef",
+					},
+				},
+				Status: v1alpha2.BuildStatus{
+					Status: corev1alpha1.Status{
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:               corev1alpha1.ConditionSucceeded,
+								Status:             status,
+								LastTransitionTime: corev1alpha1.VolatileTime{Inner: v1.Time{Time: created.Add(duration)}},
+							},
+						},
+					},
+					Stack: corev1alpha1.BuildStack{
+						RunImage: "some-repo.com/run-image@sha256:fedcba9876543210fedcba9876543210",
+						ID:       "some-stack-id",
+					},
+					LatestImage: "repo.com/image@sha256:" + number + "0123456789abcdef",
+				},
+			}
+		}
+
+		builds := []runtime.Object{
+			historyBuild("1", started, 90*time.Second, corev1.ConditionTrue, map[string]string{
+				v1alpha2.BuildReasonAnnotation: "CONFIG",
+			}),
+			historyBuild("2", started.Add(24*time.Hour), 5*time.Minute, corev1.ConditionFalse, map[string]string{
+				v1alpha2.BuildReasonAnnotation:  "COMMIT,STACK",
+				v1alpha2.BuildChangesAnnotation: `[{"reason":"COMMIT","old":"abc","new":"def"},{"reason":"STACK","old":"run-1","new":"run-2"}]`,
+			}),
+			historyBuild("3", started.Add(48*time.Hour), 0, corev1.ConditionUnknown, map[string]string{
+				v1alpha2.BuildReasonAnnotation: "TRIGGER",
+			}),
+		}
+
+		const statusOutput = `Status:         Unknown
+Message:        --
+LatestImage:    --
+
+Source
+Type:    Local Source
+
+Builder Ref
+Name:    some-cluster-builder
+Kind:    ClusterBuilder
+
+Last Successful Build
+Id:              1
+Build Reason:    CONFIG
+
+BUILDPACK ID    BUILDPACK VERSION    HOMEPAGE
+
+Last Failed Build
+Id:              2
+Build Reason:    COMMIT,STACK
+
+`
+
+		it("displays every build of the image", func() {
+			testhelpers.CommandTest{
+				Objects: append([]runtime.Object{image}, builds...),
+				Args:    []string{imageName, "--history"},
+				ExpectedOutput: statusOutput + `BUILD    STATUS      REASONS         STARTED                FINISHED               DURATION    IMAGE                  BUILDER                RUN IMAGE
+1        SUCCESS     CONFIG          2021-06-01 10:00:00    2021-06-01 10:01:30    1m30s       sha256:10123456789a    sha256:0123456789ab    sha256:fedcba987654
+2        FAILURE     COMMIT,STACK    2021-06-02 10:00:00    2021-06-02 10:05:00    5m0s        sha256:20123456789a    sha256:0123456789ab    sha256:fedcba987654
+3        BUILDING    TRIGGER         2021-06-03 10:00:00                                       sha256:30123456789a    sha256:0123456789ab    sha256:fedcba987654
+
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("only displays builds created since a timestamp", func() {
+			testhelpers.CommandTest{
+				Objects: append([]runtime.Object{image}, builds...),
+				Args:    []string{imageName, "--since", "2021-06-02T10:00:00Z"},
+				ExpectedOutput: statusOutput + `BUILD    STATUS      REASONS         STARTED                FINISHED               DURATION    IMAGE                  BUILDER                RUN IMAGE
+2        FAILURE     COMMIT,STACK    2021-06-02 10:00:00    2021-06-02 10:05:00    5m0s        sha256:20123456789a    sha256:0123456789ab    sha256:fedcba987654
+3        BUILDING    TRIGGER         2021-06-03 10:00:00                                       sha256:30123456789a    sha256:0123456789ab    sha256:fedcba987654
+
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("errors when since is invalid", func() {
+			testhelpers.CommandTest{
+				Objects:             append([]runtime.Object{image}, builds...),
+				Args:                []string{imageName, "--since", "yesterday"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: invalid since 'yesterday', must be a duration (e.g. 24h) or a timestamp (e.g. 2021-01-02 or 2021-01-02T15:04:05Z)\n",
+			}.TestKpack(t, cmdFunc)
+		})
+	})
 }
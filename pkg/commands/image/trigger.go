@@ -4,8 +4,10 @@
 package image
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -20,54 +22,186 @@ import (
 
 const BuildNeededAnnotation = "image.kpack.io/additionalBuildNeeded"
 
-func NewTriggerCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+// buildPollInterval is how often the builds of a triggered image are listed until the triggered build is created.
+const buildPollInterval = time.Second
+
+func NewTriggerCommand(clientSetProvider k8s.ClientSetProvider, newBuildWaiter func(k8s.ClientSet) BuildWaiter, confirmationProvider ConfirmationProvider) *cobra.Command {
 	var (
 		namespace string
+		wait      bool
+		bulk      bulkOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "trigger <name>",
+		Use:   "trigger [name]",
 		Short: "Trigger an image build",
 		Long: `Trigger a build using current inputs for a specific image in the provided namespace.
 
-The namespace defaults to the kubernetes current-context namespace.`,
-		Example: "kp image trigger my-image",
-		Args:    commands.ExactArgsWithUsage(1),
+The namespace defaults to the kubernetes current-context namespace.
+
+The "--wait" flag waits for the triggered build to finish while tailing its logs, then prints the built image.
+The command fails if the build fails, or does not finish within the "--wait-timeout" duration when it is set.
+
+Builds can be triggered for multiple images with the "--selector" and "--filter" flags instead of a name.
+The images are listed for confirmation unless the "--force" flag is used, and a summary of the result for every image is printed.`,
+		Example: `kp image trigger my-image
+kp image trigger my-image --wait --wait-timeout 30m
+kp image trigger --selector team=payments -A
+kp image trigger --filter clusterbuilder=default --workers 10 --rate 5 --force`,
+		Args: commands.OptionalArgsWithUsage(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cs, err := clientSetProvider.GetClientSet(namespace)
-			if err != nil {
+			if err := validateBulkArgs(args, bulk); err != nil {
 				return err
 			}
 
-			ctx := cmd.Context()
+			if wait && bulk.isBulk() {
+				return errors.New("wait cannot be used with --selector or --filter")
+			}
 
-			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(ctx, metav1.ListOptions{
-				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
-			})
+			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
 			}
 
-			if len(buildList.Items) == 0 {
-				return errors.New("no builds found")
-			} else {
-				sort.Slice(buildList.Items, build.Sort(buildList.Items))
+			ctx := cmd.Context()
 
-				build := buildList.Items[len(buildList.Items)-1].DeepCopy()
-				build.Annotations[BuildNeededAnnotation] = time.Now().String()
-				_, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).Update(ctx, build, metav1.UpdateOptions{})
+			if !bulk.isBulk() {
+				triggered, err := triggerImage(ctx, cs, cs.Namespace, args[0])
 				if err != nil {
 					return err
 				}
 
 				_, err = fmt.Fprintf(cmd.OutOrStderr(), "Triggered build for Image %q\n", args[0])
+				if err != nil || !wait {
+					return err
+				}
+
+				timeout, limited, err := commands.GetWaitTimeout(cmd)
+				if err != nil {
+					return err
+				}
+
+				waitCtx, cancel := ctx, context.CancelFunc(func() {})
+				if limited {
+					waitCtx, cancel = context.WithTimeout(ctx, timeout)
+				}
+				defer cancel()
+
+				latestImage, err := waitForTriggeredBuild(waitCtx, cmd, cs, newBuildWaiter(cs), args[0], triggered)
+				if err != nil {
+					if ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+						return errors.Errorf("timed out after %s waiting for the build of Image %q", timeout, args[0])
+					}
+					return err
+				}
+
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Image %q built '%s'\n", args[0], latestImage)
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			images, err := selectImages(ctx, cs, bulk)
+			if err != nil {
 				return err
 			}
+
+			confirmed, err := confirmBulk(ch, confirmationProvider, bulk, "triggered", images)
+			if err != nil {
+				return err
+			}
+
+			if !confirmed {
+				return ch.Printlnf("Skipping build trigger")
+			}
+
+			results := runBulk(ctx, images, bulk, func(ctx context.Context, img v1alpha2.Image) (string, error) {
+				_, err := triggerImage(ctx, cs, img.Namespace, img.Name)
+				return "triggered", err
+			})
+			return printBulkSummary(cmd.OutOrStderr(), results)
 		},
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait for the triggered build to finish and tail its logs")
+	setBulkFlags(cmd, &bulk)
 
 	return cmd
 }
+
+// triggerImage annotates the latest build of the image to request a new build and returns the annotated build.
+func triggerImage(ctx context.Context, cs k8s.ClientSet, namespace, name string) (*v1alpha2.Build, error) {
+	buildList, err := cs.KpackClient.KpackV1alpha2().Builds(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: v1alpha2.ImageLabel + "=" + name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buildList.Items) == 0 {
+		return nil, errors.New("no builds found")
+	}
+
+	sort.Slice(buildList.Items, build.Sort(buildList.Items))
+
+	bld := buildList.Items[len(buildList.Items)-1].DeepCopy()
+	bld.Annotations[BuildNeededAnnotation] = time.Now().String()
+	return cs.KpackClient.KpackV1alpha2().Builds(namespace).Update(ctx, bld, metav1.UpdateOptions{})
+}
+
+// waitForTriggeredBuild waits for the build created after the triggered build and returns the image it built.
+func waitForTriggeredBuild(ctx context.Context, cmd *cobra.Command, cs k8s.ClientSet, buildWaiter BuildWaiter, name string, triggered *v1alpha2.Build) (string, error) {
+	bld, err := findNextBuild(ctx, cs, triggered)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := fmt.Fprintf(cmd.OutOrStderr(), "Waiting for build %s of Image %q...\n", bld.Labels[v1alpha2.BuildNumberLabel], name); err != nil {
+		return "", err
+	}
+
+	return buildWaiter.Wait(ctx, cmd.OutOrStdout(), bld)
+}
+
+func findNextBuild(ctx context.Context, cs k8s.ClientSet, triggered *v1alpha2.Build) (*v1alpha2.Build, error) {
+	triggeredNumber := buildNumber(triggered)
+
+	ticker := time.NewTicker(buildPollInterval)
+	defer ticker.Stop()
+
+	for {
+		buildList, err := cs.KpackClient.KpackV1alpha2().Builds(triggered.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: v1alpha2.ImageLabel + "=" + triggered.Labels[v1alpha2.ImageLabel],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var next *v1alpha2.Build
+		for i := range buildList.Items {
+			if n := buildNumber(&buildList.Items[i]); n > triggeredNumber && (next == nil || n > buildNumber(next)) {
+				next = &buildList.Items[i]
+			}
+		}
+
+		if next != nil {
+			return next, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func buildNumber(bld *v1alpha2.Build) int {
+	n, _ := strconv.Atoi(bld.Labels[v1alpha2.BuildNumberLabel])
+	return n
+}
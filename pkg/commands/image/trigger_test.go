@@ -5,14 +5,20 @@ package image_test
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
 
+	cmdFakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
 	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
 )
 
@@ -29,12 +35,23 @@ func testImageTrigger(t *testing.T, when spec.G, it spec.S) {
 	testBuilds := testhelpers.MakeTestBuilds("some-image", defaultNamespace)
 	testNamespacedBuilds := testhelpers.MakeTestBuilds("some-image", namespace)
 
+	fakeBuildWaiter := &cmdFakes.FakeBuildWaiter{}
+	newBuildWaiter := func(k8s.ClientSet) image.BuildWaiter {
+		return fakeBuildWaiter
+	}
+
+	it.Before(func() {
+		fakeBuildWaiter.Calls = nil
+		fakeBuildWaiter.LatestImage = ""
+		fakeBuildWaiter.Err = nil
+	})
+
 	when("a namespace is provided", func() {
 		when("an image build is available", func() {
 			it("triggers the latest build", func() {
 				clientSet := fake.NewSimpleClientset(testhelpers.BuildsToRuntimeObjs(testNamespacedBuilds)...)
 				clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-				cmd := image.NewTriggerCommand(clientSetProvider)
+				cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
 
 				out := &bytes.Buffer{}
 				cmd.SetOut(out)
@@ -58,7 +75,7 @@ func testImageTrigger(t *testing.T, when spec.G, it spec.S) {
 			it("returns an error", func() {
 				clientSet := fake.NewSimpleClientset()
 				clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-				cmd := image.NewTriggerCommand(clientSetProvider)
+				cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
 
 				out := &bytes.Buffer{}
 				cmd.SetOut(out)
@@ -75,7 +92,7 @@ func testImageTrigger(t *testing.T, when spec.G, it spec.S) {
 			it("triggers the latest build", func() {
 				clientSet := fake.NewSimpleClientset(testhelpers.BuildsToRuntimeObjs(testBuilds)...)
 				clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-				cmd := image.NewTriggerCommand(clientSetProvider)
+				cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
 
 				out := &bytes.Buffer{}
 				cmd.SetOut(out)
@@ -99,7 +116,7 @@ func testImageTrigger(t *testing.T, when spec.G, it spec.S) {
 			it("returns an error", func() {
 				clientSet := fake.NewSimpleClientset()
 				clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-				cmd := image.NewTriggerCommand(clientSetProvider)
+				cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
 
 				out := &bytes.Buffer{}
 				cmd.SetOut(out)
@@ -110,4 +127,180 @@ func testImageTrigger(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("--wait is provided", func() {
+		img := &v1alpha2.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "some-image",
+				Namespace:  namespace,
+				Generation: 2,
+			},
+			Status: v1alpha2.ImageStatus{
+				LatestBuildRef: "build-three",
+			},
+		}
+
+		// the fake clientset does not run kpack, so the triggered build is created when the latest build is annotated
+		newTriggeredClientSet := func() *fake.Clientset {
+			clientSet := fake.NewSimpleClientset(append([]runtime.Object{img}, testhelpers.BuildsToRuntimeObjs(testNamespacedBuilds)...)...)
+			clientSet.PrependReactor("update", "builds", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				triggered := action.(k8stesting.UpdateAction).GetObject().(*v1alpha2.Build)
+				next := &v1alpha2.Build{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "build-four",
+						Namespace: triggered.Namespace,
+						Labels: map[string]string{
+							v1alpha2.ImageLabel:       "some-image",
+							v1alpha2.BuildNumberLabel: "4",
+						},
+					},
+				}
+				return false, nil, clientSet.Tracker().Add(next)
+			})
+			return clientSet
+		}
+
+		it("waits for the triggered build and prints the built image", func() {
+			fakeBuildWaiter.LatestImage = "some-registry.io/some-repo@sha256:some-digest"
+			clientSetProvider := testhelpers.GetFakeKpackProvider(newTriggeredClientSet(), defaultNamespace)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"some-image", "-n", namespace, "--wait"})
+
+			require.NoError(t, cmd.Execute())
+			require.Equal(t, `Triggered build for Image "some-image"
+Waiting for build 4 of Image "some-image"...
+Image "some-image" built 'some-registry.io/some-repo@sha256:some-digest'
+`, out.String())
+
+			require.Len(t, fakeBuildWaiter.Calls, 1)
+			require.Equal(t, "build-four", fakeBuildWaiter.Calls[0].Name)
+		})
+
+		it("returns an error when the build fails", func() {
+			fakeBuildWaiter.Err = errors.New(`build "build-four" failed`)
+			clientSetProvider := testhelpers.GetFakeKpackProvider(newTriggeredClientSet(), defaultNamespace)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"some-image", "-n", namespace, "--wait"})
+
+			require.EqualError(t, cmd.Execute(), `build "build-four" failed`)
+		})
+
+		it("returns an error when the triggered build is not created before the timeout", func() {
+			clientSet := fake.NewSimpleClientset(append([]runtime.Object{img}, testhelpers.BuildsToRuntimeObjs(testNamespacedBuilds)...)...)
+			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.PersistentFlags().Duration("wait-timeout", 0, "")
+			cmd.SetArgs([]string{"some-image", "-n", namespace, "--wait", "--wait-timeout", "10ms"})
+
+			require.EqualError(t, cmd.Execute(), `timed out after 10ms waiting for the build of Image "some-image"`)
+			require.Len(t, fakeBuildWaiter.Calls, 0)
+		})
+
+		it("cannot be used with --selector", func() {
+			clientSetProvider := testhelpers.GetFakeKpackProvider(fake.NewSimpleClientset(), defaultNamespace)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{"--selector", "team=payments", "--wait"})
+
+			require.EqualError(t, cmd.Execute(), "wait cannot be used with --selector or --filter")
+		})
+	})
+
+	when("images are selected with --selector", func() {
+		newImage := func(name, namespace string) *v1alpha2.Image {
+			return &v1alpha2.Image{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    map[string]string{"team": "payments"},
+				},
+			}
+		}
+
+		objects := append([]runtime.Object{
+			newImage("some-image", defaultNamespace),
+			newImage("some-image", namespace),
+			newImage("image-without-builds", namespace),
+		}, testhelpers.BuildsToRuntimeObjs(testNamespacedBuilds)...)
+
+		it("triggers the latest build of every image after confirmation and prints a summary", func() {
+			clientSet := fake.NewSimpleClientset(append(objects, testhelpers.BuildsToRuntimeObjs(testBuilds)...)...)
+			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+			confirmationProvider := cmdFakes.NewFakeConfirmationProvider(true, nil)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, confirmationProvider)
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"--selector", "team=payments", "-A", "--workers", "1"})
+
+			err := cmd.Execute()
+			require.EqualError(t, err, "1 of 3 images failed")
+			require.NoError(t, confirmationProvider.WasRequestedWithMsg("Please confirm by typing 'y': "))
+			require.Equal(t, `The following 3 images will be triggered:
+	some-default-namespace/some-image
+	some-namespace/image-without-builds
+	some-namespace/some-image
+NAMESPACE                 NAME                    RESULT
+some-default-namespace    some-image              triggered
+some-namespace            image-without-builds    failed: no builds found
+some-namespace            some-image              triggered
+
+Error: 1 of 3 images failed
+`, out.String())
+
+			actions, err := testhelpers.ActionRecorderList{clientSet}.ActionsByVerb()
+			require.NoError(t, err)
+
+			require.Len(t, actions.Updates, 2)
+			for _, update := range actions.Updates {
+				build := update.GetObject().(*v1alpha2.Build)
+				require.Equal(t, "build-three", build.Name)
+				require.NotEmpty(t, build.Annotations[image.BuildNeededAnnotation])
+			}
+		})
+
+		it("does not trigger builds when the confirmation is declined", func() {
+			clientSet := fake.NewSimpleClientset(objects...)
+			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(false, nil))
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--selector", "team=payments", "-n", namespace})
+
+			require.NoError(t, cmd.Execute())
+			require.Equal(t, `The following 2 images will be triggered:
+	some-namespace/image-without-builds
+	some-namespace/some-image
+Skipping build trigger
+`, out.String())
+
+			actions, err := testhelpers.ActionRecorderList{clientSet}.ActionsByVerb()
+			require.NoError(t, err)
+			require.Len(t, actions.Updates, 0)
+		})
+
+		it("requires a name or selection flags", func() {
+			clientSetProvider := testhelpers.GetFakeKpackProvider(fake.NewSimpleClientset(), defaultNamespace)
+			cmd := image.NewTriggerCommand(clientSetProvider, newBuildWaiter, cmdFakes.NewFakeConfirmationProvider(true, nil))
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{})
+
+			require.EqualError(t, cmd.Execute(), "an image name, --selector or --filter is required")
+		})
+	})
 }
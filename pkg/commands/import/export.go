@@ -0,0 +1,66 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	importpkg "github.com/vmware-tanzu/kpack-cli/pkg/import"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func NewExportCommand(rup registry.UtilProvider) *cobra.Command {
+	var (
+		filename  string
+		output    string
+		tlsConfig registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export -f <filename> -o <bundle>",
+		Short: "Export dependency images to an offline bundle",
+		Long: `This operation will write the lifecycle, buildpackage, and stack images referenced in the dependency descriptor to a single OCI layout tarball.
+
+The bundle can be moved to an air-gapped environment and imported with "kp import --bundle".`,
+		Example: `kp import export -f dependencies.yaml -o bundle.tar
+cat dependencies.yaml | kp import export -f - -o bundle.tar`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rawDescriptor, err := readDescriptor(cmd, filename)
+			if err != nil {
+				return err
+			}
+
+			descriptor, err := importpkg.ReadDescriptor(rawDescriptor)
+			if err != nil {
+				return err
+			}
+
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Exporting images to '%s'...\n", output); err != nil {
+				return err
+			}
+
+			exporter := registry.BundleExporter{
+				Fetcher: rup.Fetcher(tlsConfig),
+				Writer:  cmd.OutOrStdout(),
+			}
+			if err := exporter.Export(authn.DefaultKeychain, descriptor.GetImages(), output); err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "Exported images")
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "dependency descriptor filename")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "bundle filename")
+	commands.SetTLSFlags(cmd, &tlsConfig)
+	_ = cmd.MarkFlagRequired("filename")
+	_ = cmd.MarkFlagRequired("output")
+	return cmd
+}
@@ -0,0 +1,100 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	importcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/import"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+)
+
+func TestExportCommand(t *testing.T) {
+	spec.Run(t, "TestExportCommand", testExportCommand)
+}
+
+func testExportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeFetcher *registryfakes.Fetcher
+		images      map[string]v1.Image
+		bundleDir   string
+	)
+
+	it.Before(func() {
+		fakeFetcher = &registryfakes.Fetcher{}
+		images = map[string]v1.Image{}
+		for _, ref := range []string{
+			"some-registry.io/repo/lifecycle-image",
+			"some-registry.io/repo/buildpack-image",
+			"some-registry.io/repo/build-image",
+			"some-registry.io/repo/run-image",
+		} {
+			image, err := random.Image(10, 1)
+			require.NoError(t, err)
+			images[ref] = image
+			fakeFetcher.AddImage(ref, image)
+		}
+
+		var err error
+		bundleDir, err = ioutil.TempDir("", "export-test")
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(bundleDir))
+	})
+
+	it("exports every image in the dependency descriptor to the bundle", func() {
+		bundlePath := filepath.Join(bundleDir, "bundle.tar")
+
+		cmd := importcmds.NewExportCommand(registryfakes.UtilProvider{FakeFetcher: fakeFetcher})
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"-f", "./testdata/deps.yaml", "-o", bundlePath})
+
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, `Exporting images to '`+bundlePath+`'...
+	Exporting 'some-registry.io/repo/lifecycle-image'
+	Exporting 'some-registry.io/repo/buildpack-image'
+	Exporting 'some-registry.io/repo/build-image'
+	Exporting 'some-registry.io/repo/run-image'
+Exported images
+`, out.String())
+
+		bundleFetcher, err := registry.NewBundleFetcher(bundlePath)
+		require.NoError(t, err)
+		defer bundleFetcher.Close()
+
+		for ref, image := range images {
+			fetched, err := bundleFetcher.Fetch(authn.DefaultKeychain, ref)
+			require.NoError(t, err)
+
+			expectedDigest, err := image.Digest()
+			require.NoError(t, err)
+			fetchedDigest, err := fetched.Digest()
+			require.NoError(t, err)
+			require.Equal(t, expectedDigest, fetchedDigest)
+		}
+	})
+
+	it("fails when an image cannot be fetched", func() {
+		cmd := importcmds.NewExportCommand(registryfakes.UtilProvider{FakeFetcher: &registryfakes.Fetcher{}})
+		cmd.SetOut(ioutil.Discard)
+		cmd.SetErr(ioutil.Discard)
+		cmd.SetArgs([]string{"-f", "./testdata/deps.yaml", "-o", filepath.Join(bundleDir, "bundle.tar")})
+
+		require.EqualError(t, cmd.Execute(), `image not found: "some-registry.io/repo/lifecycle-image"`)
+	})
+}
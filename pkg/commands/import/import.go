@@ -4,9 +4,11 @@
 package _import
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/spf13/cobra"
@@ -22,6 +24,8 @@ import (
 	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
 )
 
+const changesPendingExitCode = 2
+
 type ConfirmationProvider interface {
 	Confirm(message string, okayResponses ...string) (bool, error)
 }
@@ -36,8 +40,12 @@ func NewImportCommand(
 
 	var (
 		filename    string
+		bundle      string
 		showChanges bool
+		summaryFile string
+		exitCode    bool
 		force       bool
+		workers     int
 		tlsConfig   registry.TLSConfig
 	)
 
@@ -57,9 +65,20 @@ func NewImportCommand(
 		Long: `This operation will create or update clusterstores, clusterstacks, and clusterbuilders defined in the dependency descriptor.
 
 kp import will always attempt to upload the stack, store, and builder images, even if the resources have not changed.
-This can be used as a way to repair resources when registry images have been unexpectedly removed.`,
+This can be used as a way to repair resources when registry images have been unexpectedly removed.
+
+Use "--summary-file" to also write the change summary as an ImportChangeSummary to a file, as yaml for ".yaml" and ".yml" files and json otherwise.
+It implies "--show-changes" and the file only contains the summary, so it can be parsed in CI. With "--output", stdout only contains the imported resources.
+Use "--exit-code" to exit with status 2 when changes are found but not imported, such as with "--dry-run".
+
+Use "--bundle" to relocate images from an offline bundle created with "kp import export" instead of their remote source.
+
+Images are uploaded concurrently and failed uploads are retried. Images and layers that already exist in the default repository are skipped,
+so an interrupted import can be resumed by running it again.`,
 		Example: `kp import -f dependencies.yaml
-cat dependencies.yaml | kp import -f -`,
+cat dependencies.yaml | kp import -f -
+kp import -f dependencies.yaml --bundle bundle.tar
+kp import -f dependencies.yaml --summary-file changes.json --dry-run --exit-code`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cs, err := clientSetProvider.GetClientSet("")
@@ -73,11 +92,22 @@ cat dependencies.yaml | kp import -f -`,
 			}
 
 			ctx := cmd.Context()
+			showChanges = showChanges || summaryFile != ""
 
 			kpConfig := config.NewKpConfigProvider(cs).GetKpConfig(ctx)
 
 			imgFetcher := rup.Fetcher(tlsConfig)
-			imgRelocator := rup.Relocator(ch.Writer(), tlsConfig, ch.CanChangeState())
+			if bundle != "" {
+				bundleFetcher, err := registry.NewBundleFetcher(bundle)
+				if err != nil {
+					return err
+				}
+				defer bundleFetcher.Close()
+
+				imgFetcher = bundleFetcher
+			}
+
+			imgRelocator := rup.ConcurrentRelocator(ch.Writer(), tlsConfig, ch.CanChangeState(), workers)
 
 			importer := importpkg.NewImporter(
 				ch,
@@ -100,17 +130,24 @@ cat dependencies.yaml | kp import -f -`,
 			}
 
 			defaultKeychain := authn.DefaultKeychain
+			hasChanges := false
 			if showChanges {
-				hasChanges, summary, err := importpkg.SummarizeChange(ctx, defaultKeychain, descriptor, kpConfig, clusterstore.NewFactory(ch, imgRelocator, imgFetcher), clusterstack.NewFactory(ch, imgRelocator, imgFetcher), differ, cs)
+				summary, err := importpkg.SummarizeChange(ctx, defaultKeychain, descriptor, kpConfig, clusterstore.NewFactory(ch, imgRelocator, imgFetcher), clusterstack.NewFactory(ch, imgRelocator, imgFetcher), differ, cs)
 				if err != nil {
 					return err
 				}
+				hasChanges = summary.HasChanges
 
-				err = ch.Printlnf(summary)
-				if err != nil {
+				if err = ch.Printlnf(summary.String()); err != nil {
 					return err
 				}
 
+				if summaryFile != "" {
+					if err = writeSummaryFile(summaryFile, summary); err != nil {
+						return err
+					}
+				}
+
 				if !force {
 					confirmed, err := confirmationProvider.Confirm(confirmMsgMap[hasChanges])
 					if err != nil {
@@ -118,7 +155,10 @@ cat dependencies.yaml | kp import -f -`,
 					}
 
 					if !confirmed {
-						return ch.Printlnf("Skipping import")
+						if err := ch.Printlnf("Skipping import"); err != nil {
+							return err
+						}
+						return changesPendingErr(exitCode && hasChanges)
 					}
 				}
 			}
@@ -150,18 +190,54 @@ cat dependencies.yaml | kp import -f -`,
 				return err
 			}
 
-			return ch.PrintResult("Imported resources")
+			if err := ch.PrintResult("Imported resources"); err != nil {
+				return err
+			}
+
+			return changesPendingErr(exitCode && hasChanges && ch.IsDryRun())
 		},
 	}
 	cmd.Flags().StringVarP(&filename, "filename", "f", "", "dependency descriptor filename")
+	cmd.Flags().StringVar(&bundle, "bundle", "", "offline bundle created with \"kp import export\" to read images from")
 	cmd.Flags().BoolVar(&showChanges, "show-changes", false, "show a summary of resource changes before importing")
+	cmd.Flags().StringVar(&summaryFile, "summary-file", "", "file to write the summary of resource changes to, implies --show-changes")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, fmt.Sprintf("exit with status %d when --show-changes finds changes that are not imported", changesPendingExitCode))
 	cmd.Flags().BoolVar(&force, "force", false, "import without confirmation when showing changes")
+	cmd.Flags().IntVar(&workers, "workers", registry.DefaultRelocateWorkers, "number of images to upload concurrently")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsConfig)
 	_ = cmd.MarkFlagRequired("filename")
 	return cmd
 }
 
+func changesPendingErr(pending bool) error {
+	if !pending {
+		return nil
+	}
+	return commands.NewExitError(changesPendingExitCode, "import has pending changes")
+}
+
+// writeSummaryFile writes the change summary as yaml for .yaml and .yml files and as json otherwise.
+func writeSummaryFile(filename string, summary *importpkg.ChangeSummary) error {
+	format := k8s.FormatJSON
+	if ext := filepath.Ext(filename); ext == ".yaml" || ext == ".yml" {
+		format = k8s.FormatYAML
+	}
+
+	printer, err := k8s.NewObjectPrinter(format)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return printer.PrintObject(summary, file)
+}
+
 func readDescriptor(cmd *cobra.Command, filename string) (string, error) {
 	var (
 		reader io.ReadCloser
@@ -4,7 +4,11 @@
 package _import_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -33,10 +37,15 @@ func TestImportCommand(t *testing.T) {
 
 func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 	const (
-		lifecycleImageKey  = "image"
-		importTimestampKey = "kpack.io/import-timestamp"
+		lifecycleImageKey   = "image"
+		importTimestampKey  = "kpack.io/import-timestamp"
+		lifecycleHistoryKey = "kpack.io/lifecycle-history"
 	)
 
+	lifecycleHistory := func(image, timestamp string) string {
+		return `[{"image":"` + image + `","changedAt":"` + timestamp + `"}]`
+	}
+
 	fakeFetcher := &registryfakes.Fetcher{}
 	fakeRegistryUtilProvider := &registryfakes.UtilProvider{
 		FakeFetcher: fakeFetcher,
@@ -126,6 +135,7 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 	expectedLifecycleImageConfig := lifecycleImageConfig.DeepCopy()
 	expectedLifecycleImageConfig.Annotations[importTimestampKey] = timestampProvider.timestamp
 	expectedLifecycleImageConfig.Data["image"] = "default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest"
+	expectedLifecycleImageConfig.Annotations[lifecycleHistoryKey] = lifecycleHistory(expectedLifecycleImageConfig.Data[lifecycleImageKey], timestampProvider.timestamp)
 
 	store := &v1alpha2.ClusterStore{
 		TypeMeta: metav1.TypeMeta{
@@ -235,8 +245,18 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 		)
 	}
 
+	var tempDir string
+
 	it.Before(func() {
 		fakeConfirmationProvider = commandsfakes.NewFakeConfirmationProvider(true, nil)
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "import-test")
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(tempDir))
 	})
 
 	when("there are no stores, stacks, or cbs", func() {
@@ -515,6 +535,162 @@ Imported resources
 				}.TestK8sAndKpack(t, cmdFunc)
 				require.Equal(t, false, fakeConfirmationProvider.WasRequested())
 			})
+
+			it("writes the change summary to the summary file", func() {
+				fakeConfirmationProvider = commandsfakes.NewFakeConfirmationProvider(false, nil)
+
+				summaryFile := filepath.Join(tempDir, "summary.yaml")
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						kpConfig,
+						lifecycleImageConfig,
+					},
+					Args: []string{
+						"-f", "./testdata/deps.yaml",
+						"--summary-file", summaryFile,
+						"--output", "yaml",
+					},
+					ExpectedErrorOutput: `Changes
+
+Lifecycle
+
+some-diff
+
+ClusterStores
+
+some-diff
+
+ClusterStacks
+
+some-diff
+
+some-diff
+
+ClusterBuilders
+
+some-diff
+
+some-diff
+
+
+Skipping import
+`,
+				}.TestK8sAndKpack(t, cmdFunc)
+				require.NoError(t, fakeConfirmationProvider.WasRequestedWithMsg("Confirm with y:"))
+
+				summary, err := ioutil.ReadFile(summaryFile)
+				require.NoError(t, err)
+				require.Equal(t, `apiVersion: kp.kpack.io/v1alpha3
+changes:
+- action: update
+  added:
+  - after: some-registry.io/repo/lifecycle-image
+    field: image
+  kind: Lifecycle
+  name: lifecycle
+- action: create
+  added:
+  - after: default-registry.io/default-repo/buildpack-id@sha256:buildpack-image-digest
+    field: sources
+  images:
+  - afterDigest: sha256:buildpack-image-digest
+    field: sources
+  kind: ClusterStore
+  name: store-name
+- action: create
+  added:
+  - after: default-registry.io/default-repo/build@sha256:build-image-digest
+    field: buildImage
+  - after: default-registry.io/default-repo/run@sha256:build-image-digest
+    field: runImage
+  images:
+  - afterDigest: sha256:build-image-digest
+    field: buildImage
+  - afterDigest: sha256:build-image-digest
+    field: runImage
+  kind: ClusterStack
+  name: stack-name
+- action: create
+  added:
+  - after: default-registry.io/default-repo/build@sha256:build-image-digest
+    field: buildImage
+  - after: default-registry.io/default-repo/run@sha256:build-image-digest
+    field: runImage
+  images:
+  - afterDigest: sha256:build-image-digest
+    field: buildImage
+  - afterDigest: sha256:build-image-digest
+    field: runImage
+  kind: ClusterStack
+  name: default
+- action: create
+  added:
+  - after: stack-name
+    field: clusterStack
+  - after: store-name
+    field: clusterStore
+  - after: '[{"group":[{"id":"buildpack-id"}]}]'
+    field: order
+  kind: ClusterBuilder
+  name: clusterbuilder-name
+- action: create
+  added:
+  - after: stack-name
+    field: clusterStack
+  - after: store-name
+    field: clusterStore
+  - after: '[{"group":[{"id":"buildpack-id"}]}]'
+    field: order
+  kind: ClusterBuilder
+  name: default
+hasChanges: true
+kind: ImportChangeSummary
+`, string(summary))
+			})
+
+			it("returns the changes pending exit code when changes are not imported", func() {
+				fakeConfirmationProvider = commandsfakes.NewFakeConfirmationProvider(false, nil)
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						kpConfig,
+						lifecycleImageConfig,
+					},
+					Args: []string{
+						"-f", "./testdata/deps.yaml",
+						"--show-changes",
+						"--exit-code",
+					},
+					ExpectErr: true,
+					ExpectedOutput: `Changes
+
+Lifecycle
+
+some-diff
+
+ClusterStores
+
+some-diff
+
+ClusterStacks
+
+some-diff
+
+some-diff
+
+ClusterBuilders
+
+some-diff
+
+some-diff
+
+
+Skipping import
+`,
+					ExpectedErrorOutput: "Error: import has pending changes\n",
+				}.TestK8sAndKpack(t, cmdFunc)
+			})
 		})
 	})
 
@@ -524,6 +700,7 @@ Imported resources
 			timestampProvider.timestamp = newTimestamp
 
 			expectedLifecycleImageConfig.Annotations[importTimestampKey] = newTimestamp
+			expectedLifecycleImageConfig.Annotations[lifecycleHistoryKey] = lifecycleHistory(expectedLifecycleImageConfig.Data[lifecycleImageKey], newTimestamp)
 
 			store.Generation = 12
 			expectedStore := store.DeepCopy()
@@ -606,7 +783,10 @@ Imported resources
 				builder.Annotations = nil
 				defaultBuilder.Annotations = nil
 
-				expectedLifecycleImageConfig.Annotations = map[string]string{importTimestampKey: newTimestamp}
+				expectedLifecycleImageConfig.Annotations = map[string]string{
+					importTimestampKey:  newTimestamp,
+					lifecycleHistoryKey: lifecycleHistory(expectedLifecycleImageConfig.Data[lifecycleImageKey], newTimestamp),
+				}
 				expectedStore.Annotations = map[string]string{importTimestampKey: newTimestamp}
 				expectedBuilder.Annotations["kubectl.kubernetes.io/last-applied-configuration"] = `{"kind":"ClusterBuilder","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"clusterbuilder-name","creationTimestamp":null},"spec":{"tag":"default-registry.io/default-repo/clusterbuilder-name","stack":{"kind":"ClusterStack","name":"stack-name"},"store":{"kind":"ClusterStore","name":"store-name"},"order":[{"group":[{"id":"buildpack-id"}]}],"serviceAccountRef":{"namespace":"kpack","name":"some-serviceaccount"}},"status":{"stack":{}}}`
 				expectedDefaultBuilder.Annotations["kubectl.kubernetes.io/last-applied-configuration"] = `{"kind":"ClusterBuilder","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"default","creationTimestamp":null},"spec":{"tag":"default-registry.io/default-repo/default","stack":{"kind":"ClusterStack","name":"stack-name"},"store":{"kind":"ClusterStore","name":"store-name"},"order":[{"group":[{"id":"buildpack-id"}]}],"serviceAccountRef":{"namespace":"kpack","name":"some-serviceaccount"}},"status":{"stack":{}}}`
@@ -659,6 +839,7 @@ Imported resources
 
 			expectedLifecycleImageConfig.Annotations[importTimestampKey] = newTimestamp
 			expectedLifecycleImageConfig.Data[lifecycleImageKey] = "default-registry.io/default-repo/lifecycle@sha256:another-lifecycle-image-digest"
+			expectedLifecycleImageConfig.Annotations[lifecycleHistoryKey] = lifecycleHistory(expectedLifecycleImageConfig.Data[lifecycleImageKey], newTimestamp)
 
 			expectedStore := store.DeepCopy()
 			expectedStore.Annotations[importTimestampKey] = newTimestamp
@@ -792,6 +973,7 @@ kind: ConfigMap
 metadata:
   annotations:
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
+    kpack.io/lifecycle-history: '[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]'
   creationTimestamp: null
   name: lifecycle-image
   namespace: kpack
@@ -929,7 +1111,8 @@ status:
         "namespace": "kpack",
         "creationTimestamp": null,
         "annotations": {
-            "kpack.io/import-timestamp": "2006-01-02T15:04:05Z"
+            "kpack.io/import-timestamp": "2006-01-02T15:04:05Z",
+            "kpack.io/lifecycle-history": "[{\"image\":\"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest\",\"changedAt\":\"2006-01-02T15:04:05Z\"}]"
         }
     },
     "data": {
@@ -1109,6 +1292,43 @@ status:
 				},
 			}.TestK8sAndKpack(t, cmdFunc)
 		})
+
+		it("prints only the resources to stdout and the change summary to the summary file", func() {
+			summaryFile := filepath.Join(tempDir, "summary.json")
+
+			cmd := cmdFunc(k8sfakes.NewSimpleClientset(kpConfig, lifecycleImageConfig), kpackfakes.NewSimpleClientset())
+			cmd.SetArgs([]string{"-f", "./testdata/deps.yaml", "--summary-file", summaryFile, "--force", "--output", "json"})
+
+			out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(errOut)
+			require.NoError(t, cmd.Execute())
+
+			var kinds []string
+			decoder := json.NewDecoder(out)
+			for decoder.More() {
+				var obj metav1.TypeMeta
+				require.NoError(t, decoder.Decode(&obj))
+				kinds = append(kinds, obj.Kind)
+			}
+			require.Equal(t, []string{"ConfigMap", "ClusterStore", "ClusterStack", "ClusterStack", "ClusterBuilder", "ClusterBuilder"}, kinds)
+
+			summaryJSON, err := ioutil.ReadFile(summaryFile)
+			require.NoError(t, err)
+
+			var summary struct {
+				Kind       string `json:"kind"`
+				HasChanges bool   `json:"hasChanges"`
+				Changes    []struct {
+					Kind   string `json:"kind"`
+					Action string `json:"action"`
+				} `json:"changes"`
+			}
+			require.NoError(t, json.Unmarshal(summaryJSON, &summary))
+			require.Equal(t, "ImportChangeSummary", summary.Kind)
+			require.True(t, summary.HasChanges)
+			require.Len(t, summary.Changes, 6)
+		})
 	})
 
 	when("dry-run flag is used", func() {
@@ -1155,6 +1375,7 @@ kind: ConfigMap
 metadata:
   annotations:
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
+    kpack.io/lifecycle-history: '[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]'
   creationTimestamp: null
   name: lifecycle-image
   namespace: kpack
@@ -1334,6 +1555,7 @@ kind: ConfigMap
 metadata:
   annotations:
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
+    kpack.io/lifecycle-history: '[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]'
   creationTimestamp: null
   name: lifecycle-image
   namespace: kpack
@@ -0,0 +1,58 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/lifecycle"
+)
+
+func NewHistoryCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List lifecycle image history",
+		Long: fmt.Sprintf(`Prints a table of the lifecycle images used by kpack, the current image first.
+
+The history keeps the last %d lifecycle images set with "kp lifecycle update", "kp lifecycle rollback" or "kp import".
+Images that were not set by kp do not have a changed time.`, lifecycle.HistoryLimit),
+		Example:      "kp lifecycle history",
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			history, err := lifecycle.GetHistory(cmd.Context(), cs.K8sClient)
+			if err != nil {
+				return err
+			}
+
+			if len(history) == 0 {
+				return errors.New("no lifecycle image found")
+			}
+
+			tableWriter, err := commands.NewTableWriter(cmd.OutOrStdout(), "Image", "Changed")
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range history {
+				if err := tableWriter.AddRow(entry.Image, entry.ChangedAt); err != nil {
+					return err
+				}
+			}
+
+			return tableWriter.Write()
+		},
+	}
+	return cmd
+}
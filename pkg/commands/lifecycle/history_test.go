@@ -0,0 +1,84 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands/lifecycle"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestHistoryCommand(t *testing.T) {
+	spec.Run(t, "TestHistoryCommand", testHistoryCommand)
+}
+
+func testHistoryCommand(t *testing.T, when spec.G, it spec.S) {
+	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sProvider(k8sClient, "")
+		return lifecycle.NewHistoryCommand(clientSetProvider)
+	}
+
+	lifecycleImageConfig := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "lifecycle-image",
+			Namespace: "kpack",
+			Annotations: map[string]string{
+				"kpack.io/lifecycle-history": `[{"image":"some-registry.io/lifecycle@sha256:new-digest","changedAt":"2006-01-02T15:04:05Z"},{"image":"some-installed-lifecycle"}]`,
+			},
+		},
+		Data: map[string]string{
+			"image": "some-registry.io/lifecycle@sha256:new-digest",
+		},
+	}
+
+	it("lists the lifecycle images with the current image first", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectedOutput: `IMAGE                                           CHANGED
+some-registry.io/lifecycle@sha256:new-digest    2006-01-02T15:04:05Z
+some-installed-lifecycle                        
+
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("lists a current image that was not set by kp", func() {
+		lifecycleImageConfig.Data["image"] = "some-other-lifecycle"
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectedOutput: `IMAGE                                           CHANGED
+some-other-lifecycle                            
+some-registry.io/lifecycle@sha256:new-digest    2006-01-02T15:04:05Z
+some-installed-lifecycle                        
+
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("errors when there is no lifecycle image", func() {
+		lifecycleImageConfig.Annotations = nil
+		lifecycleImageConfig.Data = map[string]string{}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: no lifecycle image found\n",
+		}.TestK8s(t, cmdFunc)
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/lifecycle"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func NewRollbackCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, timestampProvider lifecycle.TimestampProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+	var tlsCfg registry.TLSConfig
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Rollback lifecycle image used by kpack",
+		Long: `Rollback the lifecycle image used by kpack to the previous image in the lifecycle image history.
+
+Use "kp lifecycle history" to list the previous images, each rollback restores the next image in the history.
+The previous image is read from the registry before it is restored, and the rollback fails if it no longer exists.
+Therefore, you must have credentials to access the registry on your machine.
+
+With --wait, the command waits until every ready ClusterBuilder has been rebuilt with the restored lifecycle image.`,
+		Example: `kp lifecycle rollback
+kp lifecycle rollback --wait`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			if err = ch.PrintStatus("Rolling back lifecycle image..."); err != nil {
+				return err
+			}
+
+			var builders []v1alpha2.ClusterBuilder
+			if ch.ShouldWait() {
+				builders, err = readyClusterBuilders(ctx, cs)
+				if err != nil {
+					return err
+				}
+			}
+
+			configMap, err := lifecycle.RollbackImage(ctx, cs.K8sClient, authn.DefaultKeychain, rup.Fetcher(tlsCfg), timestampProvider.GetTimestamp(), ch.IsDryRun())
+			if err != nil {
+				return err
+			}
+
+			if len(builders) > 0 {
+				if err = waitForClusterBuilders(ctx, ch, builders, newWaiter(cs.DynamicClient)); err != nil {
+					return err
+				}
+			}
+
+			if err := ch.PrintObj(configMap); err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Rolled back lifecycle image to %q", configMap.Data["image"])
+		},
+	}
+	cmd.Flags().BoolP(commands.WaitFlag, "w", false, "wait for ClusterBuilders to be rebuilt with the restored lifecycle image")
+	commands.SetDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
+
+// readyClusterBuilders returns the ClusterBuilders that kpack will rebuild when the lifecycle image changes.
+func readyClusterBuilders(ctx context.Context, cs k8s.ClientSet) ([]v1alpha2.ClusterBuilder, error) {
+	list, err := cs.KpackClient.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var builders []v1alpha2.ClusterBuilder
+	for _, cb := range list.Items {
+		if cb.Status.LatestImage != "" && cb.Status.GetCondition(corev1alpha1.ConditionReady).IsTrue() {
+			builders = append(builders, cb)
+		}
+	}
+	return builders, nil
+}
+
+func waitForClusterBuilders(ctx context.Context, ch *commands.CommandHelper, builders []v1alpha2.ClusterBuilder, waiter commands.ResourceWaiter) error {
+	if err := ch.PrintStatus("Waiting for %d ClusterBuilder(s) to use the lifecycle image...", len(builders)); err != nil {
+		return err
+	}
+
+	// the builders are waited on together, as each can take minutes to rebuild
+	errs, errCtx := errgroup.WithContext(ctx)
+	for i := range builders {
+		cb := &builders[i]
+		errs.Go(func() error {
+			return waiter.Wait(errCtx, cb, lifecycle.BuilderHasUpdated(cb.Status.LatestImage))
+		})
+	}
+	return errs.Wait()
+}
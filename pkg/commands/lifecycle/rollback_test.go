@@ -0,0 +1,220 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	commandsfakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands/lifecycle"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestRollbackCommand(t *testing.T) {
+	spec.Run(t, "TestRollbackCommand", testRollbackCommand)
+}
+
+func testRollbackCommand(t *testing.T, when spec.G, it spec.S) {
+	fakeWaiter := &commandsfakes.FakeWaiter{}
+
+	fakeRegistryUtilProvider := registryfakes.UtilProvider{
+		FakeFetcher: registryfakes.NewLifecycleImageFetcher(
+			registryfakes.LifecycleInfo{
+				Metadata: `{"lifecycle":{"version":"0.10.0"},"apis":{"buildpack":{"supported":["0.2"]},"platform":{"supported":["0.3"]}}}`,
+				ImageInfo: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/lifecycle@sha256:old-digest",
+					Digest: "old-digest",
+				},
+			},
+		),
+	}
+
+	cmdFunc := func(k8sClient *fake.Clientset, kpackClient *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sAndKpackProvider(k8sClient, kpackClient, "")
+		return lifecycle.NewRollbackCommand(clientSetProvider, fakeRegistryUtilProvider, FakeTimestampProvider{timestamp: "2006-01-03T15:04:05Z"}, func(dynamic.Interface) commands.ResourceWaiter {
+			return fakeWaiter
+		})
+	}
+
+	lifecycleImageConfig := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "lifecycle-image",
+			Namespace: "kpack",
+			Annotations: map[string]string{
+				"kpack.io/lifecycle-history": `[{"image":"some-registry.io/lifecycle@sha256:new-digest","changedAt":"2006-01-02T15:04:05Z"},{"image":"some-registry.io/lifecycle@sha256:old-digest","changedAt":"2006-01-01T15:04:05Z"},{"image":"some-installed-lifecycle"}]`,
+			},
+		},
+		Data: map[string]string{
+			"image": "some-registry.io/lifecycle@sha256:new-digest",
+		},
+	}
+
+	rolledBackLifecycleImageConfig := lifecycleImageConfig.DeepCopy()
+	rolledBackLifecycleImageConfig.Annotations["kpack.io/lifecycle-history"] = `[{"image":"some-registry.io/lifecycle@sha256:old-digest","changedAt":"2006-01-03T15:04:05Z"},{"image":"some-installed-lifecycle"}]`
+	rolledBackLifecycleImageConfig.Data["image"] = "some-registry.io/lifecycle@sha256:old-digest"
+
+	clusterBuilder := func(name, latestImage string, ready corev1.ConditionStatus) *v1alpha2.ClusterBuilder {
+		return &v1alpha2.ClusterBuilder{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Status: v1alpha2.BuilderStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{{Type: corev1alpha1.ConditionReady, Status: ready}},
+				},
+				LatestImage: latestImage,
+			},
+		}
+	}
+
+	it("restores the previous lifecycle image", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: rolledBackLifecycleImageConfig,
+				},
+			},
+			ExpectedOutput: `Rolling back lifecycle image...
+Rolled back lifecycle image to "some-registry.io/lifecycle@sha256:old-digest"
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+		require.Len(t, fakeWaiter.WaitCalls, 0)
+	})
+
+	it("restores an image that was not set by kp", func() {
+		lifecycleImageConfig.Data["image"] = "some-registry.io/lifecycle@sha256:external-digest"
+		lifecycleImageConfig.Annotations = map[string]string{
+			"kpack.io/lifecycle-history": `[{"image":"some-registry.io/lifecycle@sha256:old-digest","changedAt":"2006-01-01T15:04:05Z"}]`,
+		}
+
+		expectedLifecycleImageConfig := lifecycleImageConfig.DeepCopy()
+		expectedLifecycleImageConfig.Annotations["kpack.io/lifecycle-history"] = `[{"image":"some-registry.io/lifecycle@sha256:old-digest","changedAt":"2006-01-03T15:04:05Z"}]`
+		expectedLifecycleImageConfig.Data["image"] = "some-registry.io/lifecycle@sha256:old-digest"
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedLifecycleImageConfig,
+				},
+			},
+			ExpectedOutput: `Rolling back lifecycle image...
+Rolled back lifecycle image to "some-registry.io/lifecycle@sha256:old-digest"
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when there is no previous lifecycle image", func() {
+		lifecycleImageConfig.Annotations = nil
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectErr: true,
+			ExpectedOutput: `Rolling back lifecycle image...
+`,
+			ExpectedErrorOutput: "Error: no previous lifecycle image to roll back to\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when the previous lifecycle image no longer exists", func() {
+		lifecycleImageConfig.Annotations = map[string]string{
+			"kpack.io/lifecycle-history": `[{"image":"some-registry.io/lifecycle@sha256:new-digest","changedAt":"2006-01-02T15:04:05Z"},{"image":"some-registry.io/lifecycle@sha256:deleted-digest","changedAt":"2006-01-01T15:04:05Z"}]`,
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectErr: true,
+			ExpectedOutput: `Rolling back lifecycle image...
+`,
+			ExpectedErrorOutput: "Error: previous lifecycle image \"some-registry.io/lifecycle@sha256:deleted-digest\" is not available: image not found: \"some-registry.io/lifecycle@sha256:deleted-digest\"\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when lifecycle-image configmap is not found", func() {
+		testhelpers.CommandTest{
+			ExpectErr: true,
+			ExpectedOutput: `Rolling back lifecycle image...
+`,
+			ExpectedErrorOutput: "Error: configmap \"lifecycle-image\" not found in \"kpack\" namespace\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	when("wait flag is used", func() {
+		it("waits for the ready ClusterBuilders to be rebuilt", func() {
+			readyBuilder := clusterBuilder("ready-builder", "some-registry.io/builder@sha256:builder-digest", corev1.ConditionTrue)
+			notReadyBuilder := clusterBuilder("not-ready-builder", "", corev1.ConditionFalse)
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					lifecycleImageConfig,
+					readyBuilder,
+					notReadyBuilder,
+				},
+				Args: []string{"--wait"},
+				ExpectUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: rolledBackLifecycleImageConfig,
+					},
+				},
+				ExpectedOutput: `Rolling back lifecycle image...
+Waiting for 1 ClusterBuilder(s) to use the lifecycle image...
+Rolled back lifecycle image to "some-registry.io/lifecycle@sha256:old-digest"
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+
+			require.Len(t, fakeWaiter.WaitCalls, 1)
+			require.Equal(t, readyBuilder.Name, fakeWaiter.WaitCalls[0].Object.(*v1alpha2.ClusterBuilder).Name)
+			require.Len(t, fakeWaiter.WaitCalls[0].ExtraChecks, 1)
+
+			hasUpdated := fakeWaiter.WaitCalls[0].ExtraChecks[0]
+
+			done, err := hasUpdated(watch.Event{Object: readyBuilder})
+			require.NoError(t, err)
+			require.False(t, done)
+
+			done, err = hasUpdated(watch.Event{Object: clusterBuilder("ready-builder", "some-registry.io/builder@sha256:rebuilt-digest", corev1.ConditionTrue)})
+			require.NoError(t, err)
+			require.True(t, done)
+		})
+	})
+
+	when("dry-run flag is used", func() {
+		it("does not update the lifecycle-image configmap or wait", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					lifecycleImageConfig,
+					clusterBuilder("ready-builder", "some-registry.io/builder@sha256:builder-digest", corev1.ConditionTrue),
+				},
+				Args: []string{"--dry-run", "--wait"},
+				ExpectedOutput: `Rolling back lifecycle image... (dry run)
+Rolled back lifecycle image to "some-registry.io/lifecycle@sha256:old-digest" (dry run)
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+			require.Len(t, fakeWaiter.WaitCalls, 0)
+		})
+	})
+}
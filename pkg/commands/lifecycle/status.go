@@ -0,0 +1,62 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/lifecycle"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func NewStatusCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var tlsCfg registry.TLSConfig
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Display lifecycle image status",
+		Long: `Prints information about the lifecycle image used by kpack.
+
+The lifecycle version and the supported buildpack and platform API versions are read from the "io.buildpacks.lifecycle.metadata" label of the image.
+Therefore, you must have credentials to access the registry on your machine.
+
+The changed time is only known for lifecycle images set with "kp lifecycle update" or "kp lifecycle rollback".`,
+		Example:      "kp lifecycle status",
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			status, err := lifecycle.GetStatus(cmd.Context(), cs.K8sClient, authn.DefaultKeychain, rup.Fetcher(tlsCfg))
+			if err != nil {
+				return err
+			}
+
+			statusWriter := commands.NewStatusWriter(cmd.OutOrStdout())
+			err = statusWriter.AddBlock("",
+				"Image", status.Image,
+				"Digest", status.Digest,
+				"Version", status.Version,
+				"Buildpack APIs", strings.Join(status.BuildpackAPIs, ", "),
+				"Platform APIs", strings.Join(status.PlatformAPIs, ", "),
+				"Changed", status.ChangedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			return statusWriter.Write()
+		},
+	}
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
@@ -0,0 +1,104 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands/lifecycle"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestStatusCommand(t *testing.T) {
+	spec.Run(t, "TestStatusCommand", testStatusCommand)
+}
+
+func testStatusCommand(t *testing.T, when spec.G, it spec.S) {
+	fakeRegistryUtilProvider := &registryfakes.UtilProvider{
+		FakeFetcher: registryfakes.NewLifecycleImageFetcher(
+			registryfakes.LifecycleInfo{
+				Metadata: `{"lifecycle":{"version":"0.11.0"},"api":{"buildpack":"0.2","platform":"0.3"},"apis":{"buildpack":{"deprecated":[],"supported":["0.2","0.3","0.4"]},"platform":{"deprecated":[],"supported":["0.3","0.4"]}}}`,
+				ImageInfo: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/lifecycle@sha256:new-digest",
+					Digest: "new-digest",
+				},
+			},
+			registryfakes.LifecycleInfo{
+				Metadata: `{"lifecycle":{"version":"0.7.5"},"api":{"buildpack":"0.2","platform":"0.3"}}`,
+				ImageInfo: registryfakes.ImageInfo{
+					Ref:    "some-installed-lifecycle",
+					Digest: "installed-digest",
+				},
+			},
+		),
+	}
+
+	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sProvider(k8sClient, "")
+		return lifecycle.NewStatusCommand(clientSetProvider, fakeRegistryUtilProvider)
+	}
+
+	lifecycleImageConfig := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "lifecycle-image",
+			Namespace: "kpack",
+			Annotations: map[string]string{
+				"kpack.io/lifecycle-history": `[{"image":"some-registry.io/lifecycle@sha256:new-digest","changedAt":"2006-01-02T15:04:05Z"},{"image":"some-installed-lifecycle"}]`,
+			},
+		},
+		Data: map[string]string{
+			"image": "some-registry.io/lifecycle@sha256:new-digest",
+		},
+	}
+
+	it("displays the lifecycle version, api versions and when it was changed", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectedOutput: `Image:             some-registry.io/lifecycle@sha256:new-digest
+Digest:            sha256:new-digest
+Version:           0.11.0
+Buildpack APIs:    0.2, 0.3, 0.4
+Platform APIs:     0.3, 0.4
+Changed:           2006-01-02T15:04:05Z
+
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("displays the single api versions of a lifecycle image that was not set by kp", func() {
+		lifecycleImageConfig.Annotations = nil
+		lifecycleImageConfig.Data["image"] = "some-installed-lifecycle"
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectedOutput: `Image:             some-installed-lifecycle
+Digest:            sha256:installed-digest
+Version:           0.7.5
+Buildpack APIs:    0.2
+Platform APIs:     0.3
+Changed:           --
+
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("errors when lifecycle-image configmap is not found", func() {
+		testhelpers.CommandTest{
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: configmap \"lifecycle-image\" not found in \"kpack\" namespace\n",
+		}.TestK8s(t, cmdFunc)
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -15,7 +17,7 @@ import (
 	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
 )
 
-func NewUpdateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+func NewUpdateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, timestampProvider lifecycle.TimestampProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
 		image  string
 		tlsCfg registry.TLSConfig
@@ -30,8 +32,13 @@ The Lifecycle image will be uploaded to the default repository.
 Therefore, you must have credentials to access the registry on your machine.
 
 The default repository is read from the "default.repository" key of the "kp-config" ConfigMap within "kpack" namespace.
+
+The previous lifecycle image is kept in the lifecycle image history and can be restored with "kp lifecycle rollback".
+
+With --wait, the command waits until every ready ClusterBuilder has been rebuilt with the new lifecycle image.
 `,
-		Example:      "kp lifecycle update --image my-registry.com/lifecycle",
+		Example: `kp lifecycle update --image my-registry.com/lifecycle
+kp lifecycle update --image my-registry.com/lifecycle --wait`,
 		Args:         commands.ExactArgsWithUsage(0),
 		SilenceUsage: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -51,10 +58,26 @@ The default repository is read from the "default.repository" key of the "kp-conf
 				return err
 			}
 
+			ctx := cmd.Context()
+
 			if err = ch.PrintStatus("Updating lifecycle image..."); err != nil {
 				return err
 			}
 
+			var (
+				previousImage string
+				builders      []v1alpha2.ClusterBuilder
+			)
+			if ch.ShouldWait() {
+				if previousImage, err = lifecycle.GetImage(ctx, cs.K8sClient); err != nil {
+					return err
+				}
+
+				if builders, err = readyClusterBuilders(ctx, cs); err != nil {
+					return err
+				}
+			}
+
 			cfg := lifecycle.ImageUpdaterConfig{
 				DryRun:       ch.IsDryRun(),
 				IOWriter:     ch.Writer(),
@@ -62,13 +85,21 @@ The default repository is read from the "default.repository" key of the "kp-conf
 				ImgRelocator: rup.Relocator(ch.Writer(), tlsCfg, ch.CanChangeState()),
 				ClientSet:    cs,
 				TLSConfig:    tlsCfg,
+				Timestamp:    timestampProvider.GetTimestamp(),
 			}
 
-			configMap, err := lifecycle.UpdateImage(cmd.Context(), authn.DefaultKeychain, image, cfg)
+			configMap, err := lifecycle.UpdateImage(ctx, authn.DefaultKeychain, image, cfg)
 			if err != nil {
 				return err
 			}
 
+			// the ClusterBuilders are not rebuilt when the lifecycle image did not change
+			if len(builders) > 0 && configMap.Data["image"] != previousImage {
+				if err = waitForClusterBuilders(ctx, ch, builders, newWaiter(cs.DynamicClient)); err != nil {
+					return err
+				}
+			}
+
 			if err := ch.PrintObj(configMap); err != nil {
 				return err
 			}
@@ -77,6 +108,7 @@ The default repository is read from the "default.repository" key of the "kp-conf
 		},
 	}
 	cmd.Flags().StringVarP(&image, "image", "i", "", "location of the image")
+	cmd.Flags().BoolP(commands.WaitFlag, "w", false, "wait for ClusterBuilders to be rebuilt with the new lifecycle image")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
 	return cmd
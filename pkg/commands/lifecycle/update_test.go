@@ -6,14 +6,21 @@ package lifecycle_test
 import (
 	"testing"
 
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/sclevine/spec"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	commandsfakes "github.com/vmware-tanzu/kpack-cli/pkg/commands/fakes"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands/lifecycle"
 	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
 	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
@@ -37,9 +44,13 @@ func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
 		),
 	}
 
-	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
-		clientSetProvider := testhelpers.GetFakeK8sProvider(k8sClient, "")
-		return lifecycle.NewUpdateCommand(clientSetProvider, fakeRegistryUtilProvider)
+	fakeWaiter := &commandsfakes.FakeWaiter{}
+
+	cmdFunc := func(k8sClient *fake.Clientset, kpackClient *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sAndKpackProvider(k8sClient, kpackClient, "")
+		return lifecycle.NewUpdateCommand(clientSetProvider, fakeRegistryUtilProvider, FakeTimestampProvider{timestamp: "2006-01-02T15:04:05Z"}, func(dynamic.Interface) commands.ResourceWaiter {
+			return fakeWaiter
+		})
 	}
 
 	kpConfig := &corev1.ConfigMap{
@@ -62,6 +73,9 @@ func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
 
 	updatedLifecycleImageConfig := lifecycleImageConfig.DeepCopy()
 	updatedLifecycleImageConfig.Data["image"] = "default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest"
+	updatedLifecycleImageConfig.Annotations = map[string]string{
+		"kpack.io/lifecycle-history": `[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]`,
+	}
 
 	it("errors when lifecycle-image configmap is not found", func() {
 		testhelpers.CommandTest{
@@ -74,7 +88,7 @@ func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
 			ExpectErr:           true,
 			ExpectedOutput:      "Updating lifecycle image...\n",
 			ExpectedErrorOutput: "Error: configmap \"lifecycle-image\" not found in \"kpack\" namespace\n",
-		}.TestK8s(t, cmdFunc)
+		}.TestK8sAndKpack(t, cmdFunc)
 	})
 
 	it("errors when io.buildpacks.lifecycle.metadata label is not set on given image", func() {
@@ -93,7 +107,7 @@ func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
 			ExpectErr:           true,
 			ExpectedOutput:      "Updating lifecycle image...\n",
 			ExpectedErrorOutput: "Error: image missing lifecycle metadata\n",
-		}.TestK8s(t, cmdFunc)
+		}.TestK8sAndKpack(t, cmdFunc)
 	})
 
 	it("errors when default.repository key is not found in kp-config configmap", func() {
@@ -116,7 +130,7 @@ func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
 			ExpectErr:           true,
 			ExpectedOutput:      "Updating lifecycle image...\n",
 			ExpectedErrorOutput: "Error: failed to get default repository: use \"kp config default-repository\" to set\n",
-		}.TestK8s(t, cmdFunc)
+		}.TestK8sAndKpack(t, cmdFunc)
 	})
 
 	it("updates lifecycle-image ConfigMap", func() {
@@ -137,7 +151,131 @@ func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
 	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 Updated lifecycle image
 `,
-		}.TestK8s(t, cmdFunc)
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("records the previous lifecycle image in the lifecycle image history", func() {
+		existingLifecycleImageConfig := lifecycleImageConfig.DeepCopy()
+		existingLifecycleImageConfig.Annotations = map[string]string{
+			"kpack.io/lifecycle-history": `[{"image":"default-registry.io/default-repo/lifecycle@sha256:previous-digest","changedAt":"2006-01-01T15:04:05Z"},{"image":"some-installed-lifecycle"}]`,
+		}
+		existingLifecycleImageConfig.Data["image"] = "default-registry.io/default-repo/lifecycle@sha256:previous-digest"
+
+		expectedLifecycleImageConfig := existingLifecycleImageConfig.DeepCopy()
+		expectedLifecycleImageConfig.Annotations["kpack.io/lifecycle-history"] = `[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"},{"image":"default-registry.io/default-repo/lifecycle@sha256:previous-digest","changedAt":"2006-01-01T15:04:05Z"},{"image":"some-installed-lifecycle"}]`
+		expectedLifecycleImageConfig.Data["image"] = "default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest"
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				kpConfig,
+				existingLifecycleImageConfig,
+			},
+			Args: []string{
+				"--image", "some-registry.io/repo/lifecycle-image",
+			},
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedLifecycleImageConfig,
+				},
+			},
+			ExpectedOutput: `Updating lifecycle image...
+	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
+Updated lifecycle image
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	when("wait flag is used", func() {
+		clusterBuilder := func(name, latestImage string, ready corev1.ConditionStatus) *v1alpha2.ClusterBuilder {
+			return &v1alpha2.ClusterBuilder{
+				ObjectMeta: v1.ObjectMeta{Name: name},
+				Status: v1alpha2.BuilderStatus{
+					Status: corev1alpha1.Status{
+						Conditions: corev1alpha1.Conditions{{Type: corev1alpha1.ConditionReady, Status: ready}},
+					},
+					LatestImage: latestImage,
+				},
+			}
+		}
+
+		it("waits for the ready ClusterBuilders to be rebuilt", func() {
+			readyBuilder := clusterBuilder("ready-builder", "some-registry.io/builder@sha256:builder-digest", corev1.ConditionTrue)
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					kpConfig,
+					lifecycleImageConfig,
+					readyBuilder,
+					clusterBuilder("not-ready-builder", "", corev1.ConditionFalse),
+				},
+				Args: []string{
+					"--image", "some-registry.io/repo/lifecycle-image",
+					"--wait",
+				},
+				ExpectUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: updatedLifecycleImageConfig,
+					},
+				},
+				ExpectedOutput: `Updating lifecycle image...
+	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
+Waiting for 1 ClusterBuilder(s) to use the lifecycle image...
+Updated lifecycle image
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+
+			require.Len(t, fakeWaiter.WaitCalls, 1)
+			require.Equal(t, readyBuilder.Name, fakeWaiter.WaitCalls[0].Object.(*v1alpha2.ClusterBuilder).Name)
+			require.Len(t, fakeWaiter.WaitCalls[0].ExtraChecks, 1)
+		})
+
+		it("does not wait when the lifecycle image did not change", func() {
+			existingLifecycleImageConfig := updatedLifecycleImageConfig.DeepCopy()
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					kpConfig,
+					existingLifecycleImageConfig,
+					clusterBuilder("ready-builder", "some-registry.io/builder@sha256:builder-digest", corev1.ConditionTrue),
+				},
+				Args: []string{
+					"--image", "some-registry.io/repo/lifecycle-image",
+					"--wait",
+				},
+				ExpectUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: existingLifecycleImageConfig,
+					},
+				},
+				ExpectedOutput: `Updating lifecycle image...
+	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
+Updated lifecycle image
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+
+			require.Len(t, fakeWaiter.WaitCalls, 0)
+		})
+
+		it("does not wait with --dry-run", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					kpConfig,
+					lifecycleImageConfig,
+					clusterBuilder("ready-builder", "some-registry.io/builder@sha256:builder-digest", corev1.ConditionTrue),
+				},
+				Args: []string{
+					"--image", "some-registry.io/repo/lifecycle-image",
+					"--wait",
+					"--dry-run",
+				},
+				ExpectedOutput: `Updating lifecycle image... (dry run)
+	Skipping 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
+Updated lifecycle image (dry run)
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+
+			require.Len(t, fakeWaiter.WaitCalls, 0)
+		})
 	})
 
 	when("output flag is used", func() {
@@ -147,6 +285,8 @@ data:
   image: default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest
 kind: ConfigMap
 metadata:
+  annotations:
+    kpack.io/lifecycle-history: '[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]'
   creationTimestamp: null
   name: lifecycle-image
   namespace: kpack
@@ -170,7 +310,7 @@ metadata:
 				ExpectedErrorOutput: `Updating lifecycle image...
 	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 `,
-			}.TestK8s(t, cmdFunc)
+			}.TestK8sAndKpack(t, cmdFunc)
 		})
 
 		it("can output in json format", func() {
@@ -180,7 +320,10 @@ metadata:
     "metadata": {
         "name": "lifecycle-image",
         "namespace": "kpack",
-        "creationTimestamp": null
+        "creationTimestamp": null,
+        "annotations": {
+            "kpack.io/lifecycle-history": "[{\"image\":\"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest\",\"changedAt\":\"2006-01-02T15:04:05Z\"}]"
+        }
     },
     "data": {
         "image": "default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest"
@@ -206,7 +349,7 @@ metadata:
 				ExpectedErrorOutput: `Updating lifecycle image...
 	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 `,
-			}.TestK8s(t, cmdFunc)
+			}.TestK8sAndKpack(t, cmdFunc)
 		})
 	})
 
@@ -225,7 +368,7 @@ metadata:
 	Skipping 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 Updated lifecycle image (dry run)
 `,
-			}.TestK8s(t, cmdFunc)
+			}.TestK8sAndKpack(t, cmdFunc)
 		})
 
 		when("output flag is used", func() {
@@ -235,6 +378,8 @@ data:
   image: default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest
 kind: ConfigMap
 metadata:
+  annotations:
+    kpack.io/lifecycle-history: '[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]'
   creationTimestamp: null
   name: lifecycle-image
   namespace: kpack
@@ -254,7 +399,7 @@ metadata:
 					ExpectedErrorOutput: `Updating lifecycle image... (dry run)
 	Skipping 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 `,
-				}.TestK8s(t, cmdFunc)
+				}.TestK8sAndKpack(t, cmdFunc)
 			})
 		})
 	})
@@ -274,7 +419,7 @@ metadata:
 	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 Updated lifecycle image (dry run with image upload)
 `,
-			}.TestK8s(t, cmdFunc)
+			}.TestK8sAndKpack(t, cmdFunc)
 		})
 
 		when("output flag is used", func() {
@@ -284,6 +429,8 @@ data:
   image: default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest
 kind: ConfigMap
 metadata:
+  annotations:
+    kpack.io/lifecycle-history: '[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest","changedAt":"2006-01-02T15:04:05Z"}]'
   creationTimestamp: null
   name: lifecycle-image
   namespace: kpack
@@ -303,8 +450,16 @@ metadata:
 					ExpectedErrorOutput: `Updating lifecycle image... (dry run with image upload)
 	Uploading 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-image-digest'
 `,
-				}.TestK8s(t, cmdFunc)
+				}.TestK8sAndKpack(t, cmdFunc)
 			})
 		})
 	})
 }
+
+type FakeTimestampProvider struct {
+	timestamp string
+}
+
+func (f FakeTimestampProvider) GetTimestamp() string {
+	return f.timestamp
+}
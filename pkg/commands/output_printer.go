@@ -0,0 +1,312 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+const (
+	OutputWide = "wide"
+	OutputName = "name"
+
+	jsonPathPrefix      = "jsonpath="
+	customColumnsPrefix = "custom-columns="
+)
+
+// Column is a column of the table printed by list commands. Wide columns are only printed with the wide output format.
+type Column struct {
+	Header string
+	Wide   bool
+}
+
+// OutputPrinter prints the resources of list and status commands in the format set by the --output flag.
+type OutputPrinter struct {
+	format        string
+	out           io.Writer
+	template      *jsonpath.JSONPath
+	customColumns []customColumn
+	typeToGVK     map[reflect.Type]schema.GroupVersionKind
+}
+
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+func SetOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(OutputFlag, "o", "", `output format; supported formats are: yaml, json, wide, name,
+  jsonpath=<template>, custom-columns=<header>:<json-path>[,<header>:<json-path>...]`)
+}
+
+func NewOutputPrinter(cmd *cobra.Command) (*OutputPrinter, error) {
+	format, err := GetStringFlag(OutputFlag, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &OutputPrinter{
+		format:    format,
+		out:       cmd.OutOrStdout(),
+		typeToGVK: getTypeToGVKLookup(),
+	}
+
+	switch {
+	case format == "", format == OutputWide, format == OutputName, format == k8s.FormatYAML, format == k8s.FormatJSON:
+	case strings.HasPrefix(format, jsonPathPrefix):
+		p.template, err = parseJSONPath("jsonpath", strings.TrimPrefix(format, jsonPathPrefix))
+	case strings.HasPrefix(format, customColumnsPrefix):
+		p.customColumns, err = parseCustomColumns(strings.TrimPrefix(format, customColumnsPrefix))
+	default:
+		err = errors.Errorf("unsupported output format: %q, supported formats are yaml, json, wide, name, jsonpath=<template>, custom-columns=<spec>", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// IsTable is true when the command prints its own table or status, without an output format or with the wide output format.
+func (p *OutputPrinter) IsTable() bool {
+	return p.format == "" || p.IsWide()
+}
+
+func (p *OutputPrinter) IsWide() bool {
+	return p.format == OutputWide
+}
+
+// PrintList prints the objects as a table with a row per object or, with an output format, as a list.
+func (p *OutputPrinter) PrintList(objs []runtime.Object, columns []Column, row func(obj runtime.Object) []string) error {
+	if !p.IsTable() {
+		return p.print(objs, true)
+	}
+
+	var headers []string
+	for _, c := range columns {
+		if !c.Wide || p.IsWide() {
+			headers = append(headers, c.Header)
+		}
+	}
+
+	writer, err := NewTableWriter(p.out, headers...)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		values := row(obj)
+		if len(values) != len(columns) {
+			return errors.New("incorrect number of columns for row")
+		}
+
+		var cells []string
+		for i, c := range columns {
+			if !c.Wide || p.IsWide() {
+				cells = append(cells, values[i])
+			}
+		}
+
+		if err := writer.AddRow(cells...); err != nil {
+			return err
+		}
+	}
+
+	return writer.Write()
+}
+
+// PrintObj prints the object in the output format. Status commands print their own status when IsTable is true.
+func (p *OutputPrinter) PrintObj(obj runtime.Object) error {
+	return p.print([]runtime.Object{obj}, false)
+}
+
+func (p *OutputPrinter) print(objs []runtime.Object, list bool) error {
+	for _, obj := range objs {
+		if err := p.setGVK(obj); err != nil {
+			return err
+		}
+	}
+
+	if p.format == OutputName {
+		return p.printNames(objs)
+	}
+
+	if p.customColumns != nil {
+		return p.printCustomColumns(objs)
+	}
+
+	obj := objs[0]
+	if list {
+		var err error
+		if obj, err = newList(objs); err != nil {
+			return err
+		}
+	}
+
+	if p.template != nil {
+		data, err := toData(obj)
+		if err != nil {
+			return err
+		}
+		return p.template.Execute(p.out, data)
+	}
+
+	printer, err := k8s.NewObjectPrinter(p.format)
+	if err != nil {
+		return err
+	}
+	return printer.PrintObject(obj, p.out)
+}
+
+func (p *OutputPrinter) printNames(objs []runtime.Object) error {
+	for _, obj := range objs {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		resource := strings.ToLower(gvk.Kind)
+		if gvk.Group != "" {
+			resource += "." + gvk.Group
+		}
+
+		if _, err := fmt.Fprintf(p.out, "%s/%s\n", resource, accessor.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *OutputPrinter) printCustomColumns(objs []runtime.Object) error {
+	var headers []string
+	for _, c := range p.customColumns {
+		headers = append(headers, c.header)
+	}
+
+	writer, err := NewTableWriter(p.out, headers...)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		data, err := toData(obj)
+		if err != nil {
+			return err
+		}
+
+		var cells []string
+		for _, c := range p.customColumns {
+			results, err := c.path.FindResults(data)
+			if err != nil {
+				return err
+			}
+
+			var values []string
+			for _, result := range results {
+				for _, v := range result {
+					values = append(values, fmt.Sprint(v.Interface()))
+				}
+			}
+
+			if len(values) == 0 {
+				cells = append(cells, "<none>")
+			} else {
+				cells = append(cells, strings.Join(values, ","))
+			}
+		}
+
+		if err := writer.AddRow(cells...); err != nil {
+			return err
+		}
+	}
+
+	return writer.Write()
+}
+
+func (p *OutputPrinter) setGVK(obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Version != "" && gvk.Kind != "" {
+		return nil
+	}
+
+	gvk, ok := p.typeToGVK[reflect.TypeOf(obj)]
+	if !ok {
+		return errors.Errorf("failed to output. unknown type %q", reflect.TypeOf(obj))
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
+
+func parseJSONPath(name, template string) (*jsonpath.JSONPath, error) {
+	path := jsonpath.New(name).AllowMissingKeys(true)
+	if err := path.Parse(template); err != nil {
+		return nil, errors.Wrapf(err, "invalid jsonpath template %q", template)
+	}
+	return path, nil
+}
+
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	var columns []customColumn
+	for _, column := range strings.Split(spec, ",") {
+		parts := strings.SplitN(column, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("invalid custom-columns spec %q, expected <header>:<json-path>", column)
+		}
+
+		template := parts[1]
+		if !strings.HasPrefix(template, "{") {
+			template = "{" + template + "}"
+		}
+
+		path, err := parseJSONPath(parts[0], template)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, customColumn{header: parts[0], path: path})
+	}
+	return columns, nil
+}
+
+func newList(objs []runtime.Object) (runtime.Object, error) {
+	list := &metav1.List{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: "v1",
+		},
+	}
+
+	for _, obj := range objs {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw})
+	}
+	return list, nil
+}
+
+func toData(obj runtime.Object) (interface{}, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	return data, json.Unmarshal(raw, &data)
+}
@@ -0,0 +1,156 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+)
+
+func TestOutputPrinter(t *testing.T) {
+	spec.Run(t, "TestOutputPrinter", testOutputPrinter)
+}
+
+func testOutputPrinter(t *testing.T, when spec.G, it spec.S) {
+	newImage := func(name, tag string) *v1alpha2.Image {
+		return &v1alpha2.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "some-namespace",
+			},
+			Spec: v1alpha2.ImageSpec{
+				Tag: tag,
+			},
+		}
+	}
+
+	columns := []commands.Column{
+		{Header: "NAME"},
+		{Header: "TAG", Wide: true},
+	}
+
+	row := func(obj runtime.Object) []string {
+		img := obj.(*v1alpha2.Image)
+		return []string{img.Name, img.Spec.Tag}
+	}
+
+	printList := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := &cobra.Command{
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				printer, err := commands.NewOutputPrinter(cmd)
+				if err != nil {
+					return err
+				}
+				return printer.PrintList([]runtime.Object{
+					newImage("image-1", "registry.io/image-1"),
+					newImage("image-2", "registry.io/image-2"),
+				}, columns, row)
+			},
+			SilenceUsage:  true,
+			SilenceErrors: true,
+		}
+		commands.SetOutputFlag(cmd)
+		cmd.SetOut(out)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	it("prints a table without wide columns by default", func() {
+		out, err := printList()
+		require.NoError(t, err)
+		require.Equal(t, `NAME
+image-1
+image-2
+
+`, out)
+	})
+
+	it("prints wide columns with the wide format", func() {
+		out, err := printList("-o", "wide")
+		require.NoError(t, err)
+		require.Equal(t, `NAME       TAG
+image-1    registry.io/image-1
+image-2    registry.io/image-2
+
+`, out)
+	})
+
+	it("prints resource names with the name format", func() {
+		out, err := printList("-o", "name")
+		require.NoError(t, err)
+		require.Equal(t, `image.kpack.io/image-1
+image.kpack.io/image-2
+`, out)
+	})
+
+	it("prints a list with the yaml format", func() {
+		out, err := printList("-o", "yaml")
+		require.NoError(t, err)
+		require.Equal(t, `apiVersion: v1
+items:
+- apiVersion: kpack.io/v1alpha2
+  kind: Image
+  metadata:
+    creationTimestamp: null
+    name: image-1
+    namespace: some-namespace
+  spec:
+    builder: {}
+    source: {}
+    tag: registry.io/image-1
+  status: {}
+- apiVersion: kpack.io/v1alpha2
+  kind: Image
+  metadata:
+    creationTimestamp: null
+    name: image-2
+    namespace: some-namespace
+  spec:
+    builder: {}
+    source: {}
+    tag: registry.io/image-2
+  status: {}
+kind: List
+metadata: {}
+`, out)
+	})
+
+	it("applies the template to the list with the jsonpath format", func() {
+		out, err := printList("-o", "jsonpath={.items[*].spec.tag}")
+		require.NoError(t, err)
+		require.Equal(t, "registry.io/image-1 registry.io/image-2", out)
+	})
+
+	it("prints a table of json paths with the custom-columns format", func() {
+		out, err := printList("-o", "custom-columns=NAME:.metadata.name,NAMESPACE:.metadata.namespace,BUILDER:.spec.builder.name")
+		require.NoError(t, err)
+		require.Equal(t, `NAME       NAMESPACE         BUILDER
+image-1    some-namespace    <none>
+image-2    some-namespace    <none>
+
+`, out)
+	})
+
+	it("errors on an invalid custom-columns spec", func() {
+		_, err := printList("-o", "custom-columns=NAME")
+		require.EqualError(t, err, `invalid custom-columns spec "NAME", expected <header>:<json-path>`)
+	})
+
+	it("errors on an unsupported format", func() {
+		_, err := printList("-o", "table")
+		require.EqualError(t, err, `unsupported output format: "table", supported formats are yaml, json, wide, name, jsonpath=<template>, custom-columns=<spec>`)
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/lifecycle"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func NewGCCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var (
+		keep   int
+		tlsCfg registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete unreferenced images from the default repository",
+		Long: `Delete images uploaded by kp to the default repository that are no longer referenced by the cluster.
+
+Images are considered referenced when their digest is used by a ClusterStack, ClusterStore, ClusterBuilder, Builder or the lifecycle image ConfigMap,
+including the previous lifecycle images that "kp lifecycle rollback" can restore.
+Only images tagged exclusively with the timestamp tags created by kp are deleted.
+Use "--keep" to always keep the most recent images in each repository.
+
+Repositories under the default repository are discovered with the registry catalog API.
+If the registry does not support it, only repositories of referenced images are scanned.
+
+The default repository is read from the "default.repository" key of the "kp-config" ConfigMap within "kpack" namespace.
+You must have credentials to access the registry on your machine.`,
+		Example: `kp registry gc --dry-run
+kp registry gc --keep 3`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			defaultRepo, err := config.NewKpConfigProvider(cs).GetKpConfig(cmd.Context()).DefaultRepository()
+			if err != nil {
+				return err
+			}
+
+			if err = ch.PrintStatus("Collecting images in '%s'...", defaultRepo); err != nil {
+				return err
+			}
+
+			referenced, err := referencedImages(cmd.Context(), cs)
+			if err != nil {
+				return err
+			}
+
+			gc := registry.GarbageCollector{
+				Client: rup.RepositoryClient(tlsCfg),
+				Writer: ch.Writer(),
+				DryRun: ch.IsDryRun(),
+				Keep:   keep,
+			}
+
+			collected, err := gc.Collect(authn.DefaultKeychain, defaultRepo, referenced)
+			if err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Deleted %d unreferenced image(s)", len(collected))
+		},
+	}
+	cmd.Flags().IntVar(&keep, "keep", 0, "number of most recent images to keep in each repository even if unreferenced")
+	cmd.Flags().Bool(commands.DryRunFlag, false, "print the unreferenced images without deleting them")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
+
+func referencedImages(ctx context.Context, cs k8s.ClientSet) ([]string, error) {
+	history, err := lifecycle.GetHistory(ctx, cs.K8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, entry := range history {
+		images = append(images, entry.Image)
+	}
+
+	stacks, err := cs.KpackClient.KpackV1alpha2().ClusterStacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, stack := range stacks.Items {
+		images = append(images,
+			stack.Spec.BuildImage.Image,
+			stack.Spec.RunImage.Image,
+			stack.Status.BuildImage.Image,
+			stack.Status.BuildImage.LatestImage,
+			stack.Status.RunImage.Image,
+			stack.Status.RunImage.LatestImage,
+		)
+	}
+
+	stores, err := cs.KpackClient.KpackV1alpha2().ClusterStores().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range stores.Items {
+		for _, source := range store.Spec.Sources {
+			images = append(images, source.Image)
+		}
+		for _, buildpack := range store.Status.Buildpacks {
+			images = append(images, buildpack.StoreImage.Image)
+		}
+	}
+
+	clusterBuilders, err := cs.KpackClient.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range clusterBuilders.Items {
+		images = append(images, builder.Status.LatestImage, builder.Status.Stack.RunImage)
+	}
+
+	builders, err := cs.KpackClient.KpackV1alpha2().Builders("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range builders.Items {
+		images = append(images, builder.Status.LatestImage, builder.Status.Stack.RunImage)
+	}
+
+	return images, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	registrycmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/registry"
+	registryfakes "github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestGCCommand(t *testing.T) {
+	spec.Run(t, "TestGCCommand", testGCCommand)
+}
+
+func testGCCommand(t *testing.T, when spec.G, it spec.S) {
+	var repositoryClient *registryfakes.RepositoryClient
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+		return registrycmds.NewGCCommand(clientSetProvider, registryfakes.UtilProvider{FakeRepositoryClient: repositoryClient})
+	}
+
+	kpConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository": "default-registry.io/default-repo",
+		},
+	}
+
+	lifecycleImageConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lifecycle-image",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"image": "default-registry.io/default-repo/lifecycle@sha256:lifecycle-current",
+		},
+	}
+
+	stack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-stack",
+		},
+		Spec: v1alpha2.ClusterStackSpec{
+			BuildImage: v1alpha2.ClusterStackSpecImage{Image: "default-registry.io/default-repo/build@sha256:build-current"},
+			RunImage:   v1alpha2.ClusterStackSpecImage{Image: "default-registry.io/default-repo/run@sha256:run-current"},
+		},
+	}
+
+	store := &v1alpha2.ClusterStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-store",
+		},
+		Spec: v1alpha2.ClusterStoreSpec{
+			Sources: []corev1alpha1.StoreImage{
+				{Image: "default-registry.io/default-repo/some-buildpack@sha256:buildpack-current"},
+			},
+		},
+	}
+
+	builder := &v1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-builder",
+		},
+		Status: v1alpha2.BuilderStatus{
+			Stack: corev1alpha1.BuildStack{RunImage: "default-registry.io/default-repo/run@sha256:run-previous"},
+		},
+	}
+
+	it.Before(func() {
+		repositoryClient = registryfakes.NewRepositoryClient()
+		repositoryClient.AddTag("default-registry.io/default-repo/lifecycle", "20210101000000", "sha256:lifecycle-old")
+		repositoryClient.AddTag("default-registry.io/default-repo/lifecycle", "20210201000000", "sha256:lifecycle-current")
+		repositoryClient.AddTag("default-registry.io/default-repo/build", "20210101000000", "sha256:build-old")
+		repositoryClient.AddTag("default-registry.io/default-repo/build", "20210201000000", "sha256:build-current")
+		repositoryClient.AddTag("default-registry.io/default-repo/run", "20210101000000", "sha256:run-old")
+		repositoryClient.AddTag("default-registry.io/default-repo/run", "20210201000000", "sha256:run-previous")
+		repositoryClient.AddTag("default-registry.io/default-repo/run", "20210301000000", "sha256:run-current")
+		repositoryClient.AddTag("default-registry.io/default-repo/some-buildpack", "20210101000000", "sha256:buildpack-current")
+		repositoryClient.AddTag("default-registry.io/default-repo/some-builder", "latest", "sha256:builder")
+	})
+
+	it("deletes images not referenced by the cluster", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				kpConfig,
+				lifecycleImageConfig,
+				stack,
+				store,
+				builder,
+			},
+			ExpectedOutput: `Collecting images in 'default-registry.io/default-repo'...
+	Deleting 'default-registry.io/default-repo/build@sha256:build-old'
+	Deleting 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-old'
+	Deleting 'default-registry.io/default-repo/run@sha256:run-old'
+Deleted 3 unreferenced image(s)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Equal(t, []string{
+			"default-registry.io/default-repo/build@sha256:build-old",
+			"default-registry.io/default-repo/lifecycle@sha256:lifecycle-old",
+			"default-registry.io/default-repo/run@sha256:run-old",
+		}, repositoryClient.DeletedRefs())
+	})
+
+	it("keeps the lifecycle images of the lifecycle history", func() {
+		lifecycleImageConfig := lifecycleImageConfig.DeepCopy()
+		lifecycleImageConfig.Annotations = map[string]string{
+			"kpack.io/lifecycle-history": `[{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-current","changedAt":"2021-02-01"},{"image":"default-registry.io/default-repo/lifecycle@sha256:lifecycle-old","changedAt":"2021-01-01"}]`,
+		}
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				kpConfig,
+				lifecycleImageConfig,
+				stack,
+				store,
+				builder,
+			},
+			ExpectedOutput: `Collecting images in 'default-registry.io/default-repo'...
+	Deleting 'default-registry.io/default-repo/build@sha256:build-old'
+	Deleting 'default-registry.io/default-repo/run@sha256:run-old'
+Deleted 2 unreferenced image(s)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("keeps the most recent images with --keep", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				kpConfig,
+				lifecycleImageConfig,
+				stack,
+				store,
+				builder,
+			},
+			Args: []string{"--keep", "2"},
+			ExpectedOutput: `Collecting images in 'default-registry.io/default-repo'...
+	Deleting 'default-registry.io/default-repo/run@sha256:run-old'
+Deleted 1 unreferenced image(s)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("does not delete images with --dry-run", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				kpConfig,
+				lifecycleImageConfig,
+				stack,
+				store,
+			},
+			Args: []string{"--dry-run"},
+			ExpectedOutput: `Collecting images in 'default-registry.io/default-repo'... (dry run)
+	Skipping delete of 'default-registry.io/default-repo/build@sha256:build-old'
+	Skipping delete of 'default-registry.io/default-repo/lifecycle@sha256:lifecycle-old'
+	Skipping delete of 'default-registry.io/default-repo/run@sha256:run-previous'
+	Skipping delete of 'default-registry.io/default-repo/run@sha256:run-old'
+Deleted 4 unreferenced image(s) (dry run)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Empty(t, repositoryClient.DeletedRefs())
+	})
+
+	it("errors when the default repository is not set", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				lifecycleImageConfig,
+			},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: failed to get default repository: use \"kp config default-repository\" to set\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
@@ -35,6 +35,18 @@ The flags for this command determine the type of secret that will be created:
   "--gcr" to create Google Container Registry credentials.
   Alternatively, provided the credentials in the "GCR_SERVICE_ACCOUNT_PATH" env var instead of the "--gcr" flag.
 
+  "--ecr" to create Amazon Elastic Container Registry credentials.
+  Use the "ECR_PASSWORD" env var to bypass the password prompt (eg. ECR_PASSWORD=$(aws ecr get-login-password)).
+  ECR passwords expire after 12 hours, use "kp secret patch" to rotate them.
+
+  "--acr" and "--registry-user" to create Azure Container Registry service principal credentials.
+  Use the "REGISTRY_PASSWORD" env var to bypass the password prompt.
+
+  "--gar" and "--gar-service-account" to create Google Artifact Registry credentials.
+  Alternatively, provided the credentials in the "GAR_SERVICE_ACCOUNT_PATH" env var instead of the "--gar-service-account" flag.
+
+  "--docker-config" to copy the credentials of a registry from the local docker config or its credential helper.
+
   "--registry" and "--registry-user" to create credentials for other registries.
   Use the "REGISTRY_PASSWORD" env var to bypass the password prompt.
 
@@ -48,6 +60,10 @@ The flags for this command determine the type of secret that will be created:
 		Example: `kp secret create my-docker-hub-creds --dockerhub dockerhub-id
 kp secret create my-gcr-creds --gcr /path/to/gcr/service-account.json
 kp secret create my-registry-cred --registry example-registry.io --registry-user my-registry-user
+kp secret create my-ecr-creds --ecr 123456789012.dkr.ecr.us-east-1.amazonaws.com
+kp secret create my-acr-creds --acr my-registry.azurecr.io --registry-user my-service-principal-id
+kp secret create my-gar-creds --gar us-docker.pkg.dev --gar-service-account /path/to/gar/service-account.json
+kp secret create my-docker-config-creds --docker-config example-registry.io
 kp secret create my-git-ssh-cred --git-url git@github.com --git-ssh-key /path/to/git/ssh-private-key.pem
 kp secret create my-git-cred --git-url https://github.com --git-user my-git-user`,
 		Args:         commands.ExactArgsWithUsage(1),
@@ -67,6 +83,10 @@ kp secret create my-git-cred --git-url https://github.com --git-user my-git-user
 				secretFactory.GcrServiceAccountFile = val
 			}
 
+			if val, ok := os.LookupEnv("GAR_SERVICE_ACCOUNT_PATH"); ok {
+				secretFactory.GarServiceAccountFile = val
+			}
+
 			if val, ok := os.LookupEnv("GIT_SSH_KEY_PATH"); ok {
 				secretFactory.GitSshKeyFile = val
 			}
@@ -124,6 +144,11 @@ kp secret create my-git-cred --git-url https://github.com --git-user my-git-user
 	cmd.Flags().StringVarP(&secretFactory.Registry, "registry", "", "", "registry")
 	cmd.Flags().StringVarP(&secretFactory.RegistryUser, "registry-user", "", "", "registry user")
 	cmd.Flags().StringVarP(&secretFactory.GcrServiceAccountFile, "gcr", "", "", "path to a file containing the GCR service account")
+	cmd.Flags().StringVarP(&secretFactory.EcrRegistry, "ecr", "", "", "amazon elastic container registry")
+	cmd.Flags().StringVarP(&secretFactory.AcrRegistry, "acr", "", "", "azure container registry")
+	cmd.Flags().StringVarP(&secretFactory.GarRegistry, "gar", "", "", "google artifact registry")
+	cmd.Flags().StringVarP(&secretFactory.GarServiceAccountFile, "gar-service-account", "", "", "path to a file containing the GAR service account")
+	cmd.Flags().StringVarP(&secretFactory.DockerConfigRegistry, "docker-config", "", "", "registry to copy credentials for from the docker config")
 	cmd.Flags().StringVarP(&secretFactory.GitUrl, "git-url", "", "", "git url")
 	cmd.Flags().StringVarP(&secretFactory.GitSshKeyFile, "git-ssh-key", "", "", "path to a file containing the GitUrl SSH private key")
 	cmd.Flags().StringVarP(&secretFactory.GitUser, "git-user", "", "", "git user")
@@ -116,6 +116,62 @@ func testSecretCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("creating an ecr secret", func() {
+			var (
+				registry          = "123456789012.dkr.ecr.us-east-1.amazonaws.com"
+				secretName        = "my-ecr-cred"
+				expectedEcrConfig = fmt.Sprintf("{\"auths\":{\"%s\":{\"username\":\"AWS\",\"password\":\"some-ecr-token\"}}}", registry)
+			)
+
+			fetcher.passwords["ECR_PASSWORD"] = "some-ecr-token"
+
+			it("creates a registry secret for the AWS user and updates the service account", func() {
+				expectedDockerSecret := &corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      secretName,
+						Namespace: namespace,
+					},
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte(expectedEcrConfig),
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+				}
+
+				expectedServiceAccount := &corev1.ServiceAccount{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "default",
+						Namespace: namespace,
+						Annotations: map[string]string{
+							secretcmds.ManagedSecretAnnotationKey: fmt.Sprintf(`{"%s":"%s"}`, secretName, registry),
+						},
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{
+						{Name: secretName},
+					},
+					Secrets: []corev1.ObjectReference{
+						{Name: secretName},
+					},
+				}
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						defaultNamespacedServiceAccount,
+					},
+					Args: []string{secretName, "--ecr", registry, "-n", namespace},
+					ExpectedOutput: `Secret "my-ecr-cred" created
+`,
+					ExpectCreates: []runtime.Object{
+						expectedDockerSecret,
+					},
+					ExpectUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: expectedServiceAccount,
+						},
+					},
+				}.TestK8s(t, cmdFunc)
+			})
+		})
+
 		when("creating a generic registry secret", func() {
 			var (
 				registry               = "my-registry.io"
@@ -9,7 +9,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
@@ -29,6 +31,11 @@ The namespace defaults to the kubernetes current-context namespace.`,
 		Example:      "kp secret list\nkp secret list -n my-namespace",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := commands.NewOutputPrinter(cmd)
+			if err != nil {
+				return err
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
@@ -42,17 +49,18 @@ The namespace defaults to the kubernetes current-context namespace.`,
 			if len(serviceAccount.Secrets) == 0 && len(serviceAccount.ImagePullSecrets) == 0 {
 				return errors.Errorf("no secrets found in %q namespace", cs.Namespace)
 			} else {
-				return displaySecretsTable(cmd, serviceAccount)
+				return displaySecretsTable(cmd, printer, cs, serviceAccount)
 			}
 		},
 	}
 
 	command.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetOutputFlag(&command)
 
 	return &command
 }
 
-func displaySecretsTable(cmd *cobra.Command, sa *corev1.ServiceAccount) error {
+func displaySecretsTable(cmd *cobra.Command, printer *commands.OutputPrinter, cs k8s.ClientSet, sa *corev1.ServiceAccount) error {
 	managedSecrets, err := readManagedSecrets(sa)
 	if err != nil {
 		return err
@@ -72,17 +80,46 @@ func displaySecretsTable(cmd *cobra.Command, sa *corev1.ServiceAccount) error {
 	}
 	sort.Strings(secretNames)
 
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "TARGET")
-	if err != nil {
-		return err
-	}
+	// only the wide table and the output formats read the secrets, for their type and metadata
+	readSecrets := printer.IsWide() || !printer.IsTable()
 
+	var objs []runtime.Object
 	for _, name := range secretNames {
-		err := writer.AddRow(name, managedSecrets[name])
-		if err != nil {
-			return err
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sa.Namespace}}
+		if readSecrets {
+			s, err := cs.K8sClient.CoreV1().Secrets(sa.Namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+			if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsForbidden(err) {
+				return err
+			} else if err == nil {
+				secret = withoutData(s)
+			}
 		}
+		objs = append(objs, secret)
 	}
 
-	return writer.Write()
+	return printer.PrintList(objs, []commands.Column{
+		{Header: "NAME"},
+		{Header: "TARGET"},
+		{Header: "TYPE", Wide: true},
+	}, func(obj runtime.Object) []string {
+		secret := obj.(*corev1.Secret)
+		return []string{secret.Name, managedSecrets[secret.Name], string(secret.Type)}
+	})
+}
+
+// withoutData returns the secret without its data, so credentials are never printed. The last applied
+// configuration and the managed fields are removed too, as they can hold a copy of the data.
+func withoutData(secret *corev1.Secret) *corev1.Secret {
+	meta := secret.ObjectMeta.DeepCopy()
+	delete(meta.Annotations, corev1.LastAppliedConfigAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	meta.ManagedFields = nil
+
+	return &corev1.Secret{
+		TypeMeta:   secret.TypeMeta,
+		ObjectMeta: *meta,
+		Type:       secret.Type,
+	}
 }
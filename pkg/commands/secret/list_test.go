@@ -166,4 +166,106 @@ secret-two      some-git-url
 			})
 		})
 	})
+
+	when("an output format is used", func() {
+		serviceAccount := &corev1.ServiceAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "default",
+				Namespace: defaultNamespace,
+			},
+			Secrets: []corev1.ObjectReference{
+				{
+					Name: "registry-secret",
+				},
+			},
+		}
+
+		registrySecret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "registry-secret",
+				Namespace: defaultNamespace,
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"some-registry.io":{"password":"some-password"}}}`),
+			},
+			StringData: map[string]string{
+				"password": "some-password",
+			},
+		}
+
+		it("does not print the secret data", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					serviceAccount,
+					registrySecret,
+				},
+				Args: []string{"-o", "yaml"},
+				ExpectedOutput: `apiVersion: v1
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    creationTimestamp: null
+    name: registry-secret
+    namespace: some-default-namespace
+  type: kubernetes.io/dockerconfigjson
+kind: List
+metadata: {}
+`,
+			}.TestK8s(t, cmdFunc)
+		})
+
+		it("does not print the last applied configuration or managed fields of the secret", func() {
+			appliedSecret := registrySecret.DeepCopy()
+			appliedSecret.Annotations = map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"apiVersion":"v1","data":{".dockerconfigjson":"eyJhdXRocyI6e319"},"kind":"Secret"}`,
+				"some-annotation":                  "some-value",
+			}
+			appliedSecret.ManagedFields = []v1.ManagedFieldsEntry{
+				{
+					Manager:    "kubectl-client-side-apply",
+					Operation:  v1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1:   &v1.FieldsV1{Raw: []byte(`{"f:data":{".":{},"f:.dockerconfigjson":{}}}`)},
+				},
+			}
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					serviceAccount,
+					appliedSecret,
+				},
+				Args: []string{"-o", "yaml"},
+				ExpectedOutput: `apiVersion: v1
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    annotations:
+      some-annotation: some-value
+    creationTimestamp: null
+    name: registry-secret
+    namespace: some-default-namespace
+  type: kubernetes.io/dockerconfigjson
+kind: List
+metadata: {}
+`,
+			}.TestK8s(t, cmdFunc)
+		})
+
+		it("prints the secret type with the wide output format", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					serviceAccount,
+					registrySecret,
+				},
+				Args: []string{"-o", "wide"},
+				ExpectedOutput: `NAME               TARGET    TYPE
+registry-secret              kubernetes.io/dockerconfigjson
+
+`,
+			}.TestK8s(t, cmdFunc)
+		})
+	})
 }
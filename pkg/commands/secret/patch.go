@@ -0,0 +1,114 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+)
+
+func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, secretFactory *secret.Factory) *cobra.Command {
+	var (
+		namespace string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "patch <name>",
+		Short: "Rotate the credentials of a secret",
+		Long: `Replace the password or key of an existing registry or git secret in the provided namespace.
+
+The registry or git url of the secret cannot be changed. Service accounts that reference the secret are not modified.
+
+The namespace defaults to the kubernetes current-context namespace.
+
+The credentials are read the same way as when the secret was created:
+
+  DockerHub and other registry secrets prompt for a new password.
+  Use the "DOCKER_PASSWORD" or "REGISTRY_PASSWORD" env var to bypass the password prompt.
+  The user can be changed with "--registry-user".
+
+  ECR secrets prompt for a new password.
+  Use the "ECR_PASSWORD" env var to bypass the password prompt.
+
+  Google Container Registry secrets require "--gcr" or the "GCR_SERVICE_ACCOUNT_PATH" env var.
+
+  Google Artifact Registry secrets require "--gar-service-account" or the "GAR_SERVICE_ACCOUNT_PATH" env var.
+
+  SSH based git secrets require "--git-ssh-key" or the "GIT_SSH_KEY_PATH" env var.
+
+  Basic Auth based git secrets prompt for a new password.
+  Use the "GIT_PASSWORD" env var to bypass the password prompt.
+  The user can be changed with "--git-user".`,
+		Example: `kp secret patch my-docker-hub-creds
+kp secret patch my-registry-cred --registry-user my-new-registry-user
+kp secret patch my-gcr-creds --gcr /path/to/gcr/service-account.json
+ECR_PASSWORD=$(aws ecr get-login-password) kp secret patch my-ecr-creds
+kp secret patch my-git-ssh-cred --git-ssh-key /path/to/git/ssh-private-key.pem`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			if val, ok := os.LookupEnv("GCR_SERVICE_ACCOUNT_PATH"); ok {
+				secretFactory.GcrServiceAccountFile = val
+			}
+
+			if val, ok := os.LookupEnv("GAR_SERVICE_ACCOUNT_PATH"); ok {
+				secretFactory.GarServiceAccountFile = val
+			}
+
+			if val, ok := os.LookupEnv("GIT_SSH_KEY_PATH"); ok {
+				secretFactory.GitSshKeyFile = val
+			}
+
+			ctx := cmd.Context()
+
+			existing, err := cs.K8sClient.CoreV1().Secrets(cs.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			s, err := secretFactory.RotateSecret(existing)
+			if err != nil {
+				return err
+			}
+
+			if !ch.IsDryRun() {
+				s, err = cs.K8sClient.CoreV1().Secrets(cs.Namespace).Update(ctx, s, metav1.UpdateOptions{})
+				if err != nil {
+					return err
+				}
+			}
+
+			if err = ch.PrintObj(s); err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Secret %q patched", s.Name)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().StringVarP(&secretFactory.RegistryUser, "registry-user", "", "", "new registry user, defaults to the current user")
+	cmd.Flags().StringVarP(&secretFactory.GcrServiceAccountFile, "gcr", "", "", "path to a file containing the GCR service account")
+	cmd.Flags().StringVarP(&secretFactory.GarServiceAccountFile, "gar-service-account", "", "", "path to a file containing the GAR service account")
+	cmd.Flags().StringVarP(&secretFactory.GitSshKeyFile, "git-ssh-key", "", "", "path to a file containing the GitUrl SSH private key")
+	cmd.Flags().StringVarP(&secretFactory.GitUser, "git-user", "", "", "new git user, defaults to the current user")
+	commands.SetDryRunOutputFlags(cmd)
+	return cmd
+}
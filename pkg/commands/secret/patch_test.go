@@ -0,0 +1,246 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	secretcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/secret"
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestSecretPatchCommand(t *testing.T) {
+	spec.Run(t, "TestSecretPatchCommand", testSecretPatchCommand)
+}
+
+func testSecretPatchCommand(t *testing.T, when spec.G, it spec.S) {
+	const defaultNamespace = "some-default-namespace"
+
+	fetcher := &fakeCredentialFetcher{
+		passwords: map[string]string{
+			"REGISTRY_PASSWORD": "new-password",
+			"GIT_PASSWORD":      "new-git-password",
+			"ECR_PASSWORD":      "new-ecr-token",
+		},
+	}
+
+	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sProvider(k8sClient, defaultNamespace)
+		return secretcmds.NewPatchCommand(clientSetProvider, &secret.Factory{CredentialFetcher: fetcher})
+	}
+
+	registrySecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-registry-cred",
+			Namespace: defaultNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"my-registry.io":{"username":"my-registry-user","password":"old-password"}}}`),
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}
+
+	gitSshSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "my-git-ssh-cred",
+			Namespace:   defaultNamespace,
+			Annotations: map[string]string{secret.GitAnnotation: "git@github.com"},
+		},
+		Data: map[string][]byte{
+			corev1.SSHAuthPrivateKey: []byte("old-key"),
+		},
+		Type: corev1.SecretTypeSSHAuth,
+	}
+
+	gitBasicAuthSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "my-git-cred",
+			Namespace:   defaultNamespace,
+			Annotations: map[string]string{secret.GitAnnotation: "https://github.com"},
+		},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("my-git-user"),
+			corev1.BasicAuthPasswordKey: []byte("old-git-password"),
+		},
+		Type: corev1.SecretTypeBasicAuth,
+	}
+
+	it("rotates the password of a registry secret for the same user", func() {
+		expectedSecret := registrySecret.DeepCopy()
+		expectedSecret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"my-registry.io":{"username":"my-registry-user","password":"new-password"}}}`)
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{registrySecret},
+			Args:    []string{"my-registry-cred"},
+			ExpectedOutput: `Secret "my-registry-cred" patched
+`,
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedSecret,
+				},
+			},
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("changes the user of a registry secret", func() {
+		expectedSecret := registrySecret.DeepCopy()
+		expectedSecret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"my-registry.io":{"username":"my-new-user","password":"new-password"}}}`)
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{registrySecret},
+			Args:    []string{"my-registry-cred", "--registry-user", "my-new-user"},
+			ExpectedOutput: `Secret "my-registry-cred" patched
+`,
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedSecret,
+				},
+			},
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("rotates the token of an ecr secret", func() {
+		ecrSecret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-ecr-cred",
+				Namespace: defaultNamespace,
+			},
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"123456789012.dkr.ecr.us-east-1.amazonaws.com":{"username":"AWS","password":"old-ecr-token"}}}`),
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+		}
+		expectedSecret := ecrSecret.DeepCopy()
+		expectedSecret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"123456789012.dkr.ecr.us-east-1.amazonaws.com":{"username":"AWS","password":"new-ecr-token"}}}`)
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{ecrSecret},
+			Args:    []string{"my-ecr-cred"},
+			ExpectedOutput: `Secret "my-ecr-cred" patched
+`,
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedSecret,
+				},
+			},
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("rotates the key of a regional gcr secret as a registry password", func() {
+		gcrSecret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-gcr-cred",
+				Namespace: defaultNamespace,
+			},
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"us.gcr.io":{"username":"_json_key","password":"old-key"}}}`),
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+		}
+		expectedSecret := gcrSecret.DeepCopy()
+		expectedSecret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"us.gcr.io":{"username":"_json_key","password":"new-password"}}}`)
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{gcrSecret},
+			Args:    []string{"my-gcr-cred"},
+			ExpectedOutput: `Secret "my-gcr-cred" patched
+`,
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedSecret,
+				},
+			},
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("requires a new service account key for a gar secret", func() {
+		garSecret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-gar-cred",
+				Namespace: defaultNamespace,
+			},
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"us-central1-docker.pkg.dev":{"username":"_json_key","password":"old-key"}}}`),
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+		}
+
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{garSecret},
+			Args:                []string{"my-gar-cred"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: missing parameter gar-service-account\n",
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("rotates the key of a git ssh secret", func() {
+		expectedSecret := gitSshSecret.DeepCopy()
+		expectedSecret.Data[corev1.SSHAuthPrivateKey] = []byte("some git ssh key")
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{gitSshSecret},
+			Args:    []string{"my-git-ssh-cred", "--git-ssh-key", "./testdata/git-ssh.pem"},
+			ExpectedOutput: `Secret "my-git-ssh-cred" patched
+`,
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedSecret,
+				},
+			},
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("rotates the password of a git basic auth secret", func() {
+		expectedSecret := gitBasicAuthSecret.DeepCopy()
+		expectedSecret.Data[corev1.BasicAuthPasswordKey] = []byte("new-git-password")
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{gitBasicAuthSecret},
+			Args:    []string{"my-git-cred"},
+			ExpectedOutput: `Secret "my-git-cred" patched
+`,
+			ExpectUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: expectedSecret,
+				},
+			},
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("does not update the secret with --dry-run", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{registrySecret},
+			Args:    []string{"my-registry-cred", "--dry-run"},
+			ExpectedOutput: `Secret "my-registry-cred" patched (dry run)
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("requires a new key for a git ssh secret", func() {
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{gitSshSecret},
+			Args:                []string{"my-git-ssh-cred"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: missing parameter git-ssh-key\n",
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("rejects flags of another kind of secret", func() {
+		testhelpers.CommandTest{
+			Objects:             []runtime.Object{registrySecret},
+			Args:                []string{"my-registry-cred", "--git-user", "my-git-user"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: extraneous parameters: git-user\n",
+		}.TestK8s(t, cmdFunc)
+	})
+}
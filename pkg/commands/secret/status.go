@@ -0,0 +1,132 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+)
+
+func NewStatusCommand(clientSetProvider k8s.ClientSetProvider, validator secret.CredentialValidator) *cobra.Command {
+	var (
+		namespace string
+		validate  bool
+		gitRepo   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status <name>",
+		Short: "Display secret status",
+		Long: `Prints the type, registry or git url and user of a secret in the provided namespace,
+and the service accounts that reference it.
+
+The namespace defaults to the kubernetes current-context namespace.
+
+The "--validate" flag logs in to the registry or git host with the credentials of the secret and fails if the login fails.
+Git basic auth credentials are validated against a repository on the git url provided with "--git-repo".
+The host key of git ssh hosts is verified with "~/.ssh/known_hosts", and is not verified without it.`,
+		Example: `kp secret status my-registry-cred
+kp secret status my-registry-cred --validate
+kp secret status my-git-cred --validate --git-repo my-org/my-repo`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			s, err := cs.K8sClient.CoreV1().Secrets(cs.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			creds, err := secret.ReadCredentials(s)
+			if err != nil {
+				return err
+			}
+
+			serviceAccounts, err := cs.K8sClient.CoreV1().ServiceAccounts(cs.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			statusWriter := commands.NewStatusWriter(cmd.OutOrStdout())
+
+			items := []string{
+				"Name", s.Name,
+				"Type", string(s.Type),
+			}
+			if creds.Registry != "" {
+				items = append(items, "Registry", creds.Registry)
+			} else {
+				items = append(items, "Git URL", creds.GitUrl)
+			}
+			items = append(items,
+				"User", creds.Username,
+				"Service Accounts", strings.Join(referencingServiceAccounts(serviceAccounts.Items, s.Name), ", "),
+			)
+
+			var validationErr error
+			if validate {
+				validationErr = validator.Validate(ctx, cmd.ErrOrStderr(), creds, gitRepo)
+				if validationErr != nil {
+					items = append(items, "Validation", "failed: "+validationErr.Error())
+				} else {
+					items = append(items, "Validation", "succeeded")
+				}
+			}
+
+			if err := statusWriter.AddBlock("", items...); err != nil {
+				return err
+			}
+
+			if err := statusWriter.Write(); err != nil {
+				return err
+			}
+
+			return validationErr
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().BoolVar(&validate, "validate", false, "log in to the registry or git host to check the credentials")
+	cmd.Flags().StringVar(&gitRepo, "git-repo", "", "repository path on the git url to validate git basic auth credentials against")
+	return cmd
+}
+
+func referencingServiceAccounts(serviceAccounts []corev1.ServiceAccount, name string) []string {
+	var names []string
+	for _, sa := range serviceAccounts {
+		if referencesSecret(sa, name) {
+			names = append(names, sa.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func referencesSecret(sa corev1.ServiceAccount, name string) bool {
+	for _, ref := range sa.Secrets {
+		if ref.Name == name {
+			return true
+		}
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
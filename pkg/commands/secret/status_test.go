@@ -0,0 +1,174 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	secretcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/secret"
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+	"github.com/vmware-tanzu/kpack-cli/pkg/testhelpers"
+)
+
+func TestSecretStatusCommand(t *testing.T) {
+	spec.Run(t, "TestSecretStatusCommand", testSecretStatusCommand)
+}
+
+type fakeCredentialValidator struct {
+	err     error
+	creds   secret.Credentials
+	gitRepo string
+}
+
+func (f *fakeCredentialValidator) Validate(_ context.Context, _ io.Writer, creds secret.Credentials, gitRepo string) error {
+	f.creds = creds
+	f.gitRepo = gitRepo
+	return f.err
+}
+
+func testSecretStatusCommand(t *testing.T, when spec.G, it spec.S) {
+	const defaultNamespace = "some-default-namespace"
+
+	validator := &fakeCredentialValidator{}
+
+	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sProvider(k8sClient, defaultNamespace)
+		return secretcmds.NewStatusCommand(clientSetProvider, validator)
+	}
+
+	registrySecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-registry-cred",
+			Namespace: defaultNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"my-registry.io":{"username":"my-registry-user","password":"some-password"}}}`),
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}
+
+	gitBasicAuthSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "my-git-cred",
+			Namespace:   defaultNamespace,
+			Annotations: map[string]string{secret.GitAnnotation: "https://github.com"},
+		},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("my-git-user"),
+			corev1.BasicAuthPasswordKey: []byte("some-git-password"),
+		},
+		Type: corev1.SecretTypeBasicAuth,
+	}
+
+	serviceAccounts := []runtime.Object{
+		&corev1.ServiceAccount{
+			ObjectMeta:       v1.ObjectMeta{Name: "default", Namespace: defaultNamespace},
+			Secrets:          []corev1.ObjectReference{{Name: "my-registry-cred"}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "my-registry-cred"}},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta:       v1.ObjectMeta{Name: "builder", Namespace: defaultNamespace},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "my-registry-cred"}},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta: v1.ObjectMeta{Name: "other", Namespace: defaultNamespace},
+		},
+	}
+
+	it.Before(func() {
+		validator.err = nil
+	})
+
+	it("displays the registry, user and referencing service accounts of a registry secret", func() {
+		testhelpers.CommandTest{
+			Objects: append([]runtime.Object{registrySecret}, serviceAccounts...),
+			Args:    []string{"my-registry-cred"},
+			ExpectedOutput: `Name:                my-registry-cred
+Type:                kubernetes.io/dockerconfigjson
+Registry:            my-registry.io
+User:                my-registry-user
+Service Accounts:    builder, default
+
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("displays the git url of a git secret", func() {
+		testhelpers.CommandTest{
+			Objects: append([]runtime.Object{gitBasicAuthSecret}, serviceAccounts...),
+			Args:    []string{"my-git-cred"},
+			ExpectedOutput: `Name:                my-git-cred
+Type:                kubernetes.io/basic-auth
+Git URL:             https://github.com
+User:                my-git-user
+Service Accounts:    --
+
+`,
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("validates the credentials with --validate", func() {
+		testhelpers.CommandTest{
+			Objects: append([]runtime.Object{gitBasicAuthSecret}, serviceAccounts...),
+			Args:    []string{"my-git-cred", "--validate", "--git-repo", "my-org/my-repo"},
+			ExpectedOutput: `Name:                my-git-cred
+Type:                kubernetes.io/basic-auth
+Git URL:             https://github.com
+User:                my-git-user
+Service Accounts:    --
+Validation:          succeeded
+
+`,
+		}.TestK8s(t, cmdFunc)
+
+		require.Equal(t, secret.Credentials{
+			GitUrl:   "https://github.com",
+			Username: "my-git-user",
+			Password: "some-git-password",
+		}, validator.creds)
+		require.Equal(t, "my-org/my-repo", validator.gitRepo)
+	})
+
+	it("fails when the credentials are invalid", func() {
+		validator.err = errors.New("failed to log in to my-registry.io: 401 Unauthorized")
+
+		testhelpers.CommandTest{
+			Objects:   append([]runtime.Object{registrySecret}, serviceAccounts...),
+			Args:      []string{"my-registry-cred", "--validate"},
+			ExpectErr: true,
+			ExpectedOutput: `Name:                my-registry-cred
+Type:                kubernetes.io/dockerconfigjson
+Registry:            my-registry.io
+User:                my-registry-user
+Service Accounts:    builder, default
+Validation:          failed: failed to log in to my-registry.io: 401 Unauthorized
+
+`,
+			ExpectedErrorOutput: "Error: failed to log in to my-registry.io: 401 Unauthorized\n",
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("returns an error for secrets that were not created for a registry or git", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{Name: "some-opaque-secret", Namespace: defaultNamespace},
+				Type:       corev1.SecretTypeOpaque,
+			}},
+			Args:                []string{"some-opaque-secret"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: secret \"some-opaque-secret\" has unsupported type \"Opaque\"\n",
+		}.TestK8s(t, cmdFunc)
+	})
+}
@@ -6,6 +6,8 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -23,26 +25,42 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
-const defaultWaitTimeout = 10 * time.Minute
+const (
+	WaitTimeoutFlag    = "wait-timeout"
+	DefaultWaitTimeout = 10 * time.Minute
+
+	// DefaultProgressInterval is how often the state of a resource is printed while waiting on it.
+	DefaultProgressInterval = 10 * time.Second
+)
 
 type ResourceWaiter interface {
 	Wait(ctx context.Context, object runtime.Object, extraChecks ...watchTools.ConditionFunc) error
 }
 
 func NewResourceWaiter(dc dynamic.Interface) ResourceWaiter {
-	return NewWaiter(dc, defaultWaitTimeout)
+	return NewWaiter(dc, DefaultWaitTimeout)
 }
 
 type Waiter struct {
-	dynamicClient dynamic.Interface
-	timeout       time.Duration
-	ctx           context.Context
+	dynamicClient    dynamic.Interface
+	timeout          time.Duration
+	progress         io.Writer
+	progressInterval time.Duration
+	progressMutex    sync.Mutex
 }
 
 func NewWaiter(dc dynamic.Interface, timeout time.Duration) *Waiter {
 	return &Waiter{dynamicClient: dc, timeout: timeout}
 }
 
+// WithProgress makes the waiter print the observed generation and ready condition of each resource it waits on
+// to the writer every interval. A waiter can wait on several resources concurrently.
+func (w *Waiter) WithProgress(writer io.Writer, interval time.Duration) *Waiter {
+	w.progress = writer
+	w.progressInterval = interval
+	return w
+}
+
 func (w *Waiter) Wait(ctx context.Context, ob runtime.Object, extraConditions ...watchTools.ConditionFunc) error {
 	m, ok := ob.(kmeta.OwnerRefable)
 	if !ok {
@@ -65,13 +83,22 @@ func (w *Waiter) wait(ctx context.Context, ob runtime.Object, condition watchToo
 			return errors.New("unexpected type")
 		}
 
+		waitCtx, cancel := context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+
 		rv := refable.GetObjectMeta().GetResourceVersion()
-		watchOne := newWatchOneWatcher(ctx, refable, w.dynamicClient)
+		watchOne := newWatchOneWatcher(waitCtx, refable, w.dynamicClient)
 
-		ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
-		defer cancel()
-		e, err = watchTools.Until(ctx, rv, watchOne, filterErrors(cfs)...)
+		progress := w.startProgress(waitCtx, refable, e)
+		e, err = watchTools.Until(waitCtx, rv, watchOne, progress.track(filterErrors(cfs))...)
+		progress.stop()
 		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+				return errors.Errorf("timed out after %s waiting for %s %q", w.timeout, refable.GetGroupVersionKind().Kind, refable.GetObjectMeta().GetName())
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			return err
 		}
 	}
@@ -91,6 +118,96 @@ func (w *Waiter) wait(ctx context.Context, ob runtime.Object, condition watchToo
 	return nil
 }
 
+// waitProgress holds the latest event seen for a resource and prints it periodically until stopped.
+type waitProgress struct {
+	mutex  sync.Mutex
+	latest watch.Event
+	done   chan struct{}
+	ended  chan struct{}
+}
+
+func (w *Waiter) startProgress(ctx context.Context, object kmeta.OwnerRefable, initial *watch.Event) *waitProgress {
+	p := &waitProgress{latest: *initial, done: make(chan struct{}), ended: make(chan struct{})}
+	if w.progress == nil || w.progressInterval <= 0 {
+		close(p.ended)
+		return p
+	}
+
+	kind := object.GetGroupVersionKind().Kind
+	name := object.GetObjectMeta().GetName()
+	generation := object.GetObjectMeta().GetGeneration()
+
+	go func() {
+		defer close(p.ended)
+
+		ticker := time.NewTicker(w.progressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.done:
+				return
+			case <-ticker.C:
+				w.printProgress(kind, name, generation, p.event())
+			}
+		}
+	}()
+	return p
+}
+
+func (p *waitProgress) event() watch.Event {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.latest
+}
+
+// track records every event the conditions are checked against. Conditions that are met are not checked again,
+// so each condition records the events it sees.
+func (p *waitProgress) track(conditions []watchTools.ConditionFunc) []watchTools.ConditionFunc {
+	cfs := []watchTools.ConditionFunc{}
+	for _, c := range conditions {
+		c := c
+		cfs = append(cfs, func(event watch.Event) (bool, error) {
+			if event.Type != watch.Error {
+				p.mutex.Lock()
+				p.latest = event
+				p.mutex.Unlock()
+			}
+			return c(event)
+		})
+	}
+	return cfs
+}
+
+func (p *waitProgress) stop() {
+	close(p.done)
+	<-p.ended
+}
+
+func (w *Waiter) printProgress(kind, name string, generation int64, e watch.Event) {
+	resource, err := eventToDuck(&e)
+	if err != nil {
+		return
+	}
+
+	line := fmt.Sprintf("Waiting for %s %q: observed generation %d of %d", kind, name, resource.Status.ObservedGeneration, generation)
+	if cond := resource.Status.GetCondition(apis.ConditionReady); cond != nil {
+		line += fmt.Sprintf(", %s %s", cond.Type, cond.Status)
+		if cond.Reason != "" {
+			line += ": " + cond.Reason
+		}
+		if cond.Message != "" {
+			line += ": " + cond.Message
+		}
+	}
+
+	w.progressMutex.Lock()
+	defer w.progressMutex.Unlock()
+	_, _ = fmt.Fprintln(w.progress, line)
+}
+
 func runChecks(e watch.Event, cfs []watchTools.ConditionFunc) (bool, error) {
 	for _, cf := range cfs {
 		done, err := cf(e)
@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -111,6 +112,59 @@ func testWaiter(t *testing.T, when spec.G, it spec.S) {
 			require.NoError(t, waiter.Wait(context.Background(), resourceToWatch, fakeConditionChecker.conditionCheck))
 			require.True(t, fakeConditionChecker.called)
 		})
+
+		it("returns an error when the resource does not resolve before the timeout", func() {
+			resourceToWatch.Status = v1alpha2.BuilderStatus{
+				Status: conditionReady(corev1.ConditionFalse, generation-1),
+			}
+
+			waiter := NewWaiter(dynamicClient, 50*time.Millisecond)
+			require.EqualError(t, waiter.Wait(context.Background(), resourceToWatch), `timed out after 50ms waiting for Builder "some-name"`)
+		})
+
+		it("stops waiting when the context is cancelled", func() {
+			resourceToWatch.Status = v1alpha2.BuilderStatus{
+				Status: conditionReady(corev1.ConditionFalse, generation-1),
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			require.EqualError(t, waiter.Wait(ctx, resourceToWatch), "context canceled")
+		})
+
+		it("prints the progress of the resource while waiting", func() {
+			resourceToWatch.Status = v1alpha2.BuilderStatus{
+				Status: conditionReady(corev1.ConditionFalse, generation-1),
+			}
+
+			notReady := conditionReady(corev1.ConditionUnknown, generation)
+			notReady.Conditions[0].Reason = "BuilderNotReady"
+			watcher.addEvent(watch.Event{
+				Type: watch.Modified,
+				Object: &v1alpha2.Builder{
+					TypeMeta:   resourceToWatch.TypeMeta,
+					ObjectMeta: resourceToWatch.ObjectMeta,
+					Status:     v1alpha2.BuilderStatus{Status: notReady},
+				},
+			})
+			time.AfterFunc(100*time.Millisecond, func() {
+				watcher.addEvent(watch.Event{
+					Type: watch.Modified,
+					Object: &v1alpha2.Builder{
+						TypeMeta:   resourceToWatch.TypeMeta,
+						ObjectMeta: resourceToWatch.ObjectMeta,
+						Status:     v1alpha2.BuilderStatus{Status: conditionReady(corev1.ConditionTrue, generation)},
+					},
+				})
+			})
+
+			out := &bytes.Buffer{}
+			waiter := NewWaiter(dynamicClient, 2*time.Second).WithProgress(out, 20*time.Millisecond)
+
+			require.NoError(t, waiter.Wait(context.Background(), resourceToWatch))
+			require.Contains(t, out.String(), `Waiting for Builder "some-name": observed generation 2 of 2, Ready Unknown: BuilderNotReady: some-message`+"\n")
+		})
 	})
 }
 
@@ -2,6 +2,8 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -13,68 +15,236 @@ import (
 )
 
 const (
-	kpNamespace                         = "kpack"
-	kpConfigMapName                     = "kp-config"
+	kpNamespace                       = "kpack"
+	kpConfigMapName                   = "kp-config"
 	defaultRepositoryKey              = "default.repository"
 	defaultServiceAccountNameKey      = "default.repository.serviceaccount"
 	defaultServiceAccountNamespaceKey = "default.repository.serviceaccount.namespace"
+	defaultClusterBuilderKey          = "default.clusterbuilder"
+	defaultNamespaceKey               = "default.namespace"
+	registryCaCertPathKey             = "registry.ca-cert-path"
 )
 
+// Keys are the supported kp config keys in the order they are listed.
+var Keys = []string{
+	defaultRepositoryKey,
+	defaultServiceAccountNameKey,
+	defaultServiceAccountNamespaceKey,
+	defaultClusterBuilderKey,
+	defaultNamespaceKey,
+	registryCaCertPathKey,
+}
+
+// clientKeys only apply to the machine running kp and are only read from the user config file.
+var clientKeys = map[string]bool{
+	defaultNamespaceKey:   true,
+	registryCaCertPathKey: true,
+}
+
+// namespacedKeys are the only keys the kp-config ConfigMap of a namespace can override,
+// the other keys are used by cluster-scoped commands.
+var namespacedKeys = map[string]bool{
+	defaultClusterBuilderKey: true,
+}
+
+// ConfigValue is a resolved config value and the source that provided it.
+type ConfigValue struct {
+	Key    string
+	Value  string
+	Source string
+}
+
 type KpConfig struct {
-	defaultRepository string
-	serviceAccount      corev1.ObjectReference
+	values map[string]ConfigValue
 }
 
 func NewKpConfig(defaultRepository string, serviceAccount corev1.ObjectReference) KpConfig {
-	return KpConfig{
-		defaultRepository: defaultRepository,
-		serviceAccount:      serviceAccount,
-	}
+	return resolve(source{
+		name: "",
+		values: map[string]string{
+			defaultRepositoryKey:              defaultRepository,
+			defaultServiceAccountNameKey:      serviceAccount.Name,
+			defaultServiceAccountNamespaceKey: serviceAccount.Namespace,
+		},
+	})
 }
 
 func (c KpConfig) DefaultRepository() (string, error) {
-	if c.defaultRepository == "" {
+	if c.value(defaultRepositoryKey) == "" {
 		return "", errors.New("failed to get default repository: use \"kp config default-repository\" to set")
 	}
 
-	return c.defaultRepository, nil
+	return c.value(defaultRepositoryKey), nil
 }
 
 func (c KpConfig) ServiceAccount() corev1.ObjectReference {
-	if c.serviceAccount.Name == "" {
+	if c.value(defaultServiceAccountNameKey) == "" {
 		return corev1.ObjectReference{Name: "default", Namespace: kpNamespace}
 	}
 
-	return c.serviceAccount
+	return corev1.ObjectReference{
+		Name:      c.value(defaultServiceAccountNameKey),
+		Namespace: c.value(defaultServiceAccountNamespaceKey),
+	}
+}
+
+// DefaultClusterBuilder is the cluster builder used by images that do not specify a builder, empty if not set.
+func (c KpConfig) DefaultClusterBuilder() string {
+	return c.value(defaultClusterBuilderKey)
+}
+
+// DefaultNamespace is used instead of the kubernetes current-context namespace, empty if not set.
+func (c KpConfig) DefaultNamespace() string {
+	return c.value(defaultNamespaceKey)
+}
+
+// RegistryCaCertPath is the CA certificate used for registry requests when none is provided, empty if not set.
+func (c KpConfig) RegistryCaCertPath() string {
+	return c.value(registryCaCertPathKey)
+}
+
+// Values returns every supported key in the order of Keys, with an empty value and source for unset keys.
+func (c KpConfig) Values() []ConfigValue {
+	var values []ConfigValue
+	for _, key := range Keys {
+		if v, ok := c.values[key]; ok {
+			values = append(values, v)
+		} else {
+			values = append(values, ConfigValue{Key: key})
+		}
+	}
+	return values
+}
+
+func (c KpConfig) value(key string) string {
+	return c.values[key].Value
+}
+
+type source struct {
+	name   string
+	values map[string]string
+	// namespace is the default namespace of the service account of the source
+	namespace string
+	// client sources are read from the machine running kp
+	client bool
+	// namespaced sources only provide namespacedKeys
+	namespaced bool
+}
+
+// resolve takes every value from the first source that sets it. The service account name and namespace
+// always come from the same source, the namespace defaults to the namespace of that source.
+func resolve(sources ...source) KpConfig {
+	values := map[string]ConfigValue{}
+
+	for _, key := range Keys {
+		if key == defaultServiceAccountNamespaceKey {
+			continue
+		}
+
+		for _, s := range sources {
+			if clientKeys[key] && !s.client {
+				continue
+			}
+
+			if s.namespaced && !namespacedKeys[key] {
+				continue
+			}
+
+			value := s.values[key]
+			if value == "" {
+				continue
+			}
+
+			values[key] = ConfigValue{Key: key, Value: value, Source: s.name}
+
+			if key == defaultServiceAccountNameKey {
+				namespace := s.values[defaultServiceAccountNamespaceKey]
+				if namespace == "" {
+					namespace = s.namespace
+				}
+				values[defaultServiceAccountNamespaceKey] = ConfigValue{Key: defaultServiceAccountNamespaceKey, Value: namespace, Source: s.name}
+			}
+			break
+		}
+	}
+
+	return KpConfig{values: values}
 }
 
 type KpConfigProvider struct {
-	cs k8s.ClientSet
+	cs             k8s.ClientSet
+	userConfigPath string
+	namespaced     bool
 }
 
+// NewKpConfigProvider returns a provider for cluster-scoped resources, it ignores the kp-config ConfigMap
+// in the namespace of the client set.
 func NewKpConfigProvider(cs k8s.ClientSet) KpConfigProvider {
-	return KpConfigProvider{cs: cs}
+	return KpConfigProvider{cs: cs, userConfigPath: UserConfigPath()}
+}
+
+// NewNamespacedKpConfigProvider returns a provider for resources in the namespace of the client set,
+// the kp-config ConfigMap in that namespace overrides the default cluster builder of the cluster config.
+func NewNamespacedKpConfigProvider(cs k8s.ClientSet) KpConfigProvider {
+	return KpConfigProvider{cs: cs, userConfigPath: UserConfigPath(), namespaced: true}
+}
+
+// WithUserConfigPath returns a provider that reads the user config from path instead of the default location.
+func (d KpConfigProvider) WithUserConfigPath(path string) KpConfigProvider {
+	d.userConfigPath = path
+	return d
 }
 
+// GetKpConfig returns the resolved config, ignoring sources that cannot be read.
 func (d KpConfigProvider) GetKpConfig(ctx context.Context) KpConfig {
-	kpConfig, err := d.getKpConfigMap(ctx)
+	kpConfig, _ := d.ResolveKpConfig(ctx)
+	return kpConfig
+}
+
+// ResolveKpConfig resolves the config from, in order of precedence:
+// the kp-config ConfigMap in the namespace of the client set (namespaced providers only),
+// the kp-config ConfigMap in the kpack namespace and the user config file.
+func (d KpConfigProvider) ResolveKpConfig(ctx context.Context) (KpConfig, error) {
+	var sources []source
+
+	if d.namespaced && d.cs.Namespace != "" && d.cs.Namespace != kpNamespace {
+		namespaceSource, err := d.configMapSource(ctx, d.cs.Namespace)
+		if err != nil {
+			return KpConfig{}, err
+		}
+		namespaceSource.namespaced = true
+		sources = append(sources, namespaceSource)
+	}
+
+	clusterSource, err := d.configMapSource(ctx, kpNamespace)
 	if err != nil {
-		return KpConfig{}
+		return KpConfig{}, err
 	}
+	sources = append(sources, clusterSource)
 
-	serviceAccountName := kpConfig.Data[defaultServiceAccountNameKey]
-	serviceAccountNamespace, ok := kpConfig.Data[defaultServiceAccountNamespaceKey]
-	if !ok && serviceAccountName != "" {
-		serviceAccountNamespace = kpNamespace
+	userSource, err := readUserConfigSource(d.userConfigPath)
+	if err != nil {
+		return resolve(sources...), err
 	}
 
-	return KpConfig{
-		defaultRepository: kpConfig.Data[defaultRepositoryKey],
-		serviceAccount: corev1.ObjectReference{
-			Name:      serviceAccountName,
-			Namespace: serviceAccountNamespace,
-		},
+	return resolve(append(sources, userSource)...), nil
+}
+
+func (d KpConfigProvider) configMapSource(ctx context.Context, namespace string) (source, error) {
+	s := source{
+		name:      fmt.Sprintf("ConfigMap %q", namespace+"/"+kpConfigMapName),
+		namespace: namespace,
+	}
+
+	cm, err := d.cs.K8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, kpConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+		return s, nil
+	} else if err != nil {
+		return s, err
 	}
+
+	s.values = cm.Data
+	return s, nil
 }
 
 func (d KpConfigProvider) SetDefaultRepository(ctx context.Context, defaultRepository string) error {
@@ -84,12 +254,14 @@ func (d KpConfigProvider) SetDefaultRepository(ctx context.Context, defaultRepos
 	}
 
 	if k8serrors.IsNotFound(err) {
-		return createKpConfigMap(ctx, d.cs.K8sClient, KpConfig{
-			defaultRepository: defaultRepository,
+		return createKpConfigMap(ctx, d.cs.K8sClient, map[string]string{
+			defaultRepositoryKey: defaultRepository,
 		})
 	}
 
-	return updateKpConfigMap(ctx, d.cs.K8sClient, existingKpConfig, KpConfig{defaultRepository: defaultRepository})
+	return updateKpConfigMap(ctx, d.cs.K8sClient, existingKpConfig, map[string]string{
+		defaultRepositoryKey: defaultRepository,
+	})
 }
 
 func (d KpConfigProvider) SetDefaultServiceAccount(ctx context.Context, serviceAccount corev1.ObjectReference) error {
@@ -99,60 +271,94 @@ func (d KpConfigProvider) SetDefaultServiceAccount(ctx context.Context, serviceA
 	}
 
 	if k8serrors.IsNotFound(err) {
-		return createKpConfigMap(ctx, d.cs.K8sClient, KpConfig{
-			serviceAccount: serviceAccount,
+		return createKpConfigMap(ctx, d.cs.K8sClient, map[string]string{
+			defaultServiceAccountNameKey:      serviceAccount.Name,
+			defaultServiceAccountNamespaceKey: serviceAccount.Namespace,
 		})
 	}
 
-	return updateKpConfigMap(ctx, d.cs.K8sClient, existingKpConfig, KpConfig{serviceAccount: serviceAccount})
+	return updateKpConfigMap(ctx, d.cs.K8sClient, existingKpConfig, map[string]string{
+		defaultServiceAccountNameKey:      serviceAccount.Name,
+		defaultServiceAccountNamespaceKey: serviceAccount.Namespace,
+	})
+}
+
+// Unset removes the key from the kp-config ConfigMap in the namespace, or the kpack namespace if empty.
+// The service account name and namespace are removed together. It returns false if the key was not set.
+func (d KpConfigProvider) Unset(ctx context.Context, namespace, key string) (bool, error) {
+	if !isKey(key) {
+		return false, errors.Errorf("unknown key %q, must be one of %s", key, strings.Join(Keys, ", "))
+	}
+
+	if clientKeys[key] {
+		return false, errors.Errorf("key %q is only read from the user config file %q", key, d.userConfigPath)
+	}
+
+	if namespace == "" {
+		namespace = kpNamespace
+	}
+
+	cm, err := d.cs.K8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, kpConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	keys := []string{key}
+	if key == defaultServiceAccountNameKey || key == defaultServiceAccountNamespaceKey {
+		keys = []string{defaultServiceAccountNameKey, defaultServiceAccountNamespaceKey}
+	}
+
+	updated := cm.DeepCopy()
+	for _, k := range keys {
+		delete(updated.Data, k)
+	}
+
+	if len(updated.Data) == len(cm.Data) {
+		return false, nil
+	}
+
+	_, err = d.cs.K8sClient.CoreV1().ConfigMaps(namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err == nil, err
 }
 
 func (d KpConfigProvider) getKpConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
 	return d.cs.K8sClient.CoreV1().ConfigMaps(kpNamespace).Get(ctx, kpConfigMapName, metav1.GetOptions{})
 }
 
-func configMapFromKpConfig(config KpConfig) *corev1.ConfigMap {
-	return &corev1.ConfigMap{
+func createKpConfigMap(ctx context.Context, client kubernetes.Interface, values map[string]string) error {
+	data := map[string]string{
+		defaultRepositoryKey:              "",
+		defaultServiceAccountNameKey:      "",
+		defaultServiceAccountNamespaceKey: "",
+	}
+	for key, value := range values {
+		data[key] = value
+	}
+
+	_, err := client.CoreV1().ConfigMaps(kpNamespace).Create(ctx, &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      kpConfigMapName,
 			Namespace: kpNamespace,
 		},
-		Data: map[string]string{
-			defaultRepositoryKey:              config.defaultRepository,
-			defaultServiceAccountNameKey:      config.serviceAccount.Name,
-			defaultServiceAccountNamespaceKey: config.serviceAccount.Namespace,
-		},
-	}
+		Data: data,
+	}, metav1.CreateOptions{})
+	return err
 }
 
-func createKpConfigMap(ctx context.Context, client kubernetes.Interface, config KpConfig) error {
-	_, err := client.CoreV1().ConfigMaps(kpNamespace).Create(ctx, configMapFromKpConfig(config), metav1.CreateOptions{})
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func updateKpConfigMap(ctx context.Context, client kubernetes.Interface, existingConfig *corev1.ConfigMap, newConfig KpConfig) error {
+func updateKpConfigMap(ctx context.Context, client kubernetes.Interface, existingConfig *corev1.ConfigMap, values map[string]string) error {
 	updatedConfig := existingConfig.DeepCopy()
-
-	if newConfig.defaultRepository != "" {
-		updatedConfig.Data[defaultRepositoryKey] = newConfig.defaultRepository
-	}
-
-	if newConfig.serviceAccount.Name != "" {
-		updatedConfig.Data[defaultServiceAccountNameKey] = newConfig.serviceAccount.Name
+	if updatedConfig.Data == nil {
+		updatedConfig.Data = map[string]string{}
 	}
 
-	if newConfig.serviceAccount.Namespace != "" {
-		updatedConfig.Data[defaultServiceAccountNamespaceKey] = newConfig.serviceAccount.Namespace
+	for key, value := range values {
+		if value != "" {
+			updatedConfig.Data[key] = value
+		}
 	}
 
 	_, err := client.CoreV1().ConfigMaps(kpNamespace).Update(ctx, updatedConfig, metav1.UpdateOptions{})
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
@@ -0,0 +1,138 @@
+package config_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
+	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+)
+
+func TestKpConfigProvider(t *testing.T) {
+	spec.Run(t, "TestKpConfigProvider", testKpConfigProvider)
+}
+
+func testKpConfigProvider(t *testing.T, when spec.G, it spec.S) {
+	var (
+		dir            string
+		userConfigPath string
+	)
+
+	configMap := func(namespace string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kp-config", Namespace: namespace},
+			Data:       data,
+		}
+	}
+
+	clientSet := func(namespace string, configMaps ...*corev1.ConfigMap) k8s.ClientSet {
+		client := k8sfakes.NewSimpleClientset()
+		for _, cm := range configMaps {
+			_, err := client.CoreV1().ConfigMaps(cm.Namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		return k8s.ClientSet{K8sClient: client, Namespace: namespace}
+	}
+
+	provider := func(namespace string, configMaps ...*corev1.ConfigMap) config.KpConfigProvider {
+		return config.NewNamespacedKpConfigProvider(clientSet(namespace, configMaps...)).WithUserConfigPath(userConfigPath)
+	}
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kp-config")
+		require.NoError(t, err)
+		userConfigPath = filepath.Join(dir, "config.yaml")
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	it("resolves values from the namespace, the cluster and the user config in that order", func() {
+		require.NoError(t, ioutil.WriteFile(userConfigPath, []byte(`
+default.repository: user-repo
+default.clusterbuilder: user-builder
+default.namespace: user-namespace
+registry.ca-cert-path: /path/to/ca.crt
+`), 0600))
+
+		kpConfig, err := provider("some-namespace",
+			configMap("some-namespace", map[string]string{"default.clusterbuilder": "team-builder", "default.namespace": "ignored"}),
+			configMap("kpack", map[string]string{"default.repository": "cluster-repo", "default.repository.serviceaccount": "cluster-sa"}),
+		).ResolveKpConfig(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, []config.ConfigValue{
+			{Key: "default.repository", Value: "cluster-repo", Source: `ConfigMap "kpack/kp-config"`},
+			{Key: "default.repository.serviceaccount", Value: "cluster-sa", Source: `ConfigMap "kpack/kp-config"`},
+			{Key: "default.repository.serviceaccount.namespace", Value: "kpack", Source: `ConfigMap "kpack/kp-config"`},
+			{Key: "default.clusterbuilder", Value: "team-builder", Source: `ConfigMap "some-namespace/kp-config"`},
+			{Key: "default.namespace", Value: "user-namespace", Source: `file "` + userConfigPath + `"`},
+			{Key: "registry.ca-cert-path", Value: "/path/to/ca.crt", Source: `file "` + userConfigPath + `"`},
+		}, kpConfig.Values())
+	})
+
+	it("reads the service account name and namespace from the same source", func() {
+		require.NoError(t, ioutil.WriteFile(userConfigPath, []byte("default.repository.serviceaccount.namespace: other-namespace\n"), 0600))
+
+		kpConfig := provider("some-namespace",
+			configMap("kpack", map[string]string{"default.repository.serviceaccount": "cluster-sa"}),
+		).GetKpConfig(context.Background())
+
+		require.Equal(t, corev1.ObjectReference{Name: "cluster-sa", Namespace: "kpack"}, kpConfig.ServiceAccount())
+	})
+
+	it("only reads the default cluster builder from the namespace config", func() {
+		kpConfig := provider("some-namespace",
+			configMap("some-namespace", map[string]string{
+				"default.repository":                "team-repo",
+				"default.repository.serviceaccount": "team-sa",
+				"default.clusterbuilder":            "team-builder",
+			}),
+			configMap("kpack", map[string]string{"default.repository": "cluster-repo"}),
+		).GetKpConfig(context.Background())
+
+		defaultRepo, err := kpConfig.DefaultRepository()
+		require.NoError(t, err)
+		require.Equal(t, "cluster-repo", defaultRepo)
+		require.Equal(t, corev1.ObjectReference{Name: "default", Namespace: "kpack"}, kpConfig.ServiceAccount())
+		require.Equal(t, "team-builder", kpConfig.DefaultClusterBuilder())
+	})
+
+	it("ignores the namespace config for cluster-scoped resources", func() {
+		kpConfig := config.NewKpConfigProvider(clientSet("some-namespace",
+			configMap("some-namespace", map[string]string{"default.repository": "team-repo", "default.repository.serviceaccount": "team-sa"}),
+			configMap("kpack", map[string]string{"default.repository": "cluster-repo", "default.repository.serviceaccount": "cluster-sa"}),
+		)).WithUserConfigPath(userConfigPath).GetKpConfig(context.Background())
+
+		defaultRepo, err := kpConfig.DefaultRepository()
+		require.NoError(t, err)
+		require.Equal(t, "cluster-repo", defaultRepo)
+		require.Equal(t, corev1.ObjectReference{Name: "cluster-sa", Namespace: "kpack"}, kpConfig.ServiceAccount())
+	})
+
+	it("defaults the service account when it is not set", func() {
+		kpConfig := provider("some-namespace").GetKpConfig(context.Background())
+
+		require.Equal(t, corev1.ObjectReference{Name: "default", Namespace: "kpack"}, kpConfig.ServiceAccount())
+		_, err := kpConfig.DefaultRepository()
+		require.EqualError(t, err, `failed to get default repository: use "kp config default-repository" to set`)
+	})
+
+	it("returns an error for unknown keys in the user config", func() {
+		require.NoError(t, ioutil.WriteFile(userConfigPath, []byte("default.repo: some-repo\n"), 0600))
+
+		_, err := provider("some-namespace").ResolveKpConfig(context.Background())
+		require.EqualError(t, err, `invalid config file "`+userConfigPath+`": unknown key "default.repo"`)
+	})
+}
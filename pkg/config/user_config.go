@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+const userConfigEnv = "KP_CONFIG"
+
+// UserConfigPath is the path of the user config file, "$KP_CONFIG" or "$HOME/.kp/config.yaml".
+func UserConfigPath() string {
+	if path, ok := os.LookupEnv(userConfigEnv); ok {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kp", "config.yaml")
+}
+
+// ReadUserConfig resolves the config from the user config file only, for client side defaults needed before
+// a cluster can be reached. A missing file is an empty config.
+func ReadUserConfig(path string) (KpConfig, error) {
+	s, err := readUserConfigSource(path)
+	return resolve(s), err
+}
+
+func readUserConfigSource(path string) (source, error) {
+	s := source{
+		name:      fmt.Sprintf("file %q", path),
+		namespace: kpNamespace,
+		client:    true,
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return s, err
+	}
+
+	if err := yaml.Unmarshal(buf, &s.values); err != nil {
+		return s, errors.Wrapf(err, "invalid config file %q", path)
+	}
+
+	for key := range s.values {
+		if !isKey(key) {
+			return s, errors.Errorf("invalid config file %q: unknown key %q", path, key)
+		}
+	}
+	return s, nil
+}
+
+func isKey(key string) bool {
+	for _, k := range Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
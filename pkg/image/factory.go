@@ -27,7 +27,7 @@ var (
 )
 
 type SourceUploader interface {
-	Upload(keychain authn.Keychain, ref, path string) (string, error)
+	Upload(keychain authn.Keychain, ref, path string, excludes []string) (string, error)
 }
 
 type Printer interface {
@@ -42,6 +42,7 @@ type Factory struct {
 	GitRevision    string
 	Blob           string
 	LocalPath      string
+	Excludes       []string
 	SubPath        *string
 	Builder        string
 	ClusterBuilder string
@@ -110,6 +111,10 @@ func (f *Factory) validateCreate() error {
 		return errors.New("image source must be one of git, blob, or local-path")
 	}
 
+	if len(f.Excludes) > 0 && f.LocalPath == "" {
+		return errors.New("exclude can only be used with local-path")
+	}
+
 	builderSet := paramSet{}
 	builderSet.add("builder", f.Builder)
 	builderSet.add("cluster-builder", f.ClusterBuilder)
@@ -192,7 +197,7 @@ func (f *Factory) makeSource(tag string) (corev1alpha1.SourceConfig, error) {
 			return corev1alpha1.SourceConfig{}, err
 		}
 
-		sourceRef, err := f.SourceUploader.Upload(keychain, imgRepo, f.LocalPath)
+		sourceRef, err := f.SourceUploader.Upload(keychain, imgRepo, f.LocalPath, f.Excludes)
 		if err != nil {
 			return corev1alpha1.SourceConfig{}, err
 		}
@@ -58,6 +58,15 @@ func testImageFactory(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("exclude is provided without local-path", func() {
+		it("returns an error message", func() {
+			factory.Blob = "some-blob"
+			factory.Excludes = []string{"node_modules"}
+			_, err := factory.MakeImage("test-name", "test-namespace", "test-registry.io/test-image")
+			require.EqualError(t, err, "exclude can only be used with local-path")
+		})
+	})
+
 	when("both builder and cluster builder are provided", func() {
 		it("returns an error message", func() {
 			factory.Blob = "some-blob"
@@ -58,6 +58,10 @@ func (f *Factory) validatePatch(img *v1alpha2.Image) error {
 		return errors.New("image source must be one of git, blob, or local-path")
 	}
 
+	if len(f.Excludes) > 0 && f.LocalPath == "" {
+		return errors.New("exclude can only be used with local-path")
+	}
+
 	if (sourceSet.contains("blob") || sourceSet.contains("local-path")) && f.GitRevision != "" {
 		return errors.New("git-revision is incompatible with blob and local path image sources")
 	}
@@ -138,7 +142,7 @@ func (f *Factory) setSource(image *v1alpha2.Image) error {
 			return err
 		}
 
-		sourceRef, err := f.SourceUploader.Upload(authn.DefaultKeychain, ref.Context().Name()+"-source", f.LocalPath)
+		sourceRef, err := f.SourceUploader.Upload(authn.DefaultKeychain, ref.Context().Name()+"-source", f.LocalPath, f.Excludes)
 		if err != nil {
 			return err
 		}
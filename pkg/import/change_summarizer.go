@@ -5,8 +5,6 @@ package _import
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 
@@ -22,66 +20,34 @@ func SummarizeChange(
 	desc DependencyDescriptor,
 	kpConfig config.KpConfig,
 	storeFactory *clusterstore.Factory, stackFactory *clusterstack.Factory,
-	differ Differ, cs buildk8s.ClientSet) (hasChanges bool, changes string, err error) {
+	differ Differ, cs buildk8s.ClientSet) (*ChangeSummary, error) {
 
-	var summarizer changeSummarizer
+	summary := NewChangeSummary()
 	iDiffer := &ImportDiffer{
 		Differ:         differ,
 		StoreRefGetter: storeFactory,
 		StackRefGetter: stackFactory,
 	}
 
-	err = writeLifecycleChange(ctx, desc.Lifecycle, iDiffer, cs, &summarizer)
+	err := writeLifecycleChange(ctx, desc.Lifecycle, iDiffer, cs, &summary)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	err = writeClusterStoresChange(ctx, keychain, kpConfig, desc.ClusterStores, iDiffer, cs, &summarizer)
+	err = writeClusterStoresChange(ctx, keychain, kpConfig, desc.ClusterStores, iDiffer, cs, &summary)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	err = writeClusterStacksChange(ctx, keychain, kpConfig, desc.GetClusterStacks(), iDiffer, cs, &summarizer)
+	err = writeClusterStacksChange(ctx, keychain, kpConfig, desc.GetClusterStacks(), iDiffer, cs, &summary)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	err = writeClusterBuildersChange(ctx, desc.GetClusterBuilders(), iDiffer, cs, &summarizer)
+	err = writeClusterBuildersChange(ctx, desc.GetClusterBuilders(), iDiffer, cs, &summary)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	return summarizer.hasChanges, summarizer.changes.String(), nil
-}
-
-type changeSummarizer struct {
-	hasChanges bool
-	changes    strings.Builder
-	diffs      strings.Builder
-}
-
-func (cs *changeSummarizer) writeChange(header string) {
-	if cs.changes.Len() == 0 {
-		cs.changes.WriteString("Changes\n\n")
-	}
-
-	cs.changes.WriteString(fmt.Sprintf("%s\n\n", header))
-
-	change := cs.diffs.String()
-	if change == "" {
-		cs.changes.WriteString("No Changes\n\n")
-	} else {
-		cs.changes.WriteString(change)
-		cs.hasChanges = true
-	}
-
-	cs.diffs.Reset()
-}
-
-func (cs *changeSummarizer) writeDiff(diff string) error {
-	var err error
-	if diff != "" {
-		_, err = cs.diffs.WriteString(fmt.Sprintf("%s\n\n", diff))
-	}
-	return err
+	return &summary, nil
 }
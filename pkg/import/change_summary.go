@@ -0,0 +1,165 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	ChangeSummaryKind = "ImportChangeSummary"
+	LifecycleKind     = "Lifecycle"
+
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionNone   = "none"
+)
+
+type ChangeSummary struct {
+	metav1.TypeMeta `json:",inline"`
+	HasChanges      bool             `json:"hasChanges"`
+	Changes         []ResourceChange `json:"changes"`
+}
+
+type ResourceChange struct {
+	Kind     string        `json:"kind"`
+	Name     string        `json:"name"`
+	Action   string        `json:"action"`
+	Added    []FieldChange `json:"added,omitempty"`
+	Removed  []FieldChange `json:"removed,omitempty"`
+	Modified []FieldChange `json:"modified,omitempty"`
+	Images   []ImageChange `json:"images,omitempty"`
+
+	Diff string `json:"-"`
+}
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+type ImageChange struct {
+	Field        string `json:"field"`
+	BeforeDigest string `json:"beforeDigest,omitempty"`
+	AfterDigest  string `json:"afterDigest,omitempty"`
+}
+
+func NewChangeSummary() ChangeSummary {
+	return ChangeSummary{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ChangeSummaryKind,
+			APIVersion: CurrentAPIVersion,
+		},
+		Changes: []ResourceChange{},
+	}
+}
+
+func (s *ChangeSummary) add(change ResourceChange) {
+	if change.Diff != "" {
+		s.HasChanges = true
+	}
+	s.Changes = append(s.Changes, change)
+}
+
+func (s ChangeSummary) String() string {
+	sections := []struct {
+		header string
+		kind   string
+	}{
+		{"Lifecycle", LifecycleKind},
+		{"ClusterStores", v1alpha2.ClusterStoreKind},
+		{"ClusterStacks", v1alpha2.ClusterStackKind},
+		{"ClusterBuilders", v1alpha2.ClusterBuilderKind},
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("Changes\n\n")
+	for _, section := range sections {
+		sb.WriteString(fmt.Sprintf("%s\n\n", section.header))
+
+		hasDiff := false
+		for _, change := range s.Changes {
+			if change.Kind != section.kind || change.Diff == "" {
+				continue
+			}
+			hasDiff = true
+			sb.WriteString(fmt.Sprintf("%s\n\n", change.Diff))
+		}
+
+		if !hasDiff {
+			sb.WriteString("No Changes\n\n")
+		}
+	}
+	return sb.String()
+}
+
+func (s *ChangeSummary) DeepCopyObject() runtime.Object {
+	out := &ChangeSummary{
+		TypeMeta:   s.TypeMeta,
+		HasChanges: s.HasChanges,
+		Changes:    make([]ResourceChange, len(s.Changes)),
+	}
+	for i, change := range s.Changes {
+		out.Changes[i] = change
+		out.Changes[i].Added = append([]FieldChange(nil), change.Added...)
+		out.Changes[i].Removed = append([]FieldChange(nil), change.Removed...)
+		out.Changes[i].Modified = append([]FieldChange(nil), change.Modified...)
+		out.Changes[i].Images = append([]ImageChange(nil), change.Images...)
+	}
+	return out
+}
+
+func newResourceChange(kind, name string, exists bool, diff string) ResourceChange {
+	action := ActionNone
+	if diff != "" {
+		if exists {
+			action = ActionUpdate
+		} else {
+			action = ActionCreate
+		}
+	}
+
+	return ResourceChange{
+		Kind:   kind,
+		Name:   name,
+		Action: action,
+		Diff:   diff,
+	}
+}
+
+func (c *ResourceChange) compareField(field, before, after string) {
+	switch {
+	case before == after:
+		return
+	case before == "":
+		c.Added = append(c.Added, FieldChange{Field: field, After: after})
+	case after == "":
+		c.Removed = append(c.Removed, FieldChange{Field: field, Before: before})
+	default:
+		c.Modified = append(c.Modified, FieldChange{Field: field, Before: before, After: after})
+	}
+}
+
+func (c *ResourceChange) compareImage(field, before, after string) {
+	c.compareField(field, before, after)
+
+	beforeDigest, afterDigest := imageDigest(before), imageDigest(after)
+	if beforeDigest != afterDigest {
+		c.Images = append(c.Images, ImageChange{Field: field, BeforeDigest: beforeDigest, AfterDigest: afterDigest})
+	}
+}
+
+func imageDigest(ref string) string {
+	parts := strings.Split(ref, "@")
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[1]
+}
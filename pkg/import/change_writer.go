@@ -16,8 +16,7 @@ import (
 )
 
 type changeWriter interface {
-	writeDiff(diffs string) error
-	writeChange(header string)
+	add(change ResourceChange)
 }
 
 func writeLifecycleChange(ctx context.Context, newLifecycle Lifecycle, differ *ImportDiffer, cs buildk8s.ClientSet, cw changeWriter) error {
@@ -27,17 +26,14 @@ func writeLifecycleChange(ctx context.Context, newLifecycle Lifecycle, differ *I
 			return err
 		}
 
-		diff, err := differ.DiffLifecycle(oldImg, newLifecycle.Image)
+		change, err := differ.LifecycleChange(oldImg, newLifecycle.Image)
 		if err != nil {
 			return err
 		}
 
-		if err = cw.writeDiff(diff); err != nil {
-			return err
-		}
+		cw.add(change)
 	}
 
-	cw.writeChange("Lifecycle")
 	return nil
 }
 
@@ -51,16 +47,14 @@ func writeClusterStoresChange(ctx context.Context, keychain authn.Keychain, kpCo
 			oldStore = nil
 		}
 
-		diff, err := differ.DiffClusterStore(keychain, kpConfig, oldStore, store)
+		change, err := differ.ClusterStoreChange(keychain, kpConfig, oldStore, store)
 		if err != nil {
 			return err
 		}
-		if err = cw.writeDiff(diff); err != nil {
-			return err
-		}
+
+		cw.add(change)
 	}
 
-	cw.writeChange("ClusterStores")
 	return nil
 }
 
@@ -74,16 +68,14 @@ func writeClusterStacksChange(ctx context.Context, keychain authn.Keychain, kpCo
 			oldStack = nil
 		}
 
-		diff, err := differ.DiffClusterStack(keychain, kpConfig, oldStack, stack)
+		change, err := differ.ClusterStackChange(keychain, kpConfig, oldStack, stack)
 		if err != nil {
 			return err
 		}
-		if err = cw.writeDiff(diff); err != nil {
-			return err
-		}
+
+		cw.add(change)
 	}
 
-	cw.writeChange("ClusterStacks")
 	return nil
 }
 
@@ -97,15 +89,13 @@ func writeClusterBuildersChange(ctx context.Context, builders []ClusterBuilder,
 			oldBuilder = nil
 		}
 
-		diff, err := differ.DiffClusterBuilder(oldBuilder, builder)
+		change, err := differ.ClusterBuilderChange(oldBuilder, builder)
 		if err != nil {
 			return err
 		}
-		if err = cw.writeDiff(diff); err != nil {
-			return err
-		}
+
+		cw.add(change)
 	}
 
-	cw.writeChange("ClusterBuilders")
 	return nil
 }
@@ -139,3 +139,22 @@ func (d DependencyDescriptor) GetClusterBuilders() []ClusterBuilder {
 	}
 	return d.ClusterBuilders
 }
+
+func (d DependencyDescriptor) GetImages() []string {
+	var images []string
+	if d.HasLifecycleImage() {
+		images = append(images, d.GetLifecycleImage())
+	}
+
+	for _, store := range d.ClusterStores {
+		for _, src := range store.Sources {
+			images = append(images, src.Image)
+		}
+	}
+
+	for _, stack := range d.ClusterStacks {
+		images = append(images, stack.BuildImage.Image, stack.RunImage.Image)
+	}
+
+	return images
+}
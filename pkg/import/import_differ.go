@@ -5,11 +5,14 @@ package _import
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/config"
@@ -34,10 +37,27 @@ type ImportDiffer struct {
 }
 
 func (id *ImportDiffer) DiffLifecycle(oldImg string, newImg string) (string, error) {
-	return id.Differ.Diff(oldImg, newImg)
+	change, err := id.LifecycleChange(oldImg, newImg)
+	return change.Diff, err
+}
+
+func (id *ImportDiffer) LifecycleChange(oldImg string, newImg string) (ResourceChange, error) {
+	diff, err := id.Differ.Diff(oldImg, newImg)
+	if err != nil {
+		return ResourceChange{}, err
+	}
+
+	change := newResourceChange(LifecycleKind, "lifecycle", true, diff)
+	change.compareImage("image", oldImg, newImg)
+	return change, nil
 }
 
 func (id *ImportDiffer) DiffClusterStore(keychain authn.Keychain, kpConfig config.KpConfig, oldCS *v1alpha2.ClusterStore, newCS ClusterStore) (string, error) {
+	change, err := id.ClusterStoreChange(keychain, kpConfig, oldCS, newCS)
+	return change.Diff, err
+}
+
+func (id *ImportDiffer) ClusterStoreChange(keychain authn.Keychain, kpConfig config.KpConfig, oldCS *v1alpha2.ClusterStore, newCS ClusterStore) (ResourceChange, error) {
 	type void struct{}
 	newBPs := map[string]void{}
 	mux := &sync.Mutex{}
@@ -58,7 +78,7 @@ func (id *ImportDiffer) DiffClusterStore(keychain authn.Keychain, kpConfig confi
 	}
 
 	if err := errs.Wait(); err != nil {
-		return "", err
+		return ResourceChange{}, err
 	}
 
 	oldCSStr := ""
@@ -71,7 +91,7 @@ Sources:`, oldCS.Name)
 	}
 
 	if len(newBPs) == 0 {
-		return "", nil
+		return newResourceChange(v1alpha2.ClusterStoreKind, newCS.Name, oldCS != nil, ""), nil
 	}
 
 	newCS.Sources = []Source{}
@@ -79,41 +99,109 @@ Sources:`, oldCS.Name)
 		newCS.Sources = append(newCS.Sources, Source{Image: img})
 	}
 
-	return id.Differ.Diff(oldCSStr, newCS)
+	diff, err := id.Differ.Diff(oldCSStr, newCS)
+	if err != nil {
+		return ResourceChange{}, err
+	}
+
+	change := newResourceChange(v1alpha2.ClusterStoreKind, newCS.Name, oldCS != nil, diff)
+	sort.Slice(newCS.Sources, func(i, j int) bool { return newCS.Sources[i].Image < newCS.Sources[j].Image })
+	for _, source := range newCS.Sources {
+		change.compareImage("sources", "", source.Image)
+	}
+	return change, nil
+}
+
+func (id *ImportDiffer) DiffClusterStack(keychain authn.Keychain, kpConfig config.KpConfig, oldCS *v1alpha2.ClusterStack, newCS ClusterStack) (string, error) {
+	change, err := id.ClusterStackChange(keychain, kpConfig, oldCS, newCS)
+	return change.Diff, err
 }
 
-func (id *ImportDiffer) DiffClusterStack(keychain authn.Keychain, kpConfig config.KpConfig, oldCS *v1alpha2.ClusterStack, newCS ClusterStack) (diff string, err error) {
+func (id *ImportDiffer) ClusterStackChange(keychain authn.Keychain, kpConfig config.KpConfig, oldCS *v1alpha2.ClusterStack, newCS ClusterStack) (change ResourceChange, err error) {
 	newCS.BuildImage.Image, err = id.StackRefGetter.RelocatedBuildImage(keychain, kpConfig, newCS.BuildImage.Image)
 	if err != nil {
-		return "", err
+		return ResourceChange{}, err
 	}
 	newCS.RunImage.Image, err = id.StackRefGetter.RelocatedRunImage(keychain, kpConfig, newCS.RunImage.Image)
 	if err != nil {
-		return "", err
+		return ResourceChange{}, err
 	}
 
-	var oldDiffableStack interface{}
+	var (
+		oldDiffableStack interface{}
+		oldStack         ClusterStack
+	)
 	if oldCS != nil {
-		oldDiffableStack = ClusterStack{
+		oldStack = ClusterStack{
 			Name:       oldCS.Name,
 			BuildImage: Source{Image: oldCS.Spec.BuildImage.Image},
 			RunImage:   Source{Image: oldCS.Spec.RunImage.Image},
 		}
+		oldDiffableStack = oldStack
+	}
+
+	diff, err := id.Differ.Diff(oldDiffableStack, newCS)
+	if err != nil {
+		return ResourceChange{}, err
 	}
 
-	return id.Differ.Diff(oldDiffableStack, newCS)
+	change = newResourceChange(v1alpha2.ClusterStackKind, newCS.Name, oldCS != nil, diff)
+	if diff != "" {
+		change.compareImage("buildImage", oldStack.BuildImage.Image, newCS.BuildImage.Image)
+		change.compareImage("runImage", oldStack.RunImage.Image, newCS.RunImage.Image)
+	}
+	return change, nil
 }
 
 func (id *ImportDiffer) DiffClusterBuilder(oldCB *v1alpha2.ClusterBuilder, newCB ClusterBuilder) (string, error) {
-	var oldDiffableCB interface{}
+	change, err := id.ClusterBuilderChange(oldCB, newCB)
+	return change.Diff, err
+}
+
+func (id *ImportDiffer) ClusterBuilderChange(oldCB *v1alpha2.ClusterBuilder, newCB ClusterBuilder) (ResourceChange, error) {
+	var (
+		oldDiffableCB interface{}
+		oldBuilder    ClusterBuilder
+	)
 	if oldCB != nil {
-		oldDiffableCB = ClusterBuilder{
+		oldBuilder = ClusterBuilder{
 			Name:         oldCB.Name,
 			ClusterStack: oldCB.Spec.Stack.Name,
 			ClusterStore: oldCB.Spec.Store.Name,
 			Order:        oldCB.Spec.Order,
 		}
+		oldDiffableCB = oldBuilder
+	}
+
+	diff, err := id.Differ.Diff(oldDiffableCB, newCB)
+	if err != nil {
+		return ResourceChange{}, err
+	}
+
+	change := newResourceChange(v1alpha2.ClusterBuilderKind, newCB.Name, oldCB != nil, diff)
+	if diff != "" {
+		oldOrder, err := orderString(oldBuilder.Order)
+		if err != nil {
+			return ResourceChange{}, err
+		}
+
+		newOrder, err := orderString(newCB.Order)
+		if err != nil {
+			return ResourceChange{}, err
+		}
+
+		change.compareField("clusterStack", oldBuilder.ClusterStack, newCB.ClusterStack)
+		change.compareField("clusterStore", oldBuilder.ClusterStore, newCB.ClusterStore)
+		change.compareField("order", oldOrder, newOrder)
+	}
+	return change, nil
+}
+
+func orderString(order []corev1alpha1.OrderEntry) (string, error) {
+	if len(order) == 0 {
+		return "", nil
 	}
 
-	return id.Differ.Diff(oldDiffableCB, newCB)
+	buf, err := json.Marshal(order)
+	return string(buf), err
 }
@@ -204,4 +204,108 @@ func testImportDiffer(t *testing.T, when spec.G, it spec.S) {
 			require.Equal(t, nil, diffArg0)
 		})
 	})
+
+	when("ClusterStackChange", func() {
+		it("reports modified stack images with their digests", func() {
+			oldStack := &v1alpha2.ClusterStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "some-stack",
+				},
+				Spec: v1alpha2.ClusterStackSpec{
+					BuildImage: v1alpha2.ClusterStackSpecImage{Image: "some-repo/build@sha256:old-build"},
+					RunImage:   v1alpha2.ClusterStackSpecImage{Image: "some-repo/run@sha256:same-run"},
+				},
+			}
+			newStack := importpkg.ClusterStack{
+				Name:       "some-stack",
+				BuildImage: importpkg.Source{Image: "some-repo/build@sha256:new-build"},
+				RunImage:   importpkg.Source{Image: "some-repo/run@sha256:same-run"},
+			}
+
+			change, err := importDiffer.ClusterStackChange(fakeKeychain, kpConfig, oldStack, newStack)
+			require.NoError(t, err)
+			require.Equal(t, importpkg.ResourceChange{
+				Kind:   "ClusterStack",
+				Name:   "some-stack",
+				Action: importpkg.ActionUpdate,
+				Modified: []importpkg.FieldChange{
+					{Field: "buildImage", Before: "some-repo/build@sha256:old-build", After: "some-repo/build@sha256:new-build"},
+				},
+				Images: []importpkg.ImageChange{
+					{Field: "buildImage", BeforeDigest: "sha256:old-build", AfterDigest: "sha256:new-build"},
+				},
+				Diff: "some-diff",
+			}, change)
+		})
+
+		it("reports added fields when the stack does not exist", func() {
+			newStack := importpkg.ClusterStack{
+				Name:       "some-stack",
+				BuildImage: importpkg.Source{Image: "some-repo/build@sha256:new-build"},
+				RunImage:   importpkg.Source{Image: "some-repo/run@sha256:new-run"},
+			}
+
+			change, err := importDiffer.ClusterStackChange(fakeKeychain, kpConfig, nil, newStack)
+			require.NoError(t, err)
+			require.Equal(t, importpkg.ActionCreate, change.Action)
+			require.Equal(t, []importpkg.FieldChange{
+				{Field: "buildImage", After: "some-repo/build@sha256:new-build"},
+				{Field: "runImage", After: "some-repo/run@sha256:new-run"},
+			}, change.Added)
+			require.Equal(t, []importpkg.ImageChange{
+				{Field: "buildImage", AfterDigest: "sha256:new-build"},
+				{Field: "runImage", AfterDigest: "sha256:new-run"},
+			}, change.Images)
+		})
+	})
+
+	when("ClusterStoreChange", func() {
+		it("reports no change when all buildpackages exist", func() {
+			oldStore := &v1alpha2.ClusterStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-store"},
+				Spec: v1alpha2.ClusterStoreSpec{
+					Sources: []corev1alpha1.StoreImage{{Image: "some-repo/bp@sha256:some-bp"}},
+				},
+			}
+
+			change, err := importDiffer.ClusterStoreChange(fakeKeychain, kpConfig, oldStore, importpkg.ClusterStore{
+				Name:    "some-store",
+				Sources: []importpkg.Source{{Image: "some-repo/bp@sha256:some-bp"}},
+			})
+			require.NoError(t, err)
+			require.Equal(t, importpkg.ResourceChange{
+				Kind:   "ClusterStore",
+				Name:   "some-store",
+				Action: importpkg.ActionNone,
+			}, change)
+		})
+	})
+
+	when("ClusterBuilderChange", func() {
+		it("reports modified stack, store and order", func() {
+			oldBuilder := &v1alpha2.ClusterBuilder{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-builder"},
+				Spec: v1alpha2.ClusterBuilderSpec{
+					BuilderSpec: v1alpha2.BuilderSpec{
+						Store: corev1.ObjectReference{Name: "some-store"},
+						Stack: corev1.ObjectReference{Name: "some-stack"},
+						Order: []corev1alpha1.OrderEntry{{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack"}}}}},
+					},
+				},
+			}
+
+			change, err := importDiffer.ClusterBuilderChange(oldBuilder, importpkg.ClusterBuilder{
+				Name:         "some-builder",
+				ClusterStore: "some-store",
+				ClusterStack: "some-new-stack",
+				Order:        []corev1alpha1.OrderEntry{{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-new-buildpack"}}}}},
+			})
+			require.NoError(t, err)
+			require.Equal(t, importpkg.ActionUpdate, change.Action)
+			require.Equal(t, []importpkg.FieldChange{
+				{Field: "clusterStack", Before: "some-stack", After: "some-new-stack"},
+				{Field: "order", Before: `[{"group":[{"id":"some-buildpack"}]}]`, After: `[{"group":[{"id":"some-new-buildpack"}]}]`},
+			}, change.Modified)
+		})
+	})
 }
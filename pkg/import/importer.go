@@ -11,6 +11,7 @@ import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,10 +23,12 @@ import (
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
 	"github.com/vmware-tanzu/kpack-cli/pkg/config"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
+	"github.com/vmware-tanzu/kpack-cli/pkg/lifecycle"
 )
 
 type ImageRelocator interface {
 	Relocate(keychain authn.Keychain, src v1.Image, destination string) (string, error)
+	Wait() error
 }
 
 type ImageFetcher interface {
@@ -75,6 +78,10 @@ func NewImporter(printer Printer, k8sClient kubernetes.Interface, client version
 }
 
 func (i *Importer) ReadDescriptor(rawDescriptor string) (DependencyDescriptor, error) {
+	return ReadDescriptor(rawDescriptor)
+}
+
+func ReadDescriptor(rawDescriptor string) (DependencyDescriptor, error) {
 	var api API
 	if err := yaml.Unmarshal([]byte(rawDescriptor), &api); err != nil {
 		return DependencyDescriptor{}, err
@@ -140,10 +147,22 @@ func (i *Importer) ImportDescriptor(ctx context.Context, keychain authn.Keychain
 		stackToGeneration[stack.Name] = gen
 	}
 
-	for _, builder := range rDescriptor.clusterBuilders {
-		if err := i.saveClusterBuilder(ctx, storeToGeneration, stackToGeneration, builder); err != nil {
+	// the builders are saved in order and waited on together, as each can take minutes to resolve
+	errs, errCtx := errgroup.WithContext(ctx)
+	for _, relocatedBuilder := range rDescriptor.clusterBuilders {
+		builder, err := i.saveClusterBuilder(ctx, relocatedBuilder)
+		if err != nil {
 			return nil, err
 		}
+
+		hasResolved := builderHasResolved(storeToGeneration[builder.Spec.Store.Name], stackToGeneration[builder.Spec.Stack.Name])
+		errs.Go(func() error {
+			return i.waiter.Wait(errCtx, builder, hasResolved)
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
 	}
 
 	return objects, nil
@@ -214,6 +233,10 @@ func (i *Importer) relocateDescriptor(ctx context.Context, keychain authn.Keycha
 		objs = append(objs, rBuilder)
 	}
 
+	if err := i.imageRelocator.Wait(); err != nil {
+		return relocatedDescriptor{}, nil, err
+	}
+
 	return relocatedDescriptor{
 		lifecycle:       updatedLifecycle,
 		clusterStores:   clusterstores,
@@ -249,8 +272,14 @@ func (i *Importer) relocateLifecycle(ctx context.Context, keychain authn.Keychai
 
 	newConfigMap := existingLifecycleConfig.DeepCopy()
 
-	newConfigMap.SetAnnotations(map[string]string{"kpack.io/import-timestamp": ts})
-	newConfigMap.Data["image"] = relocatedLifecycle
+	if newConfigMap.Annotations == nil {
+		newConfigMap.Annotations = map[string]string{}
+	}
+	newConfigMap.Annotations["kpack.io/import-timestamp"] = ts
+
+	if err := lifecycle.RecordImage(newConfigMap, relocatedLifecycle, ts); err != nil {
+		return nil, err
+	}
 	return newConfigMap, nil
 }
 
@@ -404,17 +433,17 @@ func (i *Importer) saveClusterStack(ctx context.Context, relocatedStack *v1alpha
 	return stack.Generation, nil
 }
 
-func (i *Importer) saveClusterBuilder(ctx context.Context, storeToGeneration, stackToGeneration map[string]int64, relocatedBuilder *v1alpha2.ClusterBuilder) error {
+func (i *Importer) saveClusterBuilder(ctx context.Context, relocatedBuilder *v1alpha2.ClusterBuilder) (*v1alpha2.ClusterBuilder, error) {
 	existingBuilder, err := i.client.KpackV1alpha2().ClusterBuilders().Get(ctx, relocatedBuilder.Name, metav1.GetOptions{})
 	if err != nil && !k8serrors.IsNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	var builder *v1alpha2.ClusterBuilder
 	if k8serrors.IsNotFound(err) {
 		builder, err = i.client.KpackV1alpha2().ClusterBuilders().Create(ctx, relocatedBuilder, metav1.CreateOptions{})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		updateBuilder := existingBuilder.DeepCopy()
@@ -422,11 +451,11 @@ func (i *Importer) saveClusterBuilder(ctx context.Context, storeToGeneration, st
 		updateBuilder.Annotations = k8s.MergeAnnotations(updateBuilder.Annotations, relocatedBuilder.Annotations)
 		builder, err = i.client.KpackV1alpha2().ClusterBuilders().Update(ctx, updateBuilder, metav1.UpdateOptions{})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return i.waiter.Wait(ctx, builder, builderHasResolved(storeToGeneration[relocatedBuilder.Spec.Store.Name], stackToGeneration[relocatedBuilder.Spec.Stack.Name]))
+	return builder, nil
 }
 
 func buildpackagesForSource(sources []Source) []string {
@@ -109,7 +109,7 @@ clusterBuilders:
 							Data: map[string]string{
 								"image": fmt.Sprintf("gcr.io/my-cool-repo/lifecycle@sha256:%s", lifecycleDigest),
 							},
-						}, timestampAnnotation),
+						}, timestampAnnotation, lifecycleHistoryAnnotation(fmt.Sprintf("gcr.io/my-cool-repo/lifecycle@sha256:%s", lifecycleDigest), "old/image")),
 					},
 				},
 				ExpectCreates: []runtime.Object{
@@ -653,7 +653,7 @@ clusterBuilders:
 							Data: map[string]string{
 								"image": fmt.Sprintf("gcr.io/my-cool-repo/lifecycle@sha256:%s", newLifecycleDigest),
 							},
-						}, timestampAnnotation),
+						}, timestampAnnotation, lifecycleHistoryAnnotation(fmt.Sprintf("gcr.io/my-cool-repo/lifecycle@sha256:%s", newLifecycleDigest), "old/image")),
 					},
 					{
 						Object: annotate(t, &v1alpha2.ClusterStore{
@@ -1369,6 +1369,17 @@ func kubectlAnnotation(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
 	return object
 }
 
+func lifecycleHistoryAnnotation(image, previousImage string) func(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
+	return func(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
+		annotations := k8s.MergeAnnotations(object.GetAnnotations(), map[string]string{
+			"kpack.io/lifecycle-history": fmt.Sprintf(`[{"image":%q,"changedAt":%q},{"image":%q}]`, image, time.Time{}.String(), previousImage),
+		})
+		object.SetAnnotations(annotations)
+
+		return object
+	}
+}
+
 func timestampAnnotation(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
 	annotations := k8s.MergeAnnotations(object.GetAnnotations(), map[string]string{
 		"kpack.io/import-timestamp": time.Time{}.String(),
@@ -1463,6 +1474,10 @@ func (f *fakeRelocator) Relocate(keychain authn.Keychain, src v1.Image, destinat
 	return fmt.Sprintf("%s@%s", destination, digest), nil
 }
 
+func (f *fakeRelocator) Wait() error {
+	return nil
+}
+
 type fakeWaiter struct{}
 
 func (f *fakeWaiter) Wait(ctx context.Context, object runtime.Object, extraChecks ...watchTools.ConditionFunc) error {
@@ -29,8 +29,26 @@ type ClientSetProvider interface {
 	GetClientSet(namespace string) (ClientSet, error)
 }
 
+// ContextClientSetProvider provides client sets for a kubeconfig context other than the current context.
+type ContextClientSetProvider interface {
+	ForContext(context string) ClientSetProvider
+}
+
 type DefaultClientSetProvider struct {
-	clientSet ClientSet
+	clientSet        ClientSet
+	context          string
+	defaultNamespace string
+}
+
+func (d DefaultClientSetProvider) ForContext(context string) ClientSetProvider {
+	d.context = context
+	return d
+}
+
+// WithDefaultNamespace returns a provider that uses the namespace instead of the current-context namespace.
+func (d DefaultClientSetProvider) WithDefaultNamespace(namespace string) DefaultClientSetProvider {
+	d.defaultNamespace = namespace
+	return d
 }
 
 func (d DefaultClientSetProvider) GetClientSet(namespace string) (ClientSet, error) {
@@ -86,7 +104,7 @@ func (d DefaultClientSetProvider) getDynamicClient() (dynamic.Interface, error)
 func (d DefaultClientSetProvider) restConfig() (*rest.Config, error) {
 	clientConfig := clientcmd.NewInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(),
-		&clientcmd.ConfigOverrides{},
+		&clientcmd.ConfigOverrides{CurrentContext: d.context},
 		os.Stdin,
 	)
 
@@ -95,6 +113,10 @@ func (d DefaultClientSetProvider) restConfig() (*rest.Config, error) {
 }
 
 func (d DefaultClientSetProvider) getDefaultNamespace() (string, error) {
+	if d.defaultNamespace != "" && d.context == "" {
+		return d.defaultNamespace, nil
+	}
+
 	clientConfig := clientcmd.NewInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(),
 		&clientcmd.ConfigOverrides{},
@@ -106,11 +128,19 @@ func (d DefaultClientSetProvider) getDefaultNamespace() (string, error) {
 		return "", err
 	}
 
-	if _, ok := rawConfig.Contexts[rawConfig.CurrentContext]; !ok {
+	context := rawConfig.CurrentContext
+	if d.context != "" {
+		context = d.context
+		if _, ok := rawConfig.Contexts[context]; !ok {
+			return "", errors.Errorf("Kubernetes context '%s' not found", context)
+		}
+	}
+
+	if _, ok := rawConfig.Contexts[context]; !ok {
 		return "", errors.New("Kubernetes current context is not set")
 	}
 
-	defaultNamespace := rawConfig.Contexts[rawConfig.CurrentContext].Namespace
+	defaultNamespace := rawConfig.Contexts[context].Namespace
 	if defaultNamespace == "" {
 		defaultNamespace = "default"
 	}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 
 	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
 )
 
 func CreatePatch(original, updated interface{}) ([]byte, error) {
@@ -31,3 +32,58 @@ func CreatePatch(original, updated interface{}) ([]byte, error) {
 
 	return patch, nil
 }
+
+// CreateThreeWayPatch creates a merge patch from current to updated the same way kubectl apply does.
+// Fields removed since the last applied configuration of current are deleted, fields set by others are kept.
+// The status of both objects is ignored.
+func CreateThreeWayPatch(current, updated Annotatable) ([]byte, error) {
+	originalBytes := []byte(current.GetAnnotations()[kubectlLastAppliedConfig])
+
+	updatedBytes, err := marshalWithoutStatus(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	currentBytes, err := marshalWithoutStatus(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(originalBytes) > 0 {
+		var original map[string]interface{}
+		if err := json.Unmarshal(originalBytes, &original); err != nil {
+			return nil, err
+		}
+		delete(original, "status")
+
+		if originalBytes, err = json.Marshal(original); err != nil {
+			return nil, err
+		}
+	}
+
+	patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(originalBytes, updatedBytes, currentBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(patch) == "{}" {
+		return nil, nil
+	}
+
+	return patch, nil
+}
+
+func marshalWithoutStatus(obj interface{}) ([]byte, error) {
+	objBytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(objBytes, &m); err != nil {
+		return nil, err
+	}
+	delete(m, "status")
+
+	return json.Marshal(m)
+}
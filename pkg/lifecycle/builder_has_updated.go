@@ -0,0 +1,37 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"knative.dev/pkg/apis/duck"
+)
+
+// BuilderHasUpdated is done when the builder image has changed from previousImage, which it does when kpack
+// rebuilds the builder with a new lifecycle, or when the builder is no longer ready.
+func BuilderHasUpdated(previousImage string) func(event watch.Event) (bool, error) {
+	return func(e watch.Event) (bool, error) {
+		u := &unstructured.Unstructured{}
+		var err error
+		u.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(e.Object)
+		if err != nil {
+			return false, err
+		}
+
+		cb := &v1alpha2.ClusterBuilder{}
+		if err := duck.FromUnstructured(u, cb); err != nil {
+			return false, err
+		}
+
+		if cb.Status.GetCondition(corev1alpha1.ConditionReady).IsFalse() {
+			return true, nil
+		}
+
+		return cb.Status.LatestImage != previousImage, nil
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	historyAnnotation = "kpack.io/lifecycle-history"
+
+	// HistoryLimit is the number of lifecycle images kept in the history, including the current image.
+	HistoryLimit = 10
+)
+
+type TimestampProvider interface {
+	GetTimestamp() string
+}
+
+// HistoryEntry is a lifecycle image and when it became the current image.
+type HistoryEntry struct {
+	Image     string `json:"image"`
+	ChangedAt string `json:"changedAt,omitempty"`
+}
+
+func GetHistory(ctx context.Context, c k8s.Interface) ([]HistoryEntry, error) {
+	cm, err := getConfigMap(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return History(cm)
+}
+
+// History returns the lifecycle images of the ConfigMap, most recent first. The first entry is always the
+// current image, an image that was not set by kp has no timestamp.
+func History(cm *corev1.ConfigMap) ([]HistoryEntry, error) {
+	var history []HistoryEntry
+	if value := cm.Annotations[historyAnnotation]; value != "" {
+		if err := json.Unmarshal([]byte(value), &history); err != nil {
+			return nil, errors.Wrapf(err, "invalid %q annotation on configmap %q", historyAnnotation, lifecycleConfigMapName)
+		}
+	}
+
+	current := cm.Data[lifecycleImageKey]
+	if current != "" && (len(history) == 0 || history[0].Image != current) {
+		history = append([]HistoryEntry{{Image: current}}, history...)
+	}
+	return history, nil
+}
+
+// RecordImage sets the lifecycle image of the ConfigMap and adds it to the history, the ConfigMap is not
+// changed if the image is already the current image.
+func RecordImage(cm *corev1.ConfigMap, image, timestamp string) error {
+	history, err := History(cm)
+	if err != nil {
+		return err
+	}
+
+	if len(history) > 0 && history[0].Image == image {
+		return nil
+	}
+
+	return setHistory(cm, append([]HistoryEntry{{Image: image, ChangedAt: timestamp}}, history...))
+}
+
+// setHistory sets the first entry of the history as the lifecycle image of the ConfigMap.
+func setHistory(cm *corev1.ConfigMap, history []HistoryEntry) error {
+	if len(history) > HistoryLimit {
+		history = history[:HistoryLimit]
+	}
+
+	value, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[historyAnnotation] = string(value)
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[lifecycleImageKey] = history[0].Image
+	return nil
+}
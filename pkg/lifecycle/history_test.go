@@ -0,0 +1,69 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/lifecycle"
+)
+
+func TestHistory(t *testing.T) {
+	spec.Run(t, "TestHistory", testHistory)
+}
+
+func testHistory(t *testing.T, when spec.G, it spec.S) {
+	when("RecordImage", func() {
+		it("adds the image before the current image", func() {
+			cm := &corev1.ConfigMap{Data: map[string]string{"image": "installed-image"}}
+
+			require.NoError(t, lifecycle.RecordImage(cm, "new-image", "some-timestamp"))
+
+			require.Equal(t, "new-image", cm.Data["image"])
+			history, err := lifecycle.History(cm)
+			require.NoError(t, err)
+			require.Equal(t, []lifecycle.HistoryEntry{
+				{Image: "new-image", ChangedAt: "some-timestamp"},
+				{Image: "installed-image"},
+			}, history)
+		})
+
+		it("does not change the history when the image is the current image", func() {
+			cm := &corev1.ConfigMap{}
+			require.NoError(t, lifecycle.RecordImage(cm, "some-image", "some-timestamp"))
+			require.NoError(t, lifecycle.RecordImage(cm, "some-image", "other-timestamp"))
+
+			history, err := lifecycle.History(cm)
+			require.NoError(t, err)
+			require.Equal(t, []lifecycle.HistoryEntry{{Image: "some-image", ChangedAt: "some-timestamp"}}, history)
+		})
+
+		it("keeps the most recent images", func() {
+			cm := &corev1.ConfigMap{}
+			for i := 0; i < lifecycle.HistoryLimit+2; i++ {
+				require.NoError(t, lifecycle.RecordImage(cm, fmt.Sprintf("image-%d", i), ""))
+			}
+
+			history, err := lifecycle.History(cm)
+			require.NoError(t, err)
+			require.Len(t, history, lifecycle.HistoryLimit)
+			require.Equal(t, fmt.Sprintf("image-%d", lifecycle.HistoryLimit+1), history[0].Image)
+			require.Equal(t, "image-2", history[lifecycle.HistoryLimit-1].Image)
+		})
+	})
+
+	it("errors when the history annotation is invalid", func() {
+		cm := &corev1.ConfigMap{}
+		cm.Annotations = map[string]string{"kpack.io/lifecycle-history": "not-json"}
+
+		_, err := lifecycle.History(cm)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `invalid "kpack.io/lifecycle-history" annotation on configmap "lifecycle-image"`)
+	})
+}
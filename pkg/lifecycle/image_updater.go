@@ -33,6 +33,8 @@ type ImageUpdaterConfig struct {
 	ImgRelocator registry.Relocator
 	ClientSet    buildk8s.ClientSet
 	TLSConfig    registry.TLSConfig
+	// Timestamp is recorded in the lifecycle image history
+	Timestamp string
 }
 
 func UpdateImage(ctx context.Context, keychain authn.Keychain, srcImgLocation string, cfg ImageUpdaterConfig, hooks ...PreUpdateHook) (*corev1.ConfigMap, error) {
@@ -55,7 +57,9 @@ func UpdateImage(ctx context.Context, keychain authn.Keychain, srcImgLocation st
 		return cm, err
 	}
 
-	cm.Data[lifecycleImageKey] = relocatedImgTag
+	if err = RecordImage(cm, relocatedImgTag, cfg.Timestamp); err != nil {
+		return cm, err
+	}
 
 	for _, h := range hooks {
 		h(cm)
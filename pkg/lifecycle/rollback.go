@@ -0,0 +1,48 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+// RollbackImage restores the previous lifecycle image from the history and removes the current image from it.
+// The previous image is fetched first, so that kpack is never configured with an image that no longer exists.
+func RollbackImage(ctx context.Context, c k8s.Interface, keychain authn.Keychain, fetcher registry.Fetcher, timestamp string, dryRun bool) (*corev1.ConfigMap, error) {
+	cm, err := getConfigMap(ctx, c)
+	if err != nil {
+		return cm, err
+	}
+
+	history, err := History(cm)
+	if err != nil {
+		return cm, err
+	}
+
+	if len(history) < 2 {
+		return cm, errors.New("no previous lifecycle image to roll back to")
+	}
+
+	previous := history[1:]
+	if _, err = fetcher.Fetch(keychain, previous[0].Image); err != nil {
+		return cm, errors.Wrapf(err, "previous lifecycle image %q is not available", previous[0].Image)
+	}
+
+	previous[0].ChangedAt = timestamp
+	if err = setHistory(cm, previous); err != nil {
+		return cm, err
+	}
+
+	if !dryRun {
+		cm, err = updateConfigMap(ctx, cm, c)
+	}
+	return cm, err
+}
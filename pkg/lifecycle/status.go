@@ -0,0 +1,96 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
+	"github.com/pkg/errors"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+type Status struct {
+	Image         string
+	Digest        string
+	Version       string
+	BuildpackAPIs []string
+	PlatformAPIs  []string
+	// ChangedAt is empty when the image was not set by kp
+	ChangedAt string
+}
+
+// lifecycleMetadata is the io.buildpacks.lifecycle.metadata label, older lifecycles only set a single api version.
+type lifecycleMetadata struct {
+	Lifecycle struct {
+		Version string `json:"version"`
+	} `json:"lifecycle"`
+	API struct {
+		Buildpack string `json:"buildpack"`
+		Platform  string `json:"platform"`
+	} `json:"api"`
+	APIs struct {
+		Buildpack apiVersions `json:"buildpack"`
+		Platform  apiVersions `json:"platform"`
+	} `json:"apis"`
+}
+
+type apiVersions struct {
+	Deprecated []string `json:"deprecated"`
+	Supported  []string `json:"supported"`
+}
+
+func GetStatus(ctx context.Context, c k8s.Interface, keychain authn.Keychain, fetcher registry.Fetcher) (Status, error) {
+	cm, err := getConfigMap(ctx, c)
+	if err != nil {
+		return Status{}, err
+	}
+
+	history, err := History(cm)
+	if err != nil {
+		return Status{}, err
+	}
+
+	if len(history) == 0 {
+		return Status{}, errors.Errorf("configmap %q does not have a lifecycle image", lifecycleConfigMapName)
+	}
+
+	img, err := fetcher.Fetch(keychain, history[0].Image)
+	if err != nil {
+		return Status{}, err
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return Status{}, err
+	}
+
+	var metadata lifecycleMetadata
+	if err := imagehelpers.GetLabel(img, lifecycleMetadataLabel, &metadata); err != nil {
+		return Status{}, errors.Wrapf(err, "failed to read lifecycle metadata of %q", history[0].Image)
+	}
+
+	return Status{
+		Image:         history[0].Image,
+		Digest:        digest.String(),
+		Version:       metadata.Lifecycle.Version,
+		BuildpackAPIs: supportedAPIs(metadata.APIs.Buildpack, metadata.API.Buildpack),
+		PlatformAPIs:  supportedAPIs(metadata.APIs.Platform, metadata.API.Platform),
+		ChangedAt:     history[0].ChangedAt,
+	}, nil
+}
+
+func supportedAPIs(apis apiVersions, api string) []string {
+	if len(apis.Supported) > 0 {
+		return apis.Supported
+	}
+
+	if api != "" {
+		return []string{api}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/archive"
+)
+
+const bundleRefAnnotation = "org.opencontainers.image.ref.name"
+
+type BundleExporter struct {
+	Fetcher Fetcher
+	Writer  io.Writer
+}
+
+// Export writes every image in refs into a single OCI layout tarball at dst.
+func (b BundleExporter) Export(keychain authn.Keychain, refs []string, dst string) error {
+	layoutDir, err := ioutil.TempDir("", "kp-bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(layoutDir)
+
+	layoutPath, err := layout.Write(layoutDir, empty.Index)
+	if err != nil {
+		return err
+	}
+
+	exported := map[string]struct{}{}
+	for _, ref := range refs {
+		if _, ok := exported[ref]; ok {
+			continue
+		}
+		exported[ref] = struct{}{}
+
+		if _, err := fmt.Fprintf(b.Writer, "\tExporting '%s'\n", ref); err != nil {
+			return err
+		}
+
+		image, err := b.Fetcher.Fetch(keychain, ref)
+		if err != nil {
+			return err
+		}
+
+		err = layoutPath.AppendImage(image, layout.WithAnnotations(map[string]string{bundleRefAnnotation: ref}))
+		if err != nil {
+			return err
+		}
+	}
+
+	fh, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	return archive.WriteTar(layoutDir, fh)
+}
+
+type BundleFetcher struct {
+	dir        string
+	layoutPath layout.Path
+	refs       map[string]v1.Hash
+}
+
+// NewBundleFetcher extracts the bundle to a temp dir; call Close to remove it.
+func NewBundleFetcher(bundlePath string) (*BundleFetcher, error) {
+	fh, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	dir, err := ioutil.TempDir("", "kp-bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := archive.ReadTar(fh, dir); err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrapf(err, "failed to read bundle '%s'", bundlePath)
+	}
+
+	layoutPath, err := layout.FromPath(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrapf(err, "failed to read bundle '%s'", bundlePath)
+	}
+
+	index, err := layoutPath.ImageIndex()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	refs := map[string]v1.Hash{}
+	for _, desc := range manifest.Manifests {
+		if ref, ok := desc.Annotations[bundleRefAnnotation]; ok {
+			refs[ref] = desc.Digest
+		}
+	}
+
+	return &BundleFetcher{
+		dir:        dir,
+		layoutPath: layoutPath,
+		refs:       refs,
+	}, nil
+}
+
+func (b *BundleFetcher) Fetch(_ authn.Keychain, src string) (v1.Image, error) {
+	digest, ok := b.refs[src]
+	if !ok {
+		return nil, errors.Errorf("image '%s' not found in bundle", src)
+	}
+
+	return b.layoutPath.Image(digest)
+}
+
+func (b *BundleFetcher) Close() error {
+	return os.RemoveAll(b.dir)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+)
+
+func TestBundle(t *testing.T) {
+	spec.Run(t, "Test Bundle", testBundle)
+}
+
+func testBundle(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeKeychain = &registryfakes.FakeKeychain{}
+		fakeFetcher  = &fakes.Fetcher{}
+		bundleDir    string
+		bundlePath   string
+	)
+
+	it.Before(func() {
+		var err error
+		bundleDir, err = ioutil.TempDir("", "bundle-test")
+		require.NoError(t, err)
+		bundlePath = filepath.Join(bundleDir, "bundle.tar")
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(bundleDir))
+	})
+
+	it("exports images that can be fetched from the bundle by reference", func() {
+		someImage, err := random.Image(100, 2)
+		require.NoError(t, err)
+		otherImage, err := random.Image(100, 1)
+		require.NoError(t, err)
+
+		fakeFetcher.AddImage("some-registry.io/some-image", someImage)
+		fakeFetcher.AddImage("some-registry.io/other-image", otherImage)
+
+		out := &bytes.Buffer{}
+		exporter := registry.BundleExporter{Fetcher: fakeFetcher, Writer: out}
+		err = exporter.Export(fakeKeychain, []string{
+			"some-registry.io/some-image",
+			"some-registry.io/other-image",
+			"some-registry.io/some-image",
+		}, bundlePath)
+		require.NoError(t, err)
+		require.Equal(t, "\tExporting 'some-registry.io/some-image'\n\tExporting 'some-registry.io/other-image'\n", out.String())
+
+		fetcher, err := registry.NewBundleFetcher(bundlePath)
+		require.NoError(t, err)
+		defer fetcher.Close()
+
+		fetched, err := fetcher.Fetch(fakeKeychain, "some-registry.io/some-image")
+		require.NoError(t, err)
+		requireSameDigest(t, someImage, fetched)
+
+		fetched, err = fetcher.Fetch(fakeKeychain, "some-registry.io/other-image")
+		require.NoError(t, err)
+		requireSameDigest(t, otherImage, fetched)
+
+		layers, err := fetched.Layers()
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+	})
+
+	it("errors when an image is not in the bundle", func() {
+		someImage, err := random.Image(100, 1)
+		require.NoError(t, err)
+		fakeFetcher.AddImage("some-registry.io/some-image", someImage)
+
+		exporter := registry.BundleExporter{Fetcher: fakeFetcher, Writer: ioutil.Discard}
+		require.NoError(t, exporter.Export(fakeKeychain, []string{"some-registry.io/some-image"}, bundlePath))
+
+		fetcher, err := registry.NewBundleFetcher(bundlePath)
+		require.NoError(t, err)
+		defer fetcher.Close()
+
+		_, err = fetcher.Fetch(fakeKeychain, "some-registry.io/missing-image")
+		require.EqualError(t, err, "image 'some-registry.io/missing-image' not found in bundle")
+	})
+
+	it("errors when the bundle does not exist", func() {
+		_, err := registry.NewBundleFetcher(filepath.Join(bundleDir, "missing.tar"))
+		require.Error(t, err)
+	})
+}
+
+func requireSameDigest(t *testing.T, expected, actual v1.Image) {
+	t.Helper()
+	expectedDigest, err := expected.Digest()
+	require.NoError(t, err)
+	actualDigest, err := actual.Digest()
+	require.NoError(t, err)
+	require.Equal(t, expectedDigest, actualDigest)
+}
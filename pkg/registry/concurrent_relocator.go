@@ -0,0 +1,250 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultRelocateWorkers = 4
+	DefaultRelocateRetries = 3
+)
+
+// ConcurrentRelocator returns the relocated reference immediately and uploads in the background.
+// Wait must be called before the relocated references are used.
+type ConcurrentRelocator interface {
+	Relocator
+	Wait() error
+}
+
+type ParallelRelocator struct {
+	tlsCfg  TLSConfig
+	writer  io.Writer
+	workers chan struct{}
+
+	Retries int
+	Backoff time.Duration
+
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	spinner *uploadSpinner
+	queued  map[string]struct{}
+	errs    []error
+}
+
+func NewParallelRelocator(writer io.Writer, tlsCfg TLSConfig, workers int) *ParallelRelocator {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &ParallelRelocator{
+		tlsCfg:  tlsCfg,
+		writer:  writer,
+		workers: make(chan struct{}, workers),
+		Retries: DefaultRelocateRetries,
+		Backoff: time.Second,
+		queued:  map[string]struct{}{},
+	}
+}
+
+func (p *ParallelRelocator) Relocate(keychain authn.Keychain, src v1.Image, destination string) (string, error) {
+	cfg, err := getDstImageInfo(src, destination)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.queued[cfg.refDigestStr]; ok {
+		return cfg.refDigestStr, nil
+	}
+	p.queued[cfg.refDigestStr] = struct{}{}
+
+	if p.spinner == nil {
+		p.spinner = newMultiUploadSpinner(p.writer)
+		go p.spinner.Write()
+	}
+	spinner := p.spinner
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		p.workers <- struct{}{}
+		defer func() { <-p.workers }()
+
+		if err := p.relocateWithRetry(keychain, src, cfg, spinner); err != nil {
+			p.mu.Lock()
+			p.errs = append(p.errs, errors.Wrapf(err, "failed to upload '%s'", cfg.refDigestStr))
+			p.mu.Unlock()
+		}
+	}()
+
+	return cfg.refDigestStr, nil
+}
+
+// Wait blocks until every queued upload has finished and returns the first upload error.
+func (p *ParallelRelocator) Wait() error {
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.spinner != nil {
+		p.spinner.Stop()
+		p.spinner = nil
+	}
+
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = nil
+		return err
+	}
+	return nil
+}
+
+func (p *ParallelRelocator) relocateWithRetry(keychain authn.Keychain, src v1.Image, cfg relocateImageInfo, spinner *uploadSpinner) error {
+	backoff := p.Backoff
+	for attempt := 1; ; attempt++ {
+		err := p.relocate(keychain, src, cfg, spinner)
+		if err == nil {
+			return nil
+		}
+		if attempt > p.Retries || !isRetryable(err) {
+			return newImageAccessError(cfg.refRepo.Context().RegistryStr(), err)
+		}
+
+		if err := spinner.Printlnf("\tRetrying '%s' in %s (attempt %d of %d): %s", cfg.refDigestStr, backoff, attempt+1, p.Retries+1, err); err != nil {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (p *ParallelRelocator) relocate(keychain authn.Keychain, src v1.Image, cfg relocateImageInfo, spinner *uploadSpinner) error {
+	transport, err := p.tlsCfg.Transport()
+	if err != nil {
+		return err
+	}
+	options := []remote.Option{
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithTransport(transport),
+	}
+
+	if cfg.exists(options...) {
+		if err := spinner.Printlnf("\tSkipping '%s' (already exists)", cfg.refDigestStr); err != nil {
+			return err
+		}
+		return remote.Tag(cfg.tag, src, options...)
+	}
+
+	existing, total := existingLayers(keychain, transport, cfg.refRepo.Context(), src)
+	if existing > 0 {
+		err = spinner.Printlnf("\tUploading '%s' (%d of %d layers already exist)", cfg.refDigestStr, existing, total)
+	} else {
+		err = spinner.Printlnf("\tUploading '%s'", cfg.refDigestStr)
+	}
+	if err != nil {
+		return err
+	}
+
+	progress := spinner.Add(cfg.size)
+	defer spinner.Remove(progress)
+
+	updates := make(chan v1.Update, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range updates {
+			progress.Update(update.Complete, update.Total)
+		}
+	}()
+
+	err = remote.Write(cfg.refRepo, src, append(options, remote.WithProgress(updates))...)
+	<-done
+	if err != nil {
+		return err
+	}
+
+	return remote.Tag(cfg.tag, src, options...)
+}
+
+// existingLayers is best effort and only used for reporting, remote.Write skips existing blobs on its own.
+func existingLayers(keychain authn.Keychain, t http.RoundTripper, repo name.Repository, img v1.Image) (int, int) {
+	layers, err := img.Layers()
+	if err != nil {
+		return 0, 0
+	}
+
+	auth, err := keychain.Resolve(repo)
+	if err != nil {
+		return 0, len(layers)
+	}
+
+	tr, err := transport.New(repo.Registry, auth, t, []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return 0, len(layers)
+	}
+	client := http.Client{Transport: tr}
+
+	existing := 0
+	for _, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			continue
+		}
+
+		u := url.URL{
+			Scheme: repo.Registry.Scheme(),
+			Host:   repo.RegistryStr(),
+			Path:   fmt.Sprintf("/v2/%s/blobs/%s", repo.RepositoryStr(), digest),
+		}
+		resp, err := client.Head(u.String())
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			existing++
+		}
+	}
+	return existing, len(layers)
+}
+
+// isRetryable reports whether an upload failed on a temporary network error or a 429 or 5xx response.
+// Errors such as rejected certificates, other responses and failures to read local layers are not retried.
+func isRetryable(err error) bool {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		return transportErr.StatusCode == http.StatusTooManyRequests || transportErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
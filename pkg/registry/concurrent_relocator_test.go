@@ -0,0 +1,206 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func TestParallelRelocator(t *testing.T) {
+	spec.Run(t, "Test Parallel Relocator", testParallelRelocator)
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func testParallelRelocator(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeKeychain   = &registryfakes.FakeKeychain{}
+		server         *httptest.Server
+		host           string
+		manifestErrors int32
+		manifestStatus int
+		output         *syncBuffer
+	)
+
+	it.Before(func() {
+		manifestErrors = 0
+		manifestStatus = 0
+		handler := ggcrregistry.New(ggcrregistry.Logger(log.New(ioutil.Discard, "", 0)))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") && atomic.AddInt32(&manifestErrors, -1) >= 0 {
+				if manifestStatus != 0 {
+					w.WriteHeader(manifestStatus)
+					return
+				}
+				// drop the connection to fail in a way the registry client does not retry on its own
+				conn, _, err := w.(http.Hijacker).Hijack()
+				require.NoError(t, err)
+				require.NoError(t, conn.Close())
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		host = u.Host
+
+		output = &syncBuffer{}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	requireImage := func(ref string, image v1.Image) {
+		digest, err := image.Digest()
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%s@%s", strings.Split(ref, "@")[0], digest), ref)
+
+		imageRef, err := name.ParseReference(ref)
+		require.NoError(t, err)
+		_, err = remote.Head(imageRef)
+		require.NoError(t, err)
+	}
+
+	it("uploads images concurrently and returns their references", func() {
+		relocator := registry.NewParallelRelocator(output, registry.TLSConfig{}, 2)
+
+		var images []v1.Image
+		var refs []string
+		for i := 0; i < 3; i++ {
+			image, err := random.Image(100, 2)
+			require.NoError(t, err)
+
+			ref, err := relocator.Relocate(fakeKeychain, image, fmt.Sprintf("%s/repo/image-%d", host, i))
+			require.NoError(t, err)
+
+			images = append(images, image)
+			refs = append(refs, ref)
+		}
+
+		require.NoError(t, relocator.Wait())
+
+		for i, ref := range refs {
+			requireImage(ref, images[i])
+			require.Contains(t, output.String(), fmt.Sprintf("\tUploading '%s'\n", ref))
+		}
+	})
+
+	it("skips images and reports layers that already exist", func() {
+		base, err := random.Image(100, 2)
+		require.NoError(t, err)
+		layer, err := random.Layer(100, "application/vnd.docker.image.rootfs.diff.tar.gzip")
+		require.NoError(t, err)
+		extended, err := mutate.AppendLayers(base, layer)
+		require.NoError(t, err)
+
+		relocator := registry.NewParallelRelocator(output, registry.TLSConfig{}, 1)
+		baseRef, err := relocator.Relocate(fakeKeychain, base, host+"/repo/image")
+		require.NoError(t, err)
+		require.NoError(t, relocator.Wait())
+
+		relocator = registry.NewParallelRelocator(output, registry.TLSConfig{}, 1)
+		_, err = relocator.Relocate(fakeKeychain, base, host+"/repo/image")
+		require.NoError(t, err)
+		require.NoError(t, relocator.Wait())
+
+		extendedRef, err := relocator.Relocate(fakeKeychain, extended, host+"/repo/image")
+		require.NoError(t, err)
+		require.NoError(t, relocator.Wait())
+
+		requireImage(extendedRef, extended)
+		require.Equal(t, fmt.Sprintf("\tUploading '%s'\n", baseRef)+
+			fmt.Sprintf("\tSkipping '%s' (already exists)\n", baseRef)+
+			fmt.Sprintf("\tUploading '%s' (2 of 3 layers already exist)\n", extendedRef), output.String())
+	})
+
+	it("retries failed uploads", func() {
+		manifestErrors = 2
+
+		image, err := random.Image(100, 1)
+		require.NoError(t, err)
+
+		relocator := registry.NewParallelRelocator(output, registry.TLSConfig{}, 1)
+		relocator.Backoff = time.Millisecond
+		ref, err := relocator.Relocate(fakeKeychain, image, host+"/repo/image")
+		require.NoError(t, err)
+		require.NoError(t, relocator.Wait())
+
+		requireImage(ref, image)
+		require.Contains(t, output.String(), fmt.Sprintf("\tRetrying '%s' in 1ms (attempt 2 of 4)", ref))
+		require.Contains(t, output.String(), fmt.Sprintf("\tRetrying '%s' in 2ms (attempt 3 of 4)", ref))
+	})
+
+	it("returns the upload error once retries are exhausted", func() {
+		manifestErrors = 10
+
+		image, err := random.Image(100, 1)
+		require.NoError(t, err)
+
+		relocator := registry.NewParallelRelocator(output, registry.TLSConfig{}, 1)
+		relocator.Retries = 1
+		relocator.Backoff = time.Millisecond
+		ref, err := relocator.Relocate(fakeKeychain, image, host+"/repo/image")
+		require.NoError(t, err)
+
+		err = relocator.Wait()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), fmt.Sprintf("failed to upload '%s'", ref))
+	})
+
+	it("does not retry uploads rejected with a client error status", func() {
+		manifestErrors = 10
+		manifestStatus = http.StatusBadRequest
+
+		image, err := random.Image(100, 1)
+		require.NoError(t, err)
+
+		relocator := registry.NewParallelRelocator(output, registry.TLSConfig{}, 1)
+		relocator.Backoff = time.Millisecond
+		_, err = relocator.Relocate(fakeKeychain, image, host+"/repo/image")
+		require.NoError(t, err)
+
+		require.Error(t, relocator.Wait())
+		require.NotContains(t, output.String(), "Retrying")
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+type ImageWriter struct {
+	skip   bool
+	writer io.Writer
+}
+
+func (w *ImageWriter) Write(_ authn.Keychain, image v1.Image, tag string) (string, error) {
+	digest, err := image.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := name.NewTag(tag, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	var message string
+	if w.skip {
+		message = fmt.Sprintf("\tSkipping '%s'\n", ref.Name())
+	} else {
+		message = fmt.Sprintf("\tUploading '%s'\n", ref.Name())
+	}
+
+	if w.writer == nil {
+		w.writer = ioutil.Discard
+	}
+	_, err = w.writer.Write([]byte(message))
+	return ref.Context().Digest(digest.String()).Name(), err
+}
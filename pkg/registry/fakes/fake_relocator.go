@@ -20,7 +20,8 @@ type Relocator struct {
 		Image    v1.Image
 		Dest     string
 	}
-	writer io.Writer
+	writer    io.Writer
+	waitCalls int
 }
 
 func (r *Relocator) Relocate(keychain authn.Keychain, image v1.Image, dest string) (string, error) {
@@ -56,6 +57,15 @@ func (r *Relocator) Relocate(keychain authn.Keychain, image v1.Image, dest strin
 	return refDigestStr, err
 }
 
+func (r *Relocator) Wait() error {
+	r.waitCalls++
+	return nil
+}
+
+func (r *Relocator) WaitCount() int {
+	return r.waitCalls
+}
+
 func (r *Relocator) CallCount() int {
 	return len(r.calls)
 }
@@ -0,0 +1,72 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+)
+
+type RepositoryClient struct {
+	repos       map[string]map[string]string
+	CatalogErr  error
+	deletedRefs []string
+}
+
+func NewRepositoryClient() *RepositoryClient {
+	return &RepositoryClient{repos: map[string]map[string]string{}}
+}
+
+func (r *RepositoryClient) AddTag(repository, tag, digest string) {
+	if _, ok := r.repos[repository]; !ok {
+		r.repos[repository] = map[string]string{}
+	}
+	r.repos[repository][tag] = digest
+}
+
+func (r *RepositoryClient) ListRepositories(_ authn.Keychain, parent string) ([]string, error) {
+	if r.CatalogErr != nil {
+		return nil, r.CatalogErr
+	}
+
+	var repos []string
+	for repo := range r.repos {
+		if repo == parent || strings.HasPrefix(repo, parent+"/") {
+			repos = append(repos, repo)
+		}
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+func (r *RepositoryClient) ListTags(_ authn.Keychain, repository string) (map[string]string, error) {
+	tags, ok := r.repos[repository]
+	if !ok {
+		return nil, errors.Errorf("repository '%s' not found", repository)
+	}
+	return tags, nil
+}
+
+func (r *RepositoryClient) Delete(_ authn.Keychain, ref string) error {
+	parts := strings.Split(ref, "@")
+	tags, ok := r.repos[parts[0]]
+	if !ok || len(parts) != 2 {
+		return errors.Errorf("image '%s' not found", ref)
+	}
+
+	for tag, digest := range tags {
+		if digest == parts[1] {
+			delete(tags, tag)
+		}
+	}
+	r.deletedRefs = append(r.deletedRefs, ref)
+	return nil
+}
+
+func (r *RepositoryClient) DeletedRefs() []string {
+	return r.deletedRefs
+}
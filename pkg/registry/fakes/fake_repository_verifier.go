@@ -0,0 +1,39 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+type RepositoryVerifier struct {
+	PushErr   error
+	DeleteErr error
+
+	Keychain      authn.Keychain
+	PushedProbes  []string
+	DeletedProbes []string
+}
+
+func (v *RepositoryVerifier) PushProbe(_ context.Context, keychain authn.Keychain, repository string) (string, error) {
+	v.Keychain = keychain
+	if v.PushErr != nil {
+		return "", v.PushErr
+	}
+
+	probe := repository + "@sha256:some-probe-digest"
+	v.PushedProbes = append(v.PushedProbes, probe)
+	return probe, nil
+}
+
+func (v *RepositoryVerifier) DeleteProbe(_ context.Context, _ authn.Keychain, probe string) error {
+	if v.DeleteErr != nil {
+		return v.DeleteErr
+	}
+
+	v.DeletedProbes = append(v.DeletedProbes, probe)
+	return nil
+}
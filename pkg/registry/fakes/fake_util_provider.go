@@ -10,7 +10,9 @@ import (
 )
 
 type UtilProvider struct {
-	FakeFetcher registry.Fetcher
+	FakeFetcher            registry.Fetcher
+	FakeRepositoryClient   registry.RepositoryClient
+	FakeRepositoryVerifier registry.RepositoryVerifier
 }
 
 func (u UtilProvider) Relocator(writer io.Writer, _ registry.TLSConfig, changeState bool) registry.Relocator {
@@ -20,6 +22,20 @@ func (u UtilProvider) Relocator(writer io.Writer, _ registry.TLSConfig, changeSt
 	}
 }
 
+func (u UtilProvider) ConcurrentRelocator(writer io.Writer, _ registry.TLSConfig, changeState bool, _ int) registry.ConcurrentRelocator {
+	return &Relocator{
+		skip:   !changeState,
+		writer: writer,
+	}
+}
+
+func (u UtilProvider) ImageWriter(writer io.Writer, _ registry.TLSConfig, changeState bool) registry.ImageWriter {
+	return &ImageWriter{
+		skip:   !changeState,
+		writer: writer,
+	}
+}
+
 func (u UtilProvider) Fetcher(_ registry.TLSConfig) registry.Fetcher {
 	return u.FakeFetcher
 }
@@ -27,3 +43,11 @@ func (u UtilProvider) Fetcher(_ registry.TLSConfig) registry.Fetcher {
 func (u UtilProvider) SourceUploader(writer io.Writer, tlsConfig registry.TLSConfig, changeState bool) registry.SourceUploader {
 	return NewFakeSourceUploader(writer, changeState)
 }
+
+func (u UtilProvider) RepositoryClient(_ registry.TLSConfig) registry.RepositoryClient {
+	return u.FakeRepositoryClient
+}
+
+func (u UtilProvider) RepositoryVerifier(_ registry.TLSConfig) registry.RepositoryVerifier {
+	return u.FakeRepositoryVerifier
+}
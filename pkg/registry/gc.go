@@ -0,0 +1,137 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// timestampTagPattern matches the tags created by timestampTag.
+var timestampTagPattern = regexp.MustCompile(`^\d{14}$`)
+
+type GarbageCollector struct {
+	Client RepositoryClient
+	Writer io.Writer
+	DryRun bool
+	Keep   int
+}
+
+// Collect deletes the images relocated by kp under defaultRepo whose digest is not in referenced,
+// always keeping the Keep most recent images of each repository. Images with tags not created by kp are never deleted.
+func (g GarbageCollector) Collect(keychain authn.Keychain, defaultRepo string, referenced []string) ([]string, error) {
+	repos, err := g.Client.ListRepositories(keychain, defaultRepo)
+	if err != nil {
+		// not every registry supports the catalog api
+		repos, err = referencedRepositories(defaultRepo, referenced)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(repos)
+
+	referencedDigests := map[string]struct{}{}
+	for _, ref := range referenced {
+		if parts := strings.Split(ref, "@"); len(parts) == 2 {
+			referencedDigests[parts[1]] = struct{}{}
+		}
+	}
+
+	var collected []string
+	for _, repo := range repos {
+		tags, err := g.Client.ListTags(keychain, repo)
+		if err != nil {
+			return collected, err
+		}
+
+		for _, digest := range unreferencedDigests(tags, referencedDigests, g.Keep) {
+			ref := fmt.Sprintf("%s@%s", repo, digest)
+			if g.DryRun {
+				if _, err := fmt.Fprintf(g.Writer, "\tSkipping delete of '%s'\n", ref); err != nil {
+					return collected, err
+				}
+			} else {
+				if _, err := fmt.Fprintf(g.Writer, "\tDeleting '%s'\n", ref); err != nil {
+					return collected, err
+				}
+
+				if err := g.Client.Delete(keychain, ref); err != nil {
+					return collected, err
+				}
+			}
+			collected = append(collected, ref)
+		}
+	}
+	return collected, nil
+}
+
+func referencedRepositories(defaultRepo string, referenced []string) ([]string, error) {
+	parentRepo, err := name.NewRepository(defaultRepo, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := map[string]struct{}{}
+	for _, ref := range referenced {
+		imageRepo, err := name.NewRepository(strings.Split(ref, "@")[0], name.WeakValidation)
+		if err != nil {
+			continue
+		}
+
+		repo := imageRepo.Name()
+		if repo == parentRepo.Name() || strings.HasPrefix(repo, parentRepo.Name()+"/") {
+			repos[repo] = struct{}{}
+		}
+	}
+
+	var result []string
+	for repo := range repos {
+		result = append(result, repo)
+	}
+	return result, nil
+}
+
+func unreferencedDigests(tags map[string]string, referenced map[string]struct{}, keep int) []string {
+	latestTags := map[string]string{}
+	foreign := map[string]struct{}{}
+	for tag, digest := range tags {
+		if !timestampTagPattern.MatchString(tag) {
+			foreign[digest] = struct{}{}
+			continue
+		}
+
+		if tag > latestTags[digest] {
+			latestTags[digest] = tag
+		}
+	}
+
+	var digests []string
+	for digest := range latestTags {
+		if _, ok := foreign[digest]; !ok {
+			digests = append(digests, digest)
+		}
+	}
+
+	sort.Slice(digests, func(i, j int) bool {
+		return latestTags[digests[i]] > latestTags[digests[j]]
+	})
+
+	var unreferenced []string
+	for i, digest := range digests {
+		if i < keep {
+			continue
+		}
+
+		if _, ok := referenced[digest]; !ok {
+			unreferenced = append(unreferenced, digest)
+		}
+	}
+	return unreferenced
+}
@@ -0,0 +1,97 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry/fakes"
+)
+
+func TestGarbageCollector(t *testing.T) {
+	spec.Run(t, "Test Garbage Collector", testGarbageCollector)
+}
+
+func testGarbageCollector(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeKeychain = &registryfakes.FakeKeychain{}
+		client       *fakes.RepositoryClient
+		out          *bytes.Buffer
+	)
+
+	it.Before(func() {
+		client = fakes.NewRepositoryClient()
+		client.AddTag("some-registry.io/repo/build", "20210101000000", "sha256:build-old")
+		client.AddTag("some-registry.io/repo/build", "20210201000000", "sha256:build-current")
+		client.AddTag("some-registry.io/repo/build", "20210301000000", "sha256:build-new")
+		client.AddTag("some-registry.io/repo/run", "20210101000000", "sha256:run-old")
+		client.AddTag("some-registry.io/repo/run", "20210201000000", "sha256:run-current")
+		client.AddTag("some-registry.io/repo/run", "latest", "sha256:run-latest")
+		client.AddTag("some-registry.io/other-repo/run", "20210101000000", "sha256:other")
+		out = &bytes.Buffer{}
+	})
+
+	referenced := []string{
+		"some-registry.io/repo/build@sha256:build-current",
+		"some-registry.io/repo/run@sha256:run-current",
+	}
+
+	it("deletes unreferenced images tagged by kp under the default repository", func() {
+		gc := registry.GarbageCollector{Client: client, Writer: out}
+
+		collected, err := gc.Collect(fakeKeychain, "some-registry.io/repo", referenced)
+		require.NoError(t, err)
+
+		expected := []string{
+			"some-registry.io/repo/build@sha256:build-new",
+			"some-registry.io/repo/build@sha256:build-old",
+			"some-registry.io/repo/run@sha256:run-old",
+		}
+		require.Equal(t, expected, collected)
+		require.Equal(t, expected, client.DeletedRefs())
+		require.Equal(t, "\tDeleting 'some-registry.io/repo/build@sha256:build-new'\n"+
+			"\tDeleting 'some-registry.io/repo/build@sha256:build-old'\n"+
+			"\tDeleting 'some-registry.io/repo/run@sha256:run-old'\n", out.String())
+	})
+
+	it("keeps the most recent images in each repository", func() {
+		gc := registry.GarbageCollector{Client: client, Writer: out, Keep: 1}
+
+		collected, err := gc.Collect(fakeKeychain, "some-registry.io/repo", referenced)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"some-registry.io/repo/build@sha256:build-old",
+			"some-registry.io/repo/run@sha256:run-old",
+		}, collected)
+	})
+
+	it("does not delete images with dry run", func() {
+		gc := registry.GarbageCollector{Client: client, Writer: out, DryRun: true}
+
+		collected, err := gc.Collect(fakeKeychain, "some-registry.io/repo", referenced)
+		require.NoError(t, err)
+		require.Len(t, collected, 3)
+		require.Empty(t, client.DeletedRefs())
+		require.Contains(t, out.String(), "\tSkipping delete of 'some-registry.io/repo/run@sha256:run-old'\n")
+	})
+
+	it("falls back to referenced repositories when the catalog is unavailable", func() {
+		client.CatalogErr = errors.New("catalog unsupported")
+		gc := registry.GarbageCollector{Client: client, Writer: out}
+
+		collected, err := gc.Collect(fakeKeychain, "some-registry.io/repo", []string{
+			"some-registry.io/repo/run@sha256:run-current",
+			"some-registry.io/other-repo/run@sha256:other",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"some-registry.io/repo/run@sha256:run-old"}, collected)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// ImageWriter writes an image to exactly the given tag. Unlike a Relocator, it does not write to the
+// repository of the destination with a generated tag.
+type ImageWriter interface {
+	Write(keychain authn.Keychain, src v1.Image, tag string) (string, error)
+}
+
+type DiscardImageWriter struct {
+	writer io.Writer
+}
+
+func NewDiscardImageWriter(writer io.Writer) DiscardImageWriter {
+	return DiscardImageWriter{writer: writer}
+}
+
+func (d DiscardImageWriter) Write(_ authn.Keychain, src v1.Image, tag string) (string, error) {
+	ref, digestRef, err := parseImageWriterTag(src, tag)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = d.writer.Write([]byte(fmt.Sprintf("\tSkipping '%s'\n", ref)))
+	return digestRef, err
+}
+
+type DefaultImageWriter struct {
+	tlsCfg TLSConfig
+	writer io.Writer
+}
+
+func NewDefaultImageWriter(writer io.Writer, tlsCfg TLSConfig) DefaultImageWriter {
+	return DefaultImageWriter{writer: writer, tlsCfg: tlsCfg}
+}
+
+// Write pushes the image to the tag and returns the digest reference of the pushed image.
+func (d DefaultImageWriter) Write(keychain authn.Keychain, src v1.Image, tag string) (string, error) {
+	ref, digestRef, err := parseImageWriterTag(src, tag)
+	if err != nil {
+		return "", err
+	}
+
+	size, err := imageSize(src)
+	if err != nil {
+		return "", err
+	}
+
+	transport, err := d.tlsCfg.Transport()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := d.writer.Write([]byte(fmt.Sprintf("\tUploading '%s'", ref))); err != nil {
+		return "", err
+	}
+
+	spinner := newUploadSpinner(d.writer, size)
+	defer spinner.Stop()
+	go spinner.Write()
+
+	err = remote.Write(ref, src, remote.WithAuthFromKeychain(keychain), remote.WithTransport(transport))
+	if err != nil {
+		return "", newImageAccessError(ref.Context().RegistryStr(), err)
+	}
+	return digestRef, nil
+}
+
+func parseImageWriterTag(src v1.Image, tag string) (name.Tag, string, error) {
+	ref, err := name.NewTag(tag, name.WeakValidation)
+	if err != nil {
+		return name.Tag{}, "", err
+	}
+
+	digest, err := src.Digest()
+	if err != nil {
+		return name.Tag{}, "", err
+	}
+
+	return ref, ref.Context().Digest(digest.String()).Name(), nil
+}
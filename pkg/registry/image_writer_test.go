@@ -0,0 +1,84 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func TestImageWriter(t *testing.T) {
+	spec.Run(t, "Test Image Writer", testImageWriter)
+}
+
+func testImageWriter(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		host   string
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(ioutil.Discard, "", 0))))
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		host = u.Host
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("writes the image to the tag", func() {
+		img, err := random.Image(10, 1)
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		ref, err := registry.NewDefaultImageWriter(&bytes.Buffer{}, registry.TLSConfig{}).Write(authn.DefaultKeychain, img, host+"/some-project/app:v1")
+		require.NoError(t, err)
+		require.Equal(t, host+"/some-project/app@"+digest.String(), ref)
+
+		tag, err := name.NewTag(host + "/some-project/app:v1")
+		require.NoError(t, err)
+		desc, err := remote.Head(tag)
+		require.NoError(t, err)
+		require.Equal(t, digest, desc.Digest)
+
+		tags, err := remote.List(tag.Context())
+		require.NoError(t, err)
+		require.Equal(t, []string{"v1"}, tags)
+	})
+
+	it("does not write the image when discarding", func() {
+		img, err := random.Image(10, 1)
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		out := &bytes.Buffer{}
+		ref, err := registry.NewDiscardImageWriter(out).Write(authn.DefaultKeychain, img, host+"/some-project/app:v1")
+		require.NoError(t, err)
+		require.Equal(t, host+"/some-project/app@"+digest.String(), ref)
+		require.Equal(t, "\tSkipping '"+host+"/some-project/app:v1'\n", out.String())
+
+		tag, err := name.NewTag(host + "/some-project/app:v1")
+		require.NoError(t, err)
+		_, err = remote.Head(tag)
+		require.Error(t, err)
+	})
+}
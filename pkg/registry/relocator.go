@@ -36,6 +36,10 @@ func (d DiscardRelocator) Relocate(keychain authn.Keychain, src v1.Image, destin
 	return cfg.refDigestStr, err
 }
 
+func (d DiscardRelocator) Wait() error {
+	return nil
+}
+
 type DefaultRelocator struct {
 	tlsCfg TLSConfig
 	writer io.Writer
@@ -51,14 +55,6 @@ func (d DefaultRelocator) Relocate(keychain authn.Keychain, src v1.Image, destin
 		return "", err
 	}
 
-	if _, err := d.writer.Write([]byte(fmt.Sprintf("\tUploading '%s'", cfg.refDigestStr))); err != nil {
-		return cfg.refDigestStr, err
-	}
-
-	spinner := newUploadSpinner(d.writer, cfg.size)
-	defer spinner.Stop()
-	go spinner.Write()
-
 	transport, err := d.tlsCfg.Transport()
 	if err != nil {
 		return cfg.refDigestStr, err
@@ -68,6 +64,21 @@ func (d DefaultRelocator) Relocate(keychain authn.Keychain, src v1.Image, destin
 		remote.WithTransport(transport),
 	}
 
+	if cfg.exists(imgWriteOptions...) {
+		if _, err := d.writer.Write([]byte(fmt.Sprintf("\tSkipping '%s' (already exists)\n", cfg.refDigestStr))); err != nil {
+			return cfg.refDigestStr, err
+		}
+		return cfg.refDigestStr, remote.Tag(cfg.tag, src, imgWriteOptions...)
+	}
+
+	if _, err := d.writer.Write([]byte(fmt.Sprintf("\tUploading '%s'", cfg.refDigestStr))); err != nil {
+		return cfg.refDigestStr, err
+	}
+
+	spinner := newUploadSpinner(d.writer, cfg.size)
+	defer spinner.Stop()
+	go spinner.Write()
+
 	err = remote.Write(cfg.refRepo, src, imgWriteOptions...)
 	if err != nil {
 		return cfg.refDigestStr, newImageAccessError(cfg.refRepo.Context().RegistryStr(), err)
@@ -79,10 +90,17 @@ func (d DefaultRelocator) Relocate(keychain authn.Keychain, src v1.Image, destin
 type relocateImageInfo struct {
 	refRepo      name.Reference
 	refDigestStr string
+	digest       v1.Hash
 	tag          name.Tag
 	size         int64
 }
 
+// exists reports whether the image is already in the destination repository, so identical images are not pushed again.
+func (i relocateImageInfo) exists(options ...remote.Option) bool {
+	_, err := remote.Head(i.refRepo.Context().Digest(i.digest.String()), options...)
+	return err == nil
+}
+
 func getDstImageInfo(srcImage v1.Image, dstRepoStr string) (relocateImageInfo, error) {
 	imgInfo := relocateImageInfo{}
 
@@ -112,6 +130,7 @@ func getDstImageInfo(srcImage v1.Image, dstRepoStr string) (relocateImageInfo, e
 	imgInfo = relocateImageInfo{
 		refRepo:      refDstRepo,
 		refDigestStr: fmt.Sprintf("%s@%s", refDstRepo, digest),
+		digest:       digest,
 		tag:          refDstRepo.Context().Tag(timestampTag()),
 		size:         size,
 	}
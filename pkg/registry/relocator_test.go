@@ -91,6 +91,42 @@ func testRelocateStackImages(t *testing.T, when spec.G, it spec.S) {
 			require.Equal(t, output.String(), fmt.Sprintf("\tUploading '%s'", relocatedRef))
 		})
 
+		it("should skip the upload of images that already exist in the dest registry", func() {
+			dstImageName := "dest-repo/an-image"
+			additionalTags := 0
+			dstRegistryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch path := r.URL.Path; {
+				case path == "/v2/":
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodHead && strings.HasPrefix(path, "/v2/"+dstImageName+"/manifests/sha256:"):
+					w.Header().Set("Content-Type", "application/vnd.docker.distribution.manifest.v2+json")
+					w.Header().Set("Docker-Content-Digest", strings.TrimPrefix(path, "/v2/"+dstImageName+"/manifests/"))
+					w.Header().Set("Content-Length", "1")
+					w.WriteHeader(http.StatusOK)
+				case regexp.MustCompile(fmt.Sprintf("/v2/%s/manifests/\\d{14}", dstImageName)).Match([]byte(path)):
+					additionalTags++
+					http.Error(w, "Created", http.StatusCreated)
+				default:
+					t.Fatalf("Unexpected request: %s %v", r.Method, r.URL.Path)
+				}
+			}))
+			defer dstRegistryServer.Close()
+
+			uri, err := url.Parse(dstRegistryServer.URL)
+			require.NoError(t, err)
+
+			srcImage, err := random.Image(int64(100), int64(5))
+			require.NoError(t, err)
+
+			output := &bytes.Buffer{}
+			relocator := registry.NewDefaultRelocator(output, registry.TLSConfig{})
+			relocatedRef, err := relocator.Relocate(fakeKeychain, srcImage, fmt.Sprintf("%s/%s", uri.Host, dstImageName))
+			require.NoError(t, err)
+
+			require.Equal(t, 1, additionalTags)
+			require.Equal(t, fmt.Sprintf("\tSkipping '%s' (already exists)\n", relocatedRef), output.String())
+		})
+
 		it("should error on invalid destination", func() {
 			srcImage, err := random.Image(int64(100), int64(5))
 			require.NoError(t, err)
@@ -0,0 +1,111 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+type RepositoryClient interface {
+	ListRepositories(keychain authn.Keychain, parent string) ([]string, error)
+	ListTags(keychain authn.Keychain, repository string) (map[string]string, error)
+	Delete(keychain authn.Keychain, ref string) error
+}
+
+type DefaultRepositoryClient struct {
+	tlsCfg TLSConfig
+}
+
+func NewDefaultRepositoryClient(tlsCfg TLSConfig) DefaultRepositoryClient {
+	return DefaultRepositoryClient{tlsCfg: tlsCfg}
+}
+
+// ListRepositories returns parent and every repository nested under it using the registry catalog API.
+func (d DefaultRepositoryClient) ListRepositories(keychain authn.Keychain, parent string) ([]string, error) {
+	parentRepo, err := name.NewRepository(parent, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := d.options(keychain)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := remote.Catalog(context.Background(), parentRepo.Registry, options...)
+	if err != nil {
+		return nil, newImageAccessError(parentRepo.RegistryStr(), err)
+	}
+
+	var repos []string
+	for _, repo := range catalog {
+		if repo == parentRepo.RepositoryStr() || strings.HasPrefix(repo, parentRepo.RepositoryStr()+"/") {
+			repos = append(repos, fmt.Sprintf("%s/%s", parentRepo.RegistryStr(), repo))
+		}
+	}
+	return repos, nil
+}
+
+// ListTags returns the digest of every tag in the repository keyed by tag.
+func (d DefaultRepositoryClient) ListTags(keychain authn.Keychain, repository string) (map[string]string, error) {
+	repo, err := name.NewRepository(repository, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := d.options(keychain)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := remote.List(repo, options...)
+	if err != nil {
+		return nil, newImageAccessError(repo.Name(), err)
+	}
+
+	digests := map[string]string{}
+	for _, tag := range tags {
+		desc, err := remote.Head(repo.Tag(tag), options...)
+		if err != nil {
+			return nil, newImageAccessError(repo.Tag(tag).Name(), err)
+		}
+		digests[tag] = desc.Digest.String()
+	}
+	return digests, nil
+}
+
+func (d DefaultRepositoryClient) Delete(keychain authn.Keychain, ref string) error {
+	imageRef, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	options, err := d.options(keychain)
+	if err != nil {
+		return err
+	}
+
+	if err := remote.Delete(imageRef, options...); err != nil {
+		return newImageAccessError(imageRef.Name(), err)
+	}
+	return nil
+}
+
+func (d DefaultRepositoryClient) options(keychain authn.Keychain) ([]remote.Option, error) {
+	transport, err := d.tlsCfg.Transport()
+	if err != nil {
+		return nil, err
+	}
+
+	return []remote.Option{
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithTransport(transport),
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func TestRepositoryClient(t *testing.T) {
+	spec.Run(t, "Test Repository Client", testRepositoryClient)
+}
+
+func testRepositoryClient(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		host   string
+		client registry.DefaultRepositoryClient
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(ioutil.Discard, "", 0))))
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		host = u.Host
+
+		client = registry.NewDefaultRepositoryClient(registry.TLSConfig{})
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("lists, resolves and deletes images under a repository", func() {
+		image, err := random.Image(10, 1)
+		require.NoError(t, err)
+		digest, err := image.Digest()
+		require.NoError(t, err)
+
+		for _, ref := range []string{"repo/build:20210101000000", "other-repo/build:20210101000000"} {
+			tag, err := name.NewTag(fmt.Sprintf("%s/%s", host, ref))
+			require.NoError(t, err)
+			require.NoError(t, remote.Write(tag, image))
+		}
+
+		repos, err := client.ListRepositories(authn.DefaultKeychain, host+"/repo")
+		require.NoError(t, err)
+		require.Equal(t, []string{host + "/repo/build"}, repos)
+
+		tags, err := client.ListTags(authn.DefaultKeychain, host+"/repo/build")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"20210101000000": digest.String()}, tags)
+
+		err = client.Delete(authn.DefaultKeychain, fmt.Sprintf("%s/repo/build@%s", host, digest))
+		require.NoError(t, err)
+
+		ref, err := name.ParseReference(fmt.Sprintf("%s/repo/build@%s", host, digest))
+		require.NoError(t, err)
+		_, err = remote.Head(ref)
+		require.Error(t, err)
+	})
+}
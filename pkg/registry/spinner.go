@@ -8,6 +8,8 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/ssh/terminal"
@@ -18,23 +20,70 @@ const framerate = time.Millisecond * 150
 var spinners = []string{"|", "/", "-", "\\"}
 
 type uploadSpinner struct {
-	size     string
-	stopChan chan struct{}
-	doneChan chan struct{}
-	Output   io.Writer
-	NotTty   bool
+	mu             sync.Mutex
+	uploads        map[*uploadProgress]struct{}
+	leadingNewline bool
+	stopChan       chan struct{}
+	doneChan       chan struct{}
+	Output         io.Writer
+	NotTty         bool
+}
+
+type uploadProgress struct {
+	total    int64
+	complete int64
+}
+
+func (p *uploadProgress) Update(complete, total int64) {
+	atomic.StoreInt64(&p.complete, complete)
+	atomic.StoreInt64(&p.total, total)
 }
 
 func newUploadSpinner(writer io.Writer, size int64) *uploadSpinner {
+	sp := newMultiUploadSpinner(writer)
+	sp.leadingNewline = true
+	sp.Add(size)
+	return sp
+}
+
+// newMultiUploadSpinner shows the combined progress of every upload added to it.
+// Lines must be written with Printlnf while it is running.
+func newMultiUploadSpinner(writer io.Writer) *uploadSpinner {
 	isTerminal := terminal.IsTerminal(int(os.Stdout.Fd())) || terminal.IsTerminal(int(os.Stderr.Fd()))
-	sp := &uploadSpinner{
-		size:     readableSize(size),
+	return &uploadSpinner{
+		uploads:  map[*uploadProgress]struct{}{},
 		stopChan: make(chan struct{}),
 		doneChan: make(chan struct{}),
 		Output:   writer,
 		NotTty:   !isTerminal,
 	}
-	return sp
+}
+
+func (s *uploadSpinner) Add(size int64) *uploadProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	progress := &uploadProgress{total: size}
+	s.uploads[progress] = struct{}{}
+	return progress
+}
+
+func (s *uploadSpinner) Remove(progress *uploadProgress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.uploads, progress)
+}
+
+func (s *uploadSpinner) Printlnf(format string, args ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.NotTty {
+		s.clear()
+	}
+	_, err := fmt.Fprintf(s.Output, format+"\n", args...)
+	return err
 }
 
 func (s *uploadSpinner) Stop() {
@@ -50,16 +99,23 @@ func (s *uploadSpinner) Write() {
 	}
 
 	index := 0
-	fmt.Fprint(s.Output, "\n")
+	if s.leadingNewline {
+		fmt.Fprint(s.Output, "\n")
+	}
 	for {
 		select {
 		case <-s.stopChan:
+			s.mu.Lock()
 			s.clear()
+			s.mu.Unlock()
 			return
 		case <-time.After(framerate):
+			s.mu.Lock()
 			s.clear()
-
-			fmt.Fprintf(s.Output, "\t %s %s", spinners[index], s.size)
+			if len(s.uploads) > 0 {
+				fmt.Fprintf(s.Output, "\t %s %s", spinners[index], s.status())
+			}
+			s.mu.Unlock()
 
 			index++
 			if index == len(spinners) {
@@ -69,6 +125,19 @@ func (s *uploadSpinner) Write() {
 	}
 }
 
+func (s *uploadSpinner) status() string {
+	var total, complete int64
+	for progress := range s.uploads {
+		total += atomic.LoadInt64(&progress.total)
+		complete += atomic.LoadInt64(&progress.complete)
+	}
+
+	if len(s.uploads) == 1 && complete == 0 {
+		return readableSize(total)
+	}
+	return fmt.Sprintf("%s / %s (%d uploads)", readableSize(complete), readableSize(total), len(s.uploads))
+}
+
 func (s *uploadSpinner) clear() {
 	fmt.Fprint(s.Output, "\033[2K")
 	fmt.Fprint(s.Output, "\n")
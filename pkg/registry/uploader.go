@@ -4,11 +4,13 @@
 package registry
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
-	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/pkg/errors"
 
@@ -16,32 +18,32 @@ import (
 )
 
 type SourceUploader interface {
-	Upload(keychain authn.Keychain, dstImgRefStr, srcPath string) (string, error)
+	Upload(keychain authn.Keychain, dstImgRefStr, srcPath string, excludes []string) (string, error)
 }
 
+// DefaultSourceUploader uploads a zip or a directory without the files excluded by its .gitignore
+// and .kpignore files, the .git directory and the exclude patterns. When ListFiles is set, the uploaded files of a
+// directory are listed to Writer.
 type DefaultSourceUploader struct {
 	Relocator Relocator
+	Writer    io.Writer
+	ListFiles bool
 }
 
-func (d DefaultSourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, srcPath string) (string, error) {
-	srcTarPath, err := readPathToTar(srcPath)
+func (d DefaultSourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, srcPath string, excludes []string) (string, error) {
+	srcTarPath, err := d.readPathToTar(srcPath, excludes)
 	if err != nil {
 		return "", err
 	}
 
 	defer os.Remove(srcTarPath)
 
-	image, err := random.Image(0, 0)
-	if err != nil {
-		return "", err
-	}
-
 	layer, err := tarball.LayerFromFile(srcTarPath)
 	if err != nil {
 		return "", err
 	}
 
-	image, err = mutate.AppendLayers(image, layer)
+	image, err := mutate.AppendLayers(empty.Image, layer)
 	if err != nil {
 		return "", err
 	}
@@ -49,7 +51,7 @@ func (d DefaultSourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, src
 	return d.Relocator.Relocate(keychain, image, dstImgRefStr)
 }
 
-func readPathToTar(path string) (string, error) {
+func (d DefaultSourceUploader) readPathToTar(path string, excludes []string) (string, error) {
 	fi, err := os.Stat(path)
 	if err != nil {
 		return "", err
@@ -61,5 +63,34 @@ func readPathToTar(path string) (string, error) {
 		return "", errors.New("local path must be a directory or zip")
 	}
 
-	return archive.CreateTar(path)
+	ignorer, err := archive.NewIgnorer(path, excludes...)
+	if err != nil {
+		return "", err
+	}
+
+	if d.ListFiles {
+		if err := d.listFiles(path, ignorer); err != nil {
+			return "", err
+		}
+	}
+
+	return archive.CreateTar(path, ignorer)
+}
+
+func (d DefaultSourceUploader) listFiles(path string, ignorer *archive.Ignorer) error {
+	files, err := archive.ListFiles(path, ignorer)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, f := range files {
+		if _, err := fmt.Fprintf(d.Writer, "\t\t%s (%s)\n", f.Path, readableSize(f.Size)); err != nil {
+			return err
+		}
+		total += f.Size
+	}
+
+	_, err = fmt.Fprintf(d.Writer, "\t%d file(s), %s\n", len(files), readableSize(total))
+	return err
 }
@@ -4,7 +4,12 @@
 package registry_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
 	"github.com/sclevine/spec"
@@ -20,8 +25,8 @@ func TestUploader(t *testing.T) {
 
 func testUploader(t *testing.T, when spec.G, it spec.S) {
 	const (
-		testdataDigest = "sha256:f261a0e333140b47e51e1cf5a045e704a47842d56ee33962770001582ac6656a"
-		testZipDigest  = "sha256:0a35946b7420d1f6bf0d1397c86cef1f00b08a8575d6045f20914a6845c1376c"
+		testdataDigest = "sha256:597b55be9adfcd62cb211ff461be4101e1d52a1a32c84a9838d21d15bdf99501"
+		testZipDigest  = "sha256:121b21dbf4640e003e430c30b6618e7817d2a9a6ebe50b54ece756e6a6c7f542"
 	)
 
 	when("Upload", func() {
@@ -33,7 +38,7 @@ func testUploader(t *testing.T, when spec.G, it spec.S) {
 		)
 
 		it("relocates local contents to registry", func() {
-			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample")
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample", nil)
 			require.NoError(t, err)
 
 			require.Equal(t, 1, fakeRelocator.CallCount())
@@ -45,7 +50,7 @@ func testUploader(t *testing.T, when spec.G, it spec.S) {
 		})
 
 		it("relocates local zip to registry", func() {
-			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample.zip")
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample.zip", nil)
 			require.NoError(t, err)
 
 			require.Equal(t, 1, fakeRelocator.CallCount())
@@ -56,8 +61,60 @@ func testUploader(t *testing.T, when spec.G, it spec.S) {
 			require.Equal(t, testZipDigest, digest.String())
 		})
 
+		it("uploads the same digest for unchanged sources", func() {
+			dir, err := ioutil.TempDir("", "uploader-test")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			appPath := filepath.Join(dir, "app.js")
+			require.NoError(t, ioutil.WriteFile(appPath, []byte("app"), 0644))
+
+			_, err = uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", dir, nil)
+			require.NoError(t, err)
+
+			later := time.Now().Add(time.Hour)
+			require.NoError(t, os.Chtimes(appPath, later, later))
+
+			_, err = uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", dir, nil)
+			require.NoError(t, err)
+
+			require.Equal(t, 2, fakeRelocator.CallCount())
+			_, first, _ := fakeRelocator.RelocateCall(0)
+			_, second, _ := fakeRelocator.RelocateCall(1)
+
+			firstDigest, err := first.Digest()
+			require.NoError(t, err)
+			secondDigest, err := second.Digest()
+			require.NoError(t, err)
+			require.Equal(t, firstDigest, secondDigest)
+		})
+
+		it("lists the uploaded files without the excluded files", func() {
+			out := &bytes.Buffer{}
+			listingUploader := registry.DefaultSourceUploader{
+				Relocator: fakeRelocator,
+				Writer:    out,
+				ListFiles: true,
+			}
+
+			dir, err := ioutil.TempDir("", "uploader-test")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".kpignore"), []byte(".env\n"), 0644))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("SECRET=value"), 0644))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.js"), []byte("app"), 0644))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.test.js"), []byte("test"), 0644))
+
+			_, err = listingUploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", dir, []string{"*.test.js"})
+			require.NoError(t, err)
+
+			require.Equal(t, "\t\t.kpignore (5 B)\n\t\tapp.js (3 B)\n\t2 file(s), 8 B\n", out.String())
+			require.Equal(t, 1, fakeRelocator.CallCount())
+		})
+
 		it("returns err on path to invalid zip", func() {
-			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample/app")
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample/app", nil)
 			require.EqualError(t, err, "local path must be a directory or zip")
 
 			require.Equal(t, 0, fakeRelocator.CallCount())
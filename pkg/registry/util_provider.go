@@ -7,8 +7,12 @@ import "io"
 
 type UtilProvider interface {
 	Relocator(writer io.Writer, tlsCfg TLSConfig, changeState bool) Relocator
+	ConcurrentRelocator(writer io.Writer, tlsCfg TLSConfig, changeState bool, workers int) ConcurrentRelocator
+	ImageWriter(writer io.Writer, tlsCfg TLSConfig, changeState bool) ImageWriter
 	SourceUploader(writer io.Writer, tlsCfg TLSConfig, changeState bool) SourceUploader
 	Fetcher(config TLSConfig) Fetcher
+	RepositoryClient(tlsCfg TLSConfig) RepositoryClient
+	RepositoryVerifier(tlsCfg TLSConfig) RepositoryVerifier
 }
 
 type DefaultUtilProvider struct{}
@@ -21,10 +25,38 @@ func (d DefaultUtilProvider) Relocator(writer io.Writer, tlsCfg TLSConfig, chang
 	}
 }
 
+func (d DefaultUtilProvider) ConcurrentRelocator(writer io.Writer, tlsCfg TLSConfig, changeState bool, workers int) ConcurrentRelocator {
+	if changeState {
+		return NewParallelRelocator(writer, tlsCfg, workers)
+	} else {
+		return NewDiscardRelocator(writer)
+	}
+}
+
+func (d DefaultUtilProvider) ImageWriter(writer io.Writer, tlsCfg TLSConfig, changeState bool) ImageWriter {
+	if changeState {
+		return NewDefaultImageWriter(writer, tlsCfg)
+	} else {
+		return NewDiscardImageWriter(writer)
+	}
+}
+
 func (d DefaultUtilProvider) SourceUploader(writer io.Writer, tlsCfg TLSConfig, changeState bool) SourceUploader {
-	return &DefaultSourceUploader{Relocator: d.Relocator(writer, tlsCfg, changeState)}
+	return &DefaultSourceUploader{
+		Relocator: d.Relocator(writer, tlsCfg, changeState),
+		Writer:    writer,
+		ListFiles: !changeState,
+	}
 }
 
 func (d DefaultUtilProvider) Fetcher(config TLSConfig) Fetcher {
 	return NewDefaultFetcher(config)
 }
+
+func (d DefaultUtilProvider) RepositoryClient(tlsCfg TLSConfig) RepositoryClient {
+	return NewDefaultRepositoryClient(tlsCfg)
+}
+
+func (d DefaultUtilProvider) RepositoryVerifier(tlsCfg TLSConfig) RepositoryVerifier {
+	return NewDefaultRepositoryVerifier(tlsCfg)
+}
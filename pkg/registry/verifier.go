@@ -0,0 +1,113 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+)
+
+const probeTagPrefix = "kp-verify-probe"
+
+// RepositoryVerifier checks that a repository is writable by pushing and deleting a small probe image.
+type RepositoryVerifier interface {
+	PushProbe(ctx context.Context, keychain authn.Keychain, repository string) (string, error)
+	DeleteProbe(ctx context.Context, keychain authn.Keychain, probe string) error
+}
+
+type DefaultRepositoryVerifier struct {
+	tlsCfg TLSConfig
+}
+
+func NewDefaultRepositoryVerifier(tlsCfg TLSConfig) DefaultRepositoryVerifier {
+	return DefaultRepositoryVerifier{tlsCfg: tlsCfg}
+}
+
+// PushProbe pushes a uniquely tagged probe image to the repository and returns its digest reference.
+// The probe is pushed to the repository itself because credentials are often scoped to a single repository.
+func (v DefaultRepositoryVerifier) PushProbe(ctx context.Context, keychain authn.Keychain, repository string) (string, error) {
+	tag, err := name.NewTag(fmt.Sprintf("%s:%s-%d", repository, probeTagPrefix, time.Now().UnixNano()), name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := random.Image(256, 1)
+	if err != nil {
+		return "", err
+	}
+
+	options, err := v.options(ctx, keychain)
+	if err != nil {
+		return "", err
+	}
+
+	if err := remote.Write(tag, img, options...); err != nil {
+		return "", newVerifyError(tag.RegistryStr(), "push to", tag.Context().Name(), err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return tag.Context().Digest(digest.String()).Name(), nil
+}
+
+func (v DefaultRepositoryVerifier) DeleteProbe(ctx context.Context, keychain authn.Keychain, probe string) error {
+	ref, err := name.ParseReference(probe, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	options, err := v.options(ctx, keychain)
+	if err != nil {
+		return err
+	}
+
+	if err := remote.Delete(ref, options...); err != nil {
+		return newVerifyError(ref.Context().RegistryStr(), "delete", ref.Name(), err)
+	}
+	return nil
+}
+
+func (v DefaultRepositoryVerifier) options(ctx context.Context, keychain authn.Keychain) ([]remote.Option, error) {
+	t, err := v.tlsCfg.Transport()
+	if err != nil {
+		return nil, err
+	}
+
+	return []remote.Option{
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithTransport(t),
+		remote.WithContext(ctx),
+	}, nil
+}
+
+// newVerifyError explains the TLS and authorization errors that are likely to be misconfigurations.
+func newVerifyError(registry, action, ref string, err error) error {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		transportErr     *transport.Error
+	)
+
+	switch {
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostname), errors.As(err, &invalid):
+		return errors.Errorf("TLS verification of registry %q failed: %s\nUse --registry-ca-cert-path to provide the CA certificate of the registry", registry, err)
+	case errors.As(err, &transportErr) && (transportErr.StatusCode == 401 || transportErr.StatusCode == 403):
+		return errors.Errorf("not authorized to %s %q: %s", action, ref, err)
+	}
+
+	return errors.Wrapf(err, "failed to %s %q", action, ref)
+}
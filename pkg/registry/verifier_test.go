@@ -0,0 +1,68 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
+)
+
+func TestRepositoryVerifier(t *testing.T) {
+	spec.Run(t, "Test Repository Verifier", testRepositoryVerifier)
+}
+
+func testRepositoryVerifier(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		host   string
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(ioutil.Discard, "", 0))))
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		host = u.Host
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("pushes and deletes a probe image", func() {
+		verifier := registry.NewDefaultRepositoryVerifier(registry.TLSConfig{})
+
+		probe, err := verifier.PushProbe(context.Background(), authn.DefaultKeychain, host+"/some-project")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(probe, host+"/some-project@sha256:"), probe)
+
+		ref, err := name.ParseReference(probe)
+		require.NoError(t, err)
+		_, err = remote.Head(ref)
+		require.NoError(t, err)
+
+		tags, err := remote.List(ref.Context())
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		require.True(t, strings.HasPrefix(tags[0], "kp-verify-probe-"), tags[0])
+
+		require.NoError(t, verifier.DeleteProbe(context.Background(), authn.DefaultKeychain, probe))
+
+		_, err = remote.Head(ref)
+		require.Error(t, err)
+	})
+}
@@ -3,11 +3,15 @@ package rootcommand
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/pivotal/kpack/pkg/logs"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
 
+	"github.com/vmware-tanzu/kpack-cli/pkg/build"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands"
+	applycmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/apply"
 	buildcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/build"
 	buildercmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/builder"
 	clusterbuildercmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/clusterbuilder"
@@ -17,11 +21,14 @@ import (
 	imgcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/image"
 	importcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/import"
 	"github.com/vmware-tanzu/kpack-cli/pkg/commands/lifecycle"
+	registrycmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/registry"
 	secretcmds "github.com/vmware-tanzu/kpack-cli/pkg/commands/secret"
+	"github.com/vmware-tanzu/kpack-cli/pkg/config"
 	importpkg "github.com/vmware-tanzu/kpack-cli/pkg/import"
 	"github.com/vmware-tanzu/kpack-cli/pkg/k8s"
 	"github.com/vmware-tanzu/kpack-cli/pkg/registry"
 	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+	"github.com/vmware-tanzu/kpack-cli/pkg/watch"
 )
 
 var (
@@ -30,7 +37,10 @@ var (
 )
 
 func GetRootCommand() *cobra.Command {
-	var clientSetProvider k8s.DefaultClientSetProvider
+	var (
+		clientSetProvider k8s.DefaultClientSetProvider
+		waitTimeout       time.Duration
+	)
 
 	rootCmd := &cobra.Command{
 		Use: "kp",
@@ -40,18 +50,49 @@ kpack extends Kubernetes and utilizes unprivileged kubernetes primitives to prov
 builds of OCI images as a platform implementation of Cloud Native Buildpacks (CNB).
 Learn more about kpack @ https://github.com/pivotal/kpack`,
 	}
+	userConfig, userConfigErr := config.ReadUserConfig(config.UserConfigPath())
+	clientSetProvider = clientSetProvider.WithDefaultNamespace(userConfig.DefaultNamespace())
+
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		if userConfigErr != nil {
+			return userConfigErr
+		}
+		return commands.SetDefaultCaCertPath(cmd, userConfig.RegistryCaCertPath())
+	}
+
+	rootCmd.PersistentFlags().DurationVar(&waitTimeout, commands.WaitTimeoutFlag, commands.DefaultWaitTimeout, "maximum time to wait for resources to become ready with --wait, image builds are only limited when it is set")
+
+	newWaiter := func(dc dynamic.Interface) commands.ResourceWaiter {
+		return commands.NewWaiter(dc, waitTimeout).WithProgress(rootCmd.ErrOrStderr(), commands.DefaultProgressInterval)
+	}
+
+	newImageWaiter := func(clientSet k8s.ClientSet) imgcmds.ImageWaiter {
+		imageWaiter := logs.NewImageWaiter(clientSet.KpackClient, logs.NewBuildLogsClient(clientSet.K8sClient))
+		// builds can take longer than the default timeout, so image waits are only limited by an explicit --wait-timeout
+		if !rootCmd.PersistentFlags().Changed(commands.WaitTimeoutFlag) {
+			return imageWaiter
+		}
+		return imgcmds.NewTimeoutImageWaiter(imageWaiter, waitTimeout)
+	}
+
+	newBuildWaiter := func(clientSet k8s.ClientSet) imgcmds.BuildWaiter {
+		return build.NewWaiter(clientSet.KpackClient, logs.NewBuildLogsClient(clientSet.K8sClient))
+	}
+
 	rootCmd.AddCommand(
 		getVersionCommand(),
-		getImageCommand(clientSetProvider),
+		getImageCommand(clientSetProvider, newImageWaiter, newBuildWaiter),
 		getBuildCommand(clientSetProvider),
 		getSecretCommand(clientSetProvider),
-		getClusterBuilderCommand(clientSetProvider),
-		getBuilderCommand(clientSetProvider),
-		getStackCommand(clientSetProvider),
-		getStoreCommand(clientSetProvider),
-		getLifecycleCommand(clientSetProvider),
-		getImportCommand(clientSetProvider),
+		getClusterBuilderCommand(clientSetProvider, newWaiter),
+		getBuilderCommand(clientSetProvider, newWaiter),
+		getStackCommand(clientSetProvider, newWaiter),
+		getStoreCommand(clientSetProvider, newWaiter),
+		getLifecycleCommand(clientSetProvider, newWaiter),
+		getImportCommand(clientSetProvider, newWaiter),
+		getApplyCommand(clientSetProvider, newWaiter),
 		getConfigCommand(clientSetProvider),
+		getRegistryCommand(clientSetProvider),
 		getCompletionCommand(),
 	)
 
@@ -69,11 +110,7 @@ func getVersionCommand() *cobra.Command {
 	return versionCmd
 }
 
-func getImageCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
-	newImageWaiter := func(clientSet k8s.ClientSet) imgcmds.ImageWaiter {
-		return logs.NewImageWaiter(clientSet.KpackClient, logs.NewBuildLogsClient(clientSet.K8sClient))
-	}
-
+func getImageCommand(clientSetProvider k8s.ClientSetProvider, newImageWaiter func(k8s.ClientSet) imgcmds.ImageWaiter, newBuildWaiter func(k8s.ClientSet) imgcmds.BuildWaiter) *cobra.Command {
 	imageRootCmd := &cobra.Command{
 		Use:     "image",
 		Short:   "Image commands",
@@ -81,12 +118,14 @@ func getImageCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	}
 	imageRootCmd.AddCommand(
 		imgcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter),
-		imgcmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter),
+		imgcmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter, commands.NewConfirmationProvider()),
 		imgcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter),
 		imgcmds.NewListCommand(clientSetProvider),
-		imgcmds.NewDeleteCommand(clientSetProvider),
-		imgcmds.NewTriggerCommand(clientSetProvider),
+		imgcmds.NewDeleteCommand(clientSetProvider, commands.NewConfirmationProvider()),
+		imgcmds.NewTriggerCommand(clientSetProvider, newBuildWaiter, commands.NewConfirmationProvider()),
 		imgcmds.NewStatusCommand(clientSetProvider),
+		imgcmds.NewPromoteCommand(clientSetProvider, registry.DefaultUtilProvider{}),
+		imgcmds.NewDevCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter, watch.Poller{Interval: 500 * time.Millisecond}),
 	)
 	return imageRootCmd
 }
@@ -120,20 +159,22 @@ func getSecretCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		secretcmds.NewCreateCommand(clientSetProvider, secretFactory),
 		secretcmds.NewDeleteCommand(clientSetProvider),
 		secretcmds.NewListCommand(clientSetProvider),
+		secretcmds.NewPatchCommand(clientSetProvider, &secret.Factory{CredentialFetcher: credentialFetcher}),
+		secretcmds.NewStatusCommand(clientSetProvider, secret.DefaultCredentialValidator{}),
 	)
 	return secretRootCmd
 }
 
-func getClusterBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getClusterBuilderCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	clusterBuilderRootCmd := &cobra.Command{
 		Use:     "clusterbuilder",
 		Short:   "ClusterBuilder Commands",
 		Aliases: []string{"clusterbuilders", "clstrbldrs", "clstrbldr", "cbldrs", "cbldr", "cbs", "cb"},
 	}
 	clusterBuilderRootCmd.AddCommand(
-		clusterbuildercmds.NewCreateCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterbuildercmds.NewPatchCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterbuildercmds.NewSaveCommand(clientSetProvider, commands.NewResourceWaiter),
+		clusterbuildercmds.NewCreateCommand(clientSetProvider, newWaiter),
+		clusterbuildercmds.NewPatchCommand(clientSetProvider, newWaiter),
+		clusterbuildercmds.NewSaveCommand(clientSetProvider, newWaiter),
 		clusterbuildercmds.NewListCommand(clientSetProvider),
 		clusterbuildercmds.NewStatusCommand(clientSetProvider),
 		clusterbuildercmds.NewDeleteCommand(clientSetProvider),
@@ -141,16 +182,16 @@ func getClusterBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Co
 	return clusterBuilderRootCmd
 }
 
-func getBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getBuilderCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	builderRootCmd := &cobra.Command{
 		Use:     "builder",
 		Short:   "Builder Commands",
 		Aliases: []string{"builders", "bldrs", "bldr"},
 	}
 	builderRootCmd.AddCommand(
-		buildercmds.NewCreateCommand(clientSetProvider, commands.NewResourceWaiter),
-		buildercmds.NewPatchCommand(clientSetProvider, commands.NewResourceWaiter),
-		buildercmds.NewSaveCommand(clientSetProvider, commands.NewResourceWaiter),
+		buildercmds.NewCreateCommand(clientSetProvider, newWaiter),
+		buildercmds.NewPatchCommand(clientSetProvider, newWaiter),
+		buildercmds.NewSaveCommand(clientSetProvider, newWaiter),
 		buildercmds.NewListCommand(clientSetProvider),
 		buildercmds.NewDeleteCommand(clientSetProvider),
 		buildercmds.NewStatusCommand(clientSetProvider),
@@ -158,62 +199,74 @@ func getBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	return builderRootCmd
 }
 
-func getStackCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getStackCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	stackRootCmd := &cobra.Command{
 		Use:     "clusterstack",
 		Aliases: []string{"clusterstacks", "clstrcsks", "clstrcsk", "cstacks", "cstack", "cstks", "cstk", "csks", "csk"},
 		Short:   "ClusterStack Commands",
 	}
 	stackRootCmd.AddCommand(
-		clusterstackcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
-		clusterstackcmds.NewUpdateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
-		clusterstackcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		clusterstackcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter),
+		clusterstackcmds.NewUpdateCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter),
+		clusterstackcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter),
 		clusterstackcmds.NewListCommand(clientSetProvider),
 		clusterstackcmds.NewStatusCommand(clientSetProvider),
+		clusterstackcmds.NewImpactCommand(clientSetProvider, registry.DefaultUtilProvider{}),
 		clusterstackcmds.NewDeleteCommand(clientSetProvider),
 	)
 	return stackRootCmd
 }
 
-func getStoreCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getStoreCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	storeRootCommand := &cobra.Command{
 		Use:     "clusterstore",
 		Aliases: []string{"clusterstores", "clstrcsrs", "clstrcsr", "cstores", "cstore", "cstrs", "cstr", "csrs", "csr"},
 		Short:   "ClusterStore Commands",
 	}
 	storeRootCommand.AddCommand(
-		clusterstorecmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
-		clusterstorecmds.NewAddCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
-		clusterstorecmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		clusterstorecmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter),
+		clusterstorecmds.NewAddCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter),
+		clusterstorecmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter),
 		clusterstorecmds.NewDeleteCommand(clientSetProvider, commands.NewConfirmationProvider()),
 		clusterstorecmds.NewStatusCommand(clientSetProvider),
-		clusterstorecmds.NewRemoveCommand(clientSetProvider, commands.NewResourceWaiter),
+		clusterstorecmds.NewRemoveCommand(clientSetProvider, newWaiter),
 		clusterstorecmds.NewListCommand(clientSetProvider),
 	)
 
 	return storeRootCommand
 }
 
-func getLifecycleCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getLifecycleCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	lifecycleRootCommand := &cobra.Command{
 		Use:   "lifecycle",
 		Short: "Lifecycle Commands",
 	}
 	lifecycleRootCommand.AddCommand(
-		lifecycle.NewUpdateCommand(clientSetProvider, registry.DefaultUtilProvider{}),
+		lifecycle.NewUpdateCommand(clientSetProvider, registry.DefaultUtilProvider{}, importpkg.DefaultTimestampProvider(), newWaiter),
+		lifecycle.NewStatusCommand(clientSetProvider, registry.DefaultUtilProvider{}),
+		lifecycle.NewHistoryCommand(clientSetProvider),
+		lifecycle.NewRollbackCommand(clientSetProvider, registry.DefaultUtilProvider{}, importpkg.DefaultTimestampProvider(), newWaiter),
 	)
 	return lifecycleRootCommand
 }
 
-func getImportCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
-	return importcmds.NewImportCommand(
+func getImportCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+	importCmd := importcmds.NewImportCommand(
 		commands.Differ{},
 		clientSetProvider,
 		registry.DefaultUtilProvider{},
 		importpkg.DefaultTimestampProvider(),
 		commands.NewConfirmationProvider(),
-		commands.NewResourceWaiter,
+		newWaiter,
 	)
+	importCmd.AddCommand(
+		importcmds.NewExportCommand(registry.DefaultUtilProvider{}),
+	)
+	return importCmd
+}
+
+func getApplyCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+	return applycmds.NewApplyCommand(clientSetProvider, registry.DefaultUtilProvider{}, newWaiter)
 }
 
 func getConfigCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
@@ -225,11 +278,26 @@ func getConfigCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	configRootCmd.AddCommand(
 		configcmds.NewDefaultRepositoryCommand(clientSetProvider),
 		configcmds.NewDefaultServiceAccountCommand(clientSetProvider),
+		configcmds.NewListCommand(clientSetProvider),
+		configcmds.NewShowCommand(clientSetProvider),
+		configcmds.NewUnsetCommand(clientSetProvider),
+		configcmds.NewVerifyCommand(clientSetProvider, registry.DefaultUtilProvider{}),
 	)
 
 	return configRootCmd
 }
 
+func getRegistryCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	registryRootCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Registry Commands",
+	}
+	registryRootCmd.AddCommand(
+		registrycmds.NewGCCommand(clientSetProvider, registry.DefaultUtilProvider{}),
+	)
+	return registryRootCmd
+}
+
 func getCompletionCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "completion [bash|zsh|fish|powershell]",
@@ -0,0 +1,55 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Credentials are the credentials stored in a registry or git secret.
+type Credentials struct {
+	Registry string
+	GitUrl   string
+	Username string
+	Password string
+	SshKey   []byte
+}
+
+func (c Credentials) Target() string {
+	if c.Registry != "" {
+		return c.Registry
+	}
+	return c.GitUrl
+}
+
+func ReadCredentials(s *corev1.Secret) (Credentials, error) {
+	switch s.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var configJson DockerConfigJson
+		if err := json.Unmarshal(s.Data[corev1.DockerConfigJsonKey], &configJson); err != nil {
+			return Credentials{}, errors.Wrapf(err, "secret %q has an invalid %s", s.Name, corev1.DockerConfigJsonKey)
+		}
+
+		if len(configJson.Auths) != 1 {
+			return Credentials{}, errors.Errorf("secret %q must contain credentials for exactly one registry, found %d", s.Name, len(configJson.Auths))
+		}
+
+		for registry, auth := range configJson.Auths {
+			return Credentials{Registry: registry, Username: auth.Username, Password: auth.Password}, nil
+		}
+	case corev1.SecretTypeSSHAuth:
+		return Credentials{GitUrl: s.Annotations[GitAnnotation], SshKey: s.Data[corev1.SSHAuthPrivateKey]}, nil
+	case corev1.SecretTypeBasicAuth:
+		return Credentials{
+			GitUrl:   s.Annotations[GitAnnotation],
+			Username: string(s.Data[corev1.BasicAuthUsernameKey]),
+			Password: string(s.Data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	}
+
+	return Credentials{}, errors.Errorf("secret %q has unsupported type %q", s.Name, s.Type)
+}
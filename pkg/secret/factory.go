@@ -6,6 +6,7 @@ package secret
 import (
 	"encoding/json"
 	"io/ioutil"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -20,6 +21,7 @@ const (
 	DockerhubUrl  = "https://index.docker.io/v1/"
 	GcrUrl        = "gcr.io"
 	GcrUser       = "_json_key"
+	EcrUser       = "AWS"
 	GitAnnotation = "kpack.io/git"
 )
 
@@ -33,6 +35,12 @@ type Factory struct {
 	Registry              string
 	RegistryUser          string
 	GcrServiceAccountFile string
+	EcrRegistry           string
+	AcrRegistry           string
+	GarRegistry           string
+	GarServiceAccountFile string
+	DockerConfigRegistry  string
+	Keychain              authn.Keychain
 	GitUrl                string
 	GitSshKeyFile         string
 	GitUser               string
@@ -55,6 +63,14 @@ func (f *Factory) MakeSecret(name, namespace string) (*corev1.Secret, string, er
 		return f.makeGcrSecret(name, namespace)
 	case registryKind:
 		return f.makeRegistrySecret(name, namespace)
+	case ecrKind:
+		return f.makeEcrSecret(name, namespace)
+	case acrKind:
+		return f.makeAcrSecret(name, namespace)
+	case garKind:
+		return f.makeGarSecret(name, namespace)
+	case dockerConfigKind:
+		return f.makeDockerConfigSecret(name, namespace)
 	case gitSshKind:
 		return f.makeGitSshSecret(name, namespace)
 	case gitBasicAuthKind:
@@ -69,13 +85,18 @@ func (f *Factory) validate() error {
 	set.add("dockerhub", f.DockerhubId)
 	set.add("registry", f.Registry)
 	set.add("gcr", f.GcrServiceAccountFile)
+	set.add("ecr", f.EcrRegistry)
+	set.add("acr", f.AcrRegistry)
+	set.add("gar", f.GarRegistry)
+	set.add("docker-config", f.DockerConfigRegistry)
 	set.add("git", f.GitUrl)
 
 	if len(set) != 1 {
-		return errors.Errorf("secret must be one of dockerhub, gcr, registry, or git")
+		return errors.Errorf("secret must be one of dockerhub, gcr, ecr, acr, gar, docker-config, registry, or git")
 	}
 
 	set.add("registry-user", f.RegistryUser)
+	set.add("gar-service-account", f.GarServiceAccountFile)
 	set.add("git-user", f.GitUser)
 	set.add("git-ssh-key", f.GitSshKeyFile)
 
@@ -87,6 +108,30 @@ func (f *Factory) validate() error {
 		return set.getExtraParamsError("gcr")
 	}
 
+	if set.contains("ecr") && len(set) != 1 {
+		return set.getExtraParamsError("ecr")
+	}
+
+	if set.contains("docker-config") && len(set) != 1 {
+		return set.getExtraParamsError("docker-config")
+	}
+
+	if set.contains("acr") {
+		if !set.contains("registry-user") {
+			return errors.Errorf("missing parameter registry-user")
+		} else if len(set) != 2 {
+			return set.getExtraParamsError("acr", "registry-user")
+		}
+	}
+
+	if set.contains("gar") {
+		if !set.contains("gar-service-account") {
+			return errors.Errorf("missing parameter gar-service-account")
+		} else if len(set) != 2 {
+			return set.getExtraParamsError("gar", "gar-service-account")
+		}
+	}
+
 	if set.contains("registry") {
 		if !set.contains("registry-user") {
 			return errors.Errorf("missing parameter registry-user")
@@ -105,6 +150,18 @@ func (f *Factory) validate() error {
 		}
 	}
 
+	if f.EcrRegistry != "" && !ecrRegistryRegex.MatchString(f.EcrRegistry) {
+		return errors.Errorf("must provide a valid ecr registry (ex. 123456789012.dkr.ecr.us-east-1.amazonaws.com)")
+	}
+
+	if f.AcrRegistry != "" && !strings.HasSuffix(f.AcrRegistry, acrRegistrySuffix) {
+		return errors.Errorf("must provide a valid acr registry (ex. my-registry.azurecr.io)")
+	}
+
+	if f.GarRegistry != "" && !strings.HasSuffix(f.GarRegistry, garRegistrySuffix) {
+		return errors.Errorf("must provide a valid gar registry (ex. us-docker.pkg.dev)")
+	}
+
 	if f.GitUser != "" && !(strings.HasPrefix(f.GitUrl, "http://") || strings.HasPrefix(f.GitUrl, "https://")) {
 		return errors.Errorf("must provide a valid git url for basic auth (ex. https://github.com)")
 	}
@@ -123,6 +180,14 @@ func (f *Factory) getSecretKind() (secretKind, error) {
 		return registryKind, nil
 	} else if f.GcrServiceAccountFile != "" {
 		return gcrKind, nil
+	} else if f.EcrRegistry != "" {
+		return ecrKind, nil
+	} else if f.AcrRegistry != "" && f.RegistryUser != "" {
+		return acrKind, nil
+	} else if f.GarRegistry != "" && f.GarServiceAccountFile != "" {
+		return garKind, nil
+	} else if f.DockerConfigRegistry != "" {
+		return dockerConfigKind, nil
 	} else if f.GitUrl != "" && f.GitSshKeyFile != "" {
 		return gitSshKind, nil
 	} else if f.GitUrl != "" && f.GitUser != "" {
@@ -137,27 +202,10 @@ func (f *Factory) makeDockerhubSecret(name, namespace string) (*corev1.Secret, s
 		return nil, "", err
 	}
 
-	configJson := DockerConfigJson{Auths: DockerCredentials{
-		DockerhubUrl: authn.AuthConfig{
-			Username: f.DockerhubId,
-			Password: password,
-		},
-	}}
-	dockerCfgJson, err := json.Marshal(configJson)
-	if err != nil {
-		return nil, "", err
-	}
-
-	return &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Data: map[string][]byte{
-			corev1.DockerConfigJsonKey: dockerCfgJson,
-		},
-		Type: corev1.SecretTypeDockerConfigJson,
-	}, DockerhubUrl, nil
+	return makeDockerConfigJsonSecret(name, namespace, DockerhubUrl, DockerhubUrl, authn.AuthConfig{
+		Username: f.DockerhubId,
+		Password: password,
+	})
 }
 
 func (f *Factory) makeGcrSecret(name string, namespace string) (*corev1.Secret, string, error) {
@@ -166,27 +214,10 @@ func (f *Factory) makeGcrSecret(name string, namespace string) (*corev1.Secret,
 		return nil, "", err
 	}
 
-	configJson := DockerConfigJson{Auths: DockerCredentials{
-		GcrUrl: authn.AuthConfig{
-			Username: GcrUser,
-			Password: string(password),
-		},
-	}}
-	dockerCfgJson, err := json.Marshal(configJson)
-	if err != nil {
-		return nil, "", err
-	}
-
-	return &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Data: map[string][]byte{
-			corev1.DockerConfigJsonKey: dockerCfgJson,
-		},
-		Type: corev1.SecretTypeDockerConfigJson,
-	}, GcrUrl, nil
+	return makeDockerConfigJsonSecret(name, namespace, GcrUrl, GcrUrl, authn.AuthConfig{
+		Username: GcrUser,
+		Password: string(password),
+	})
 }
 
 func (f *Factory) makeRegistrySecret(secretName string, namespace string) (*corev1.Secret, string, error) {
@@ -205,11 +236,85 @@ func (f *Factory) makeRegistrySecret(secretName string, namespace string) (*core
 		reg = r.RegistryStr()
 	}
 
+	return makeDockerConfigJsonSecret(secretName, namespace, reg, f.Registry, authn.AuthConfig{
+		Username: f.RegistryUser,
+		Password: password,
+	})
+}
+
+func (f *Factory) makeEcrSecret(name, namespace string) (*corev1.Secret, string, error) {
+	password, err := f.CredentialFetcher.FetchPassword("ECR_PASSWORD", "ecr password: ")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return makeDockerConfigJsonSecret(name, namespace, f.EcrRegistry, f.EcrRegistry, authn.AuthConfig{
+		Username: EcrUser,
+		Password: password,
+	})
+}
+
+func (f *Factory) makeAcrSecret(name, namespace string) (*corev1.Secret, string, error) {
+	password, err := f.CredentialFetcher.FetchPassword("REGISTRY_PASSWORD", "acr password: ")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return makeDockerConfigJsonSecret(name, namespace, f.AcrRegistry, f.AcrRegistry, authn.AuthConfig{
+		Username: f.RegistryUser,
+		Password: password,
+	})
+}
+
+func (f *Factory) makeGarSecret(name, namespace string) (*corev1.Secret, string, error) {
+	password, err := ioutil.ReadFile(f.GarServiceAccountFile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return makeDockerConfigJsonSecret(name, namespace, f.GarRegistry, f.GarRegistry, authn.AuthConfig{
+		Username: GcrUser,
+		Password: string(password),
+	})
+}
+
+// makeDockerConfigSecret copies the credentials of the registry from the docker config or its credential helper.
+func (f *Factory) makeDockerConfigSecret(secretName, namespace string) (*corev1.Secret, string, error) {
+	reg, err := name.NewRegistry(f.DockerConfigRegistry, name.WeakValidation)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keychain := f.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	authenticator, err := keychain.Resolve(reg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if authenticator == authn.Anonymous {
+		return nil, "", errors.Errorf("no credentials found for registry %q in the docker config", f.DockerConfigRegistry)
+	}
+
+	auth, err := authenticator.Authorization()
+	if err != nil {
+		return nil, "", err
+	}
+
+	host := f.DockerConfigRegistry
+	if reg.RegistryStr() == name.DefaultRegistry {
+		host = DockerhubUrl
+	}
+
+	return makeDockerConfigJsonSecret(secretName, namespace, host, f.DockerConfigRegistry, *auth)
+}
+
+func makeDockerConfigJsonSecret(name, namespace, registry, target string, auth authn.AuthConfig) (*corev1.Secret, string, error) {
 	configJson := DockerConfigJson{Auths: DockerCredentials{
-		reg: authn.AuthConfig{
-			Username: f.RegistryUser,
-			Password: password,
-		},
+		registry: auth,
 	}}
 	dockerCfgJson, err := json.Marshal(configJson)
 	if err != nil {
@@ -218,14 +323,14 @@ func (f *Factory) makeRegistrySecret(secretName string, namespace string) (*core
 
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
+			Name:      name,
 			Namespace: namespace,
 		},
 		Data: map[string][]byte{
 			corev1.DockerConfigJsonKey: dockerCfgJson,
 		},
 		Type: corev1.SecretTypeDockerConfigJson,
-	}, f.Registry, nil
+	}, target, nil
 }
 
 func (f *Factory) makeGitSshSecret(name string, namespace string) (*corev1.Secret, string, error) {
@@ -271,12 +376,23 @@ func (f *Factory) makeGitBasicAuthSecret(name string, namespace string) (*corev1
 	}, f.GitUrl, nil
 }
 
+const (
+	acrRegistrySuffix = ".azurecr.io"
+	garRegistrySuffix = "-docker.pkg.dev"
+)
+
+var ecrRegistryRegex = regexp.MustCompile(`^[0-9]{12}\.dkr\.ecr(-fips)?\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
 type secretKind string
 
 const (
 	dockerHubKind    secretKind = "dockerhub"
 	gcrKind                     = "gcr"
 	registryKind                = "registry"
+	ecrKind                     = "ecr"
+	acrKind                     = "acr"
+	garKind                     = "gar"
+	dockerConfigKind            = "docker config"
 	gitSshKind                  = "git ssh"
 	gitBasicAuthKind            = "git basic auth"
 )
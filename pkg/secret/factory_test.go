@@ -4,10 +4,14 @@
 package secret_test
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
 )
@@ -36,7 +40,7 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 	when("no params are set", func() {
 		it("returns an error message", func() {
 			_, _, err := factory.MakeSecret("test-name", "test-namespace")
-			require.EqualError(t, err, "secret must be one of dockerhub, gcr, registry, or git")
+			require.EqualError(t, err, "secret must be one of dockerhub, gcr, ecr, acr, gar, docker-config, registry, or git")
 		})
 	})
 
@@ -45,7 +49,7 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 			factory.DockerhubId = "some-dockerhub-id"
 			factory.GcrServiceAccountFile = "some-gcr-service-account"
 			_, _, err := factory.MakeSecret("test-name", "test-namespace")
-			require.EqualError(t, err, "secret must be one of dockerhub, gcr, registry, or git")
+			require.EqualError(t, err, "secret must be one of dockerhub, gcr, ecr, acr, gar, docker-config, registry, or git")
 		})
 	})
 
@@ -138,6 +142,104 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("ecr is provided", func() {
+		it("makes a registry secret for the AWS user", func() {
+			factory.EcrRegistry = "123456789012.dkr.ecr.us-east-1.amazonaws.com"
+			s, target, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Equal(t, "123456789012.dkr.ecr.us-east-1.amazonaws.com", target)
+			require.Equal(t, corev1.SecretTypeDockerConfigJson, s.Type)
+			require.Equal(t, `{"auths":{"123456789012.dkr.ecr.us-east-1.amazonaws.com":{"username":"AWS","password":"foo"}}}`, string(s.Data[".dockerconfigjson"]))
+		})
+
+		it("validates the ecr registry", func() {
+			factory.EcrRegistry = "registry.io"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "must provide a valid ecr registry (ex. 123456789012.dkr.ecr.us-east-1.amazonaws.com)")
+		})
+
+		it("does not accept a registry user", func() {
+			factory.EcrRegistry = "123456789012.dkr.ecr.us-east-1.amazonaws.com"
+			factory.RegistryUser = "some-reg-user"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "extraneous parameters: registry-user")
+		})
+	})
+
+	when("acr is provided", func() {
+		it("makes a registry secret for the service principal", func() {
+			factory.AcrRegistry = "my-registry.azurecr.io"
+			factory.RegistryUser = "some-service-principal"
+			s, target, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Equal(t, "my-registry.azurecr.io", target)
+			require.Equal(t, `{"auths":{"my-registry.azurecr.io":{"username":"some-service-principal","password":"foo"}}}`, string(s.Data[".dockerconfigjson"]))
+		})
+
+		it("requires a registry user", func() {
+			factory.AcrRegistry = "my-registry.azurecr.io"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "missing parameter registry-user")
+		})
+
+		it("validates the acr registry", func() {
+			factory.AcrRegistry = "registry.io"
+			factory.RegistryUser = "some-service-principal"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "must provide a valid acr registry (ex. my-registry.azurecr.io)")
+		})
+	})
+
+	when("gar is provided", func() {
+		it("makes a registry secret with the service account key", func() {
+			keyFile, err := ioutil.TempFile("", "gar-service-account")
+			require.NoError(t, err)
+			defer os.Remove(keyFile.Name())
+			_, err = keyFile.WriteString(`{"some-key":"some-value"}`)
+			require.NoError(t, err)
+			require.NoError(t, keyFile.Close())
+
+			factory.GarRegistry = "us-docker.pkg.dev"
+			factory.GarServiceAccountFile = keyFile.Name()
+			s, target, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Equal(t, "us-docker.pkg.dev", target)
+			require.Equal(t, `{"auths":{"us-docker.pkg.dev":{"username":"_json_key","password":"{\"some-key\":\"some-value\"}"}}}`, string(s.Data[".dockerconfigjson"]))
+		})
+
+		it("requires a service account key", func() {
+			factory.GarRegistry = "us-docker.pkg.dev"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "missing parameter gar-service-account")
+		})
+	})
+
+	when("docker-config is provided", func() {
+		it("copies the credentials of the registry from the keychain", func() {
+			factory.DockerConfigRegistry = "registry.io"
+			factory.Keychain = fakeKeychain{"registry.io": &authn.Basic{Username: "some-user", Password: "some-password"}}
+			s, target, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Equal(t, "registry.io", target)
+			require.Equal(t, `{"auths":{"registry.io":{"username":"some-user","password":"some-password"}}}`, string(s.Data[".dockerconfigjson"]))
+		})
+
+		it("uses the dockerhub url for dockerhub credentials", func() {
+			factory.DockerConfigRegistry = "index.docker.io"
+			factory.Keychain = fakeKeychain{"index.docker.io": &authn.Basic{Username: "some-user", Password: "some-password"}}
+			s, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Equal(t, `{"auths":{"https://index.docker.io/v1/":{"username":"some-user","password":"some-password"}}}`, string(s.Data[".dockerconfigjson"]))
+		})
+
+		it("returns an error when the registry has no credentials", func() {
+			factory.DockerConfigRegistry = "registry.io"
+			factory.Keychain = fakeKeychain{}
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, `no credentials found for registry "registry.io" in the docker config`)
+		})
+	})
+
 	when("using git ssh keys", func() {
 		it("validates that the git url begins with git@", func() {
 			factory.GitUrl = "some-git"
@@ -148,6 +250,15 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 	})
 }
 
+type fakeKeychain map[string]authn.Authenticator
+
+func (k fakeKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	if auth, ok := k[resource.RegistryStr()]; ok {
+		return auth, nil
+	}
+	return authn.Anonymous, nil
+}
+
 type fakeCredentialFetcher struct {
 	pw string
 }
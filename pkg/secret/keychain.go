@@ -0,0 +1,92 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ServiceAccountKeychain resolves registry credentials from the docker config secrets of a service account.
+type ServiceAccountKeychain struct {
+	credentials DockerCredentials
+}
+
+func NewServiceAccountKeychain(ctx context.Context, client kubernetes.Interface, serviceAccount corev1.ObjectReference) (ServiceAccountKeychain, error) {
+	sa, err := client.CoreV1().ServiceAccounts(serviceAccount.Namespace).Get(ctx, serviceAccount.Name, metav1.GetOptions{})
+	if err != nil {
+		return ServiceAccountKeychain{}, err
+	}
+
+	var refs []string
+	for _, s := range sa.Secrets {
+		refs = append(refs, s.Name)
+	}
+	for _, s := range sa.ImagePullSecrets {
+		refs = append(refs, s.Name)
+	}
+
+	keychain := ServiceAccountKeychain{credentials: DockerCredentials{}}
+	for _, ref := range refs {
+		s, err := client.CoreV1().Secrets(serviceAccount.Namespace).Get(ctx, ref, metav1.GetOptions{})
+		if err != nil {
+			return ServiceAccountKeychain{}, err
+		}
+
+		if s.Type != corev1.SecretTypeDockerConfigJson {
+			continue
+		}
+
+		var configJson DockerConfigJson
+		if err := json.Unmarshal(s.Data[corev1.DockerConfigJsonKey], &configJson); err != nil {
+			return ServiceAccountKeychain{}, errors.Wrapf(err, "secret %q has an invalid %s", s.Name, corev1.DockerConfigJsonKey)
+		}
+
+		for registry, auth := range configJson.Auths {
+			host := registryHost(registry)
+			if _, ok := keychain.credentials[host]; !ok {
+				keychain.credentials[host] = auth
+			}
+		}
+	}
+
+	return keychain, nil
+}
+
+// HasCredentials reports whether the service account has credentials for the registry.
+func (k ServiceAccountKeychain) HasCredentials(registry string) bool {
+	_, ok := k.credentials[registryHost(registry)]
+	return ok
+}
+
+func (k ServiceAccountKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	auth, ok := k.credentials[registryHost(resource.RegistryStr())]
+	if !ok {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(auth), nil
+}
+
+// registryHost strips the scheme and path of a docker config registry, docker hub is "index.docker.io".
+func registryHost(registry string) string {
+	host := registry
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+
+	if host == "docker.io" || host == "registry-1.docker.io" {
+		return "index.docker.io"
+	}
+	return host
+}
@@ -0,0 +1,71 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+)
+
+func TestServiceAccountKeychain(t *testing.T) {
+	spec.Run(t, "TestServiceAccountKeychain", testServiceAccountKeychain)
+}
+
+func testServiceAccountKeychain(t *testing.T, when spec.G, it spec.S) {
+	dockerConfigSecret := func(name, auths string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-namespace"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(auths)},
+		}
+	}
+
+	client := k8sfakes.NewSimpleClientset(
+		&corev1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "some-sa", Namespace: "some-namespace"},
+			Secrets:          []corev1.ObjectReference{{Name: "dockerhub-secret"}, {Name: "git-secret"}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-secret"}},
+		},
+		dockerConfigSecret("dockerhub-secret", `{"auths":{"https://index.docker.io/v1/":{"username":"some-user","password":"some-password"}}}`),
+		dockerConfigSecret("registry-secret", `{"auths":{"some-registry.io:5000":{"username":"other-user","password":"other-password"}}}`),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-secret", Namespace: "some-namespace"},
+			Type:       corev1.SecretTypeBasicAuth,
+		},
+	)
+
+	resolve := func(keychain authn.Keychain, ref string) *authn.AuthConfig {
+		repo, err := name.NewRepository(ref)
+		require.NoError(t, err)
+
+		auth, err := keychain.Resolve(repo)
+		require.NoError(t, err)
+
+		config, err := auth.Authorization()
+		require.NoError(t, err)
+		return config
+	}
+
+	it("resolves the docker config credentials of the service account secrets", func() {
+		keychain, err := secret.NewServiceAccountKeychain(context.Background(), client, corev1.ObjectReference{Name: "some-sa", Namespace: "some-namespace"})
+		require.NoError(t, err)
+
+		require.Equal(t, "some-user", resolve(keychain, "some-user/some-repo").Username)
+		require.Equal(t, "other-user", resolve(keychain, "some-registry.io:5000/some-repo").Username)
+		require.Equal(t, &authn.AuthConfig{}, resolve(keychain, "gcr.io/some-repo"))
+
+		require.True(t, keychain.HasCredentials("index.docker.io"))
+		require.False(t, keychain.HasCredentials("gcr.io"))
+	})
+}
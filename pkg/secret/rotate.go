@@ -0,0 +1,102 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RotateSecret returns a copy of the secret with new credentials for the same registry or git url.
+// The user defaults to the user of the secret and can be changed with RegistryUser or GitUser.
+func (f *Factory) RotateSecret(existing *corev1.Secret) (*corev1.Secret, error) {
+	creds, err := ReadCredentials(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err := f.rotationFactory(existing, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	s, _, err := rotation.MakeSecret(existing.Name, existing.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated := existing.DeepCopy()
+	rotated.Data = s.Data
+	return rotated, nil
+}
+
+func (f *Factory) rotationFactory(existing *corev1.Secret, creds Credentials) (*Factory, error) {
+	set := paramSet{}
+	set.add("registry-user", f.RegistryUser)
+	set.add("gcr", f.GcrServiceAccountFile)
+	set.add("gar-service-account", f.GarServiceAccountFile)
+	set.add("git-user", f.GitUser)
+	set.add("git-ssh-key", f.GitSshKeyFile)
+
+	rotation := &Factory{CredentialFetcher: f.CredentialFetcher}
+
+	switch {
+	case creds.Registry == DockerhubUrl:
+		if len(set) > 0 && !(set.contains("registry-user") && len(set) == 1) {
+			return nil, set.getExtraParamsError("registry-user")
+		}
+		rotation.DockerhubId = valueOrDefault(f.RegistryUser, creds.Username)
+	case creds.Registry == GcrUrl && creds.Username == GcrUser:
+		if !set.contains("gcr") {
+			return nil, errors.New("missing parameter gcr")
+		} else if len(set) != 1 {
+			return nil, set.getExtraParamsError("gcr")
+		}
+		rotation.GcrServiceAccountFile = f.GcrServiceAccountFile
+	case strings.HasSuffix(creds.Registry, garRegistrySuffix) && creds.Username == GcrUser:
+		if !set.contains("gar-service-account") {
+			return nil, errors.New("missing parameter gar-service-account")
+		} else if len(set) != 1 {
+			return nil, set.getExtraParamsError("gar-service-account")
+		}
+		rotation.GarRegistry = creds.Registry
+		rotation.GarServiceAccountFile = f.GarServiceAccountFile
+	case ecrRegistryRegex.MatchString(creds.Registry) && creds.Username == EcrUser:
+		if len(set) > 0 {
+			return nil, set.getExtraParamsError()
+		}
+		rotation.EcrRegistry = creds.Registry
+	case creds.Registry != "":
+		if len(set) > 0 && !(set.contains("registry-user") && len(set) == 1) {
+			return nil, set.getExtraParamsError("registry-user")
+		}
+		rotation.Registry = creds.Registry
+		rotation.RegistryUser = valueOrDefault(f.RegistryUser, creds.Username)
+	case existing.Type == corev1.SecretTypeSSHAuth:
+		if !set.contains("git-ssh-key") {
+			return nil, errors.New("missing parameter git-ssh-key")
+		} else if len(set) != 1 {
+			return nil, set.getExtraParamsError("git-ssh-key")
+		}
+		rotation.GitUrl = creds.GitUrl
+		rotation.GitSshKeyFile = f.GitSshKeyFile
+	default:
+		if len(set) > 0 && !(set.contains("git-user") && len(set) == 1) {
+			return nil, set.getExtraParamsError("git-user")
+		}
+		rotation.GitUrl = creds.GitUrl
+		rotation.GitUser = valueOrDefault(f.GitUser, creds.Username)
+	}
+
+	return rotation, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	return defaultValue
+}
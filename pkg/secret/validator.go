@@ -0,0 +1,199 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const validationTimeout = 30 * time.Second
+
+type CredentialValidator interface {
+	// Validate logs in to the registry or git host of the credentials and prints warnings to the writer.
+	// Git basic auth credentials are checked against the repository, which is a path on the git url.
+	Validate(ctx context.Context, writer io.Writer, creds Credentials, gitRepo string) error
+}
+
+// DefaultCredentialValidator verifies git ssh host keys with the KnownHostsFile, which defaults to ~/.ssh/known_hosts.
+type DefaultCredentialValidator struct {
+	KnownHostsFile string
+}
+
+func (v DefaultCredentialValidator) Validate(ctx context.Context, writer io.Writer, creds Credentials, gitRepo string) error {
+	ctx, cancel := context.WithTimeout(ctx, validationTimeout)
+	defer cancel()
+
+	switch {
+	case creds.Registry != "":
+		return validateRegistry(ctx, creds)
+	case len(creds.SshKey) > 0:
+		return v.validateGitSsh(ctx, writer, creds)
+	default:
+		return validateGitBasicAuth(ctx, creds, gitRepo)
+	}
+}
+
+func validateRegistry(ctx context.Context, creds Credentials) error {
+	host := creds.Registry
+	if creds.Registry == DockerhubUrl {
+		host = name.DefaultRegistry
+	}
+
+	reg, err := name.NewRegistry(host, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	auth := authn.FromConfig(authn.AuthConfig{Username: creds.Username, Password: creds.Password})
+	rt, err := transport.NewWithContext(ctx, reg, auth, http.DefaultTransport, []string{reg.Scope(transport.PullScope)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to log in to %s", reg.RegistryStr())
+	}
+
+	return checkResponse(ctx, &http.Client{Transport: rt}, fmt.Sprintf("%s://%s/v2/", reg.Scheme(), reg.RegistryStr()), reg.RegistryStr())
+}
+
+func validateGitBasicAuth(ctx context.Context, creds Credentials, gitRepo string) error {
+	if gitRepo == "" {
+		return errors.New("git-repo is required to validate git basic auth credentials")
+	}
+
+	repoUrl := strings.TrimSuffix(creds.GitUrl, "/") + "/" + strings.TrimPrefix(gitRepo, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repoUrl+"/info/refs?service=git-upload-pack", nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(creds.Username, creds.Password)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to log in to %s: %s", repoUrl, resp.Status)
+	}
+	return nil
+}
+
+func (v DefaultCredentialValidator) validateGitSsh(ctx context.Context, writer io.Writer, creds Credentials) error {
+	signer, err := ssh.ParsePrivateKey(creds.SshKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid ssh private key")
+	}
+
+	user, host, port := parseSshUrl(creds.GitUrl)
+	hostKeyCallback, err := v.hostKeyCallback(writer, host)
+	if err != nil {
+		return err
+	}
+
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, net.JoinHostPort(host, port), config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to log in to %s", host)
+	}
+	return ssh.NewClient(c, chans, reqs).Close()
+}
+
+// hostKeyCallback verifies host keys with the known hosts file. Without a known hosts file the host key
+// is not verified, so the public key and the signature of the login may be sent to an impersonated host.
+func (v DefaultCredentialValidator) hostKeyCallback(writer io.Writer, host string) (ssh.HostKeyCallback, error) {
+	knownHostsFile := v.KnownHostsFile
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	if _, err := os.Stat(knownHostsFile); os.IsNotExist(err) {
+		_, err := fmt.Fprintf(writer, "Warning: the host key of %s is not verified, %s does not exist\n", host, knownHostsFile)
+		return ssh.InsecureIgnoreHostKey(), err
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", knownHostsFile)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return errors.Errorf("the host key of %s is not in %s", host, knownHostsFile)
+		}
+		return err
+	}, nil
+}
+
+// parseSshUrl reads git ssh urls of the form user@host or ssh://user@host:port
+func parseSshUrl(gitUrl string) (string, string, string) {
+	if u, err := url.Parse(gitUrl); err == nil && u.Scheme == "ssh" {
+		port := u.Port()
+		if port == "" {
+			port = "22"
+		}
+		return u.User.Username(), u.Hostname(), port
+	}
+
+	user, host := "git", gitUrl
+	if i := strings.Index(gitUrl, "@"); i >= 0 {
+		user, host = gitUrl[:i], gitUrl[i+1:]
+	}
+	return user, strings.SplitN(host, ":", 2)[0], "22"
+}
+
+func checkResponse(ctx context.Context, client *http.Client, u, registry string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to log in to %s: %s", registry, resp.Status)
+	}
+	return nil
+}
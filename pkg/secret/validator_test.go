@@ -0,0 +1,135 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/secret"
+)
+
+func TestCredentialValidator(t *testing.T) {
+	spec.Run(t, "TestCredentialValidator", testCredentialValidator)
+}
+
+func testCredentialValidator(t *testing.T, when spec.G, it spec.S) {
+	when("validating git ssh credentials", func() {
+		var (
+			listener       net.Listener
+			hostKey        ssh.Signer
+			creds          secret.Credentials
+			knownHostsFile string
+			output         *bytes.Buffer
+		)
+
+		newKey := func() *rsa.PrivateKey {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+			return key
+		}
+
+		it.Before(func() {
+			var err error
+			hostKey, err = ssh.NewSignerFromKey(newKey())
+			require.NoError(t, err)
+
+			config := &ssh.ServerConfig{
+				PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+					return nil, nil
+				},
+			}
+			config.AddHostKey(hostKey)
+
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						defer conn.Close()
+						if _, chans, reqs, err := ssh.NewServerConn(conn, config); err == nil {
+							go ssh.DiscardRequests(reqs)
+							for range chans {
+							}
+						}
+					}()
+				}
+			}()
+
+			creds = secret.Credentials{
+				GitUrl: "ssh://git@" + listener.Addr().String(),
+				SshKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(newKey())}),
+			}
+
+			dir, err := ioutil.TempDir("", "known-hosts")
+			require.NoError(t, err)
+			knownHostsFile = filepath.Join(dir, "known_hosts")
+
+			output = &bytes.Buffer{}
+		})
+
+		it.After(func() {
+			require.NoError(t, listener.Close())
+			require.NoError(t, os.RemoveAll(filepath.Dir(knownHostsFile)))
+		})
+
+		writeKnownHosts := func(key ssh.PublicKey) {
+			line := knownhosts.Line([]string{knownhosts.Normalize(listener.Addr().String())}, key)
+			require.NoError(t, ioutil.WriteFile(knownHostsFile, []byte(line+"\n"), 0600))
+		}
+
+		it("verifies the host key with the known hosts file", func() {
+			writeKnownHosts(hostKey.PublicKey())
+
+			validator := secret.DefaultCredentialValidator{KnownHostsFile: knownHostsFile}
+			require.NoError(t, validator.Validate(context.Background(), output, creds, ""))
+			require.Empty(t, output.String())
+		})
+
+		it("fails when the host key does not match the known hosts file", func() {
+			otherKey, err := ssh.NewSignerFromKey(newKey())
+			require.NoError(t, err)
+			writeKnownHosts(otherKey.PublicKey())
+
+			validator := secret.DefaultCredentialValidator{KnownHostsFile: knownHostsFile}
+			err = validator.Validate(context.Background(), output, creds, "")
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "key mismatch")
+		})
+
+		it("fails when the host is not in the known hosts file", func() {
+			require.NoError(t, ioutil.WriteFile(knownHostsFile, nil, 0600))
+
+			validator := secret.DefaultCredentialValidator{KnownHostsFile: knownHostsFile}
+			err := validator.Validate(context.Background(), output, creds, "")
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "the host key of 127.0.0.1 is not in "+knownHostsFile)
+		})
+
+		it("warns that the host key is not verified without a known hosts file", func() {
+			validator := secret.DefaultCredentialValidator{KnownHostsFile: knownHostsFile}
+			require.NoError(t, validator.Validate(context.Background(), output, creds, ""))
+			require.Equal(t, "Warning: the host key of 127.0.0.1 is not verified, "+knownHostsFile+" does not exist\n", output.String())
+		})
+	})
+}
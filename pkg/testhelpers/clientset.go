@@ -12,6 +12,21 @@ import (
 
 type FakeClientSetProvider struct {
 	clientSet k8s.ClientSet
+	contexts  map[string]k8s.ClientSet
+}
+
+// ForContext returns a provider of the client set registered for the context with WithContext.
+func (f FakeClientSetProvider) ForContext(context string) k8s.ClientSetProvider {
+	return FakeClientSetProvider{clientSet: f.contexts[context]}
+}
+
+func (f FakeClientSetProvider) WithContext(context string, provider FakeClientSetProvider) FakeClientSetProvider {
+	contexts := map[string]k8s.ClientSet{context: provider.clientSet}
+	for c, cs := range f.contexts {
+		contexts[c] = cs
+	}
+	f.contexts = contexts
+	return f
 }
 
 func (f FakeClientSetProvider) GetClientSet(namespace string) (clientSet k8s.ClientSet, err error) {
@@ -55,3 +70,13 @@ func GetFakeClusterProvider(k8sClient *k8sfakes.Clientset, kpackClient *kpackfak
 		},
 	}
 }
+
+func GetFakeK8sAndKpackProvider(k8sClient *k8sfakes.Clientset, kpackClient *kpackfakes.Clientset, namespace string) FakeClientSetProvider {
+	return FakeClientSetProvider{
+		clientSet: k8s.ClientSet{
+			K8sClient:   k8sClient,
+			KpackClient: kpackClient,
+			Namespace:   namespace,
+		},
+	}
+}
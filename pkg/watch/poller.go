@@ -0,0 +1,87 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/archive"
+)
+
+// Poller watches a directory by comparing snapshots of the paths, sizes and modification times of its files.
+// Files excluded by the .gitignore and .kpignore files or the exclude patterns are not watched.
+type Poller struct {
+	Interval time.Duration
+}
+
+// Watch sends on the returned channel once the files of the directory changed and then did not change
+// for the debounce duration. Changes made while a previous change was not received are coalesced.
+// The channel is closed when the context is done.
+func (p Poller) Watch(ctx context.Context, dir string, excludes []string, debounce time.Duration) (<-chan struct{}, error) {
+	ignorer, err := archive.NewIgnorer(dir, excludes...)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := snapshot(dir, ignorer)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(p.Interval)
+		defer ticker.Stop()
+
+		var changedAt time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := snapshot(dir, ignorer)
+			if err != nil {
+				continue
+			}
+
+			if current != last {
+				last = current
+				changedAt = time.Now()
+				continue
+			}
+
+			if !changedAt.IsZero() && time.Since(changedAt) >= debounce {
+				changedAt = time.Time{}
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+func snapshot(dir string, ignorer *archive.Ignorer) (string, error) {
+	files, err := archive.ListFiles(dir, ignorer)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, f := range files {
+		if _, err := fmt.Fprintf(h, "%s\x00%d\x00%d\n", f.Path, f.Size, f.ModTime.UnixNano()); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
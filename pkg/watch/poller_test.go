@@ -0,0 +1,85 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package watch_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/kpack-cli/pkg/watch"
+)
+
+func TestPoller(t *testing.T) {
+	spec.Run(t, "TestPoller", testPoller)
+}
+
+func testPoller(t *testing.T, when spec.G, it spec.S) {
+	var (
+		dir    string
+		ctx    context.Context
+		cancel context.CancelFunc
+		poller = watch.Poller{Interval: 10 * time.Millisecond}
+	)
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "poller-test")
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.js"), []byte("v1"), 0644))
+
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	it.After(func() {
+		cancel()
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	it("sends a change after the files change", func() {
+		changes, err := poller.Watch(ctx, dir, nil, 20*time.Millisecond)
+		require.NoError(t, err)
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.js"), []byte("v1"), 0644))
+
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a change")
+		}
+	})
+
+	it("does not send a change for excluded files", func() {
+		changes, err := poller.Watch(ctx, dir, []string{"*.log"}, 20*time.Millisecond)
+		require.NoError(t, err)
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "debug.log"), []byte("log"), 0644))
+
+		select {
+		case <-changes:
+			t.Fatal("expected no change")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	it("closes the channel when the context is done", func() {
+		changes, err := poller.Watch(ctx, dir, nil, 20*time.Millisecond)
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, ok := <-changes:
+			require.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the channel to be closed")
+		}
+	})
+}